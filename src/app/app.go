@@ -2,6 +2,7 @@ package app
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"peek/src/k8s"
+	"peek/src/settings"
 	"peek/src/styles"
 	"peek/src/ui"
 )
@@ -31,9 +33,25 @@ type Model struct {
 	loadingSpinner     *ui.LoadingSpinner
 	timeframeInputPane *ui.TimeframeInput
 	logsViewer         *ui.LogsViewer
+	containerPicker    *ui.ContainerPicker
+	inputDialog        *ui.InputDialog
+	logTailNamespace   string
 	confirmationDialog *ui.ConfirmationDialog
+	progressPanel      *ui.ProgressPanel
+	bulkTargets        []k8s.PodInfo    // pods a bulk action waits for confirmation on
+	bulkLabels         k8s.LabelChanges // label changes a bulk label action waits for confirmation on
+	bulk               *bulkJob
+	bulkRuns           int
 	yamlViewer         *ui.YAMLViewer
 	execTerminal       *ui.ExecTerminal
+	podDetail          *ui.PodDetailView
+	applicationDetail  *ui.ApplicationDetailView
+	scaleTarget        k8s.ApplicationInfo // application a scale waits for the replica count of
+	ownerTree          *ui.OwnerTreeView
+	debugDialog        *ui.DebugDialog
+	copyDialog         *ui.CopyDialog
+	debugRuns          int
+	tailRuns           int // log tails resolved, so only the latest opens the viewer
 	width              int
 	height             int
 	leftPaneWidth      int
@@ -53,13 +71,30 @@ func InitialModel() Model {
 	loadingSpinner := ui.NewLoadingSpinner("Connecting to Kubernetes cluster...")
 	timeframeInputPane := ui.NewTimeframeInput()
 	logsViewer := ui.NewLogsViewer()
+	containerPicker := ui.NewContainerPicker()
+	inputDialog := ui.NewInputDialog()
 	confirmationDialog := ui.NewConfirmationDialog()
+	progressPanel := ui.NewProgressPanel()
 	yamlViewer := ui.NewYAMLViewer()
 	execTerminal := ui.NewExecTerminal()
+	podDetail := ui.NewPodDetailView()
+	applicationDetail := ui.NewApplicationDetailView()
+	ownerTree := ui.NewOwnerTreeView()
+	debugDialog := ui.NewDebugDialog()
+	copyDialog := ui.NewCopyDialog()
 
 	// Connect notifications to right pane
 	rightPane.SetNotifications(notifications)
 
+	// Load persisted preferences; defaults are used if the file is missing or broken
+	userSettings, err := settings.Load()
+	if err != nil {
+		notifications.AddError("Settings", err.Error())
+	}
+	rightPane.SetSettings(userSettings)
+	logsViewer.SetSettings(userSettings)
+	debugDialog.SetImages(userSettings.Debug.Images)
+
 	return Model{
 		leftPane:           leftPane,
 		rightPane:          rightPane,
@@ -68,9 +103,17 @@ func InitialModel() Model {
 		loadingSpinner:     loadingSpinner,
 		timeframeInputPane: timeframeInputPane,
 		logsViewer:         logsViewer,
+		containerPicker:    containerPicker,
+		inputDialog:        inputDialog,
 		confirmationDialog: confirmationDialog,
+		progressPanel:      progressPanel,
 		yamlViewer:         yamlViewer,
 		execTerminal:       execTerminal,
+		podDetail:          podDetail,
+		applicationDetail:  applicationDetail,
+		ownerTree:          ownerTree,
+		debugDialog:        debugDialog,
+		copyDialog:         copyDialog,
 		leftPaneWidth:      leftPaneWidth,
 		width:              80,
 		height:             24,
@@ -81,10 +124,11 @@ func InitialModel() Model {
 }
 
 func (m Model) Init() tea.Cmd {
-	// Start both the connection check and ticker
+	// Start the connection check, the ticker and the wait for streamed logs
 	return tea.Batch(
 		connectToClusterCmd(),
 		tickCmd(),
+		m.logsViewer.WaitForLogs(),
 	)
 }
 
@@ -95,6 +139,51 @@ type connectionResultMsg struct {
 	contextSelector   *ui.ContextSelector
 	err               error
 }
+type logExportMsg struct {
+	path string
+	err  error
+}
+
+// bulkResultMsg reports how a bulk action went on the pod at index
+type bulkResultMsg struct {
+	run   int
+	index int
+	err   error
+}
+
+// logTailResolvedMsg carries the label selector a log tail target resolved to
+type logTailResolvedMsg struct {
+	run       int
+	context   string
+	namespace string
+	target    string
+	selector  string
+	err       error
+}
+
+// debugContainerMsg reports that a debug container was added to a pod, or that it is running
+type debugContainerMsg struct {
+	run       int
+	pod       k8s.PodInfo
+	container string
+	running   bool
+	err       error
+}
+
+// applicationActionMsg reports how a scale, restart or delete of an application went
+type applicationActionMsg struct {
+	action   string
+	app      k8s.ApplicationInfo
+	replicas int32
+	err      error
+}
+
+// debugSessionEndedMsg reports that the terminal attached to a debug container was closed
+type debugSessionEndedMsg struct {
+	pod       k8s.PodInfo
+	container string
+	err       error
+}
 type contextConnectionResultMsg struct {
 	context          string
 	namespaces       []string
@@ -219,6 +308,99 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case ui.LogStreamMsg:
+		m.logsViewer.HandleLogs(msg)
+		return m, m.logsViewer.WaitForLogs()
+
+	case logExportMsg:
+		if msg.err != nil {
+			m.notifications.AddError("Export Failed", msg.err.Error())
+		} else {
+			m.notifications.AddSuccess("Logs Exported", msg.path)
+		}
+		return m, nil
+
+	case bulkResultMsg:
+		cmd := m.handleBulkResult(msg)
+		return m, cmd
+
+	case debugContainerMsg:
+		cmd := m.handleDebugContainer(msg)
+		return m, cmd
+
+	case logTailResolvedMsg:
+		m.openLogTail(msg)
+		return m, nil
+
+	case applicationActionMsg:
+		cmd := m.handleApplicationAction(msg)
+		return m, cmd
+
+	case ui.MetricsSampledMsg:
+		m.rightPane.FinishSample(msg)
+		return m, nil
+
+	case ui.EventsArchivedMsg:
+		m.rightPane.FinishArchive(msg)
+		return m, nil
+
+	case ui.EventsQueriedMsg:
+		m.rightPane.FinishArchiveQuery(msg)
+		return m, nil
+
+	case ui.PodsLoadedMsg:
+		m.rightPane.FinishPodsLoad(msg)
+		return m, nil
+
+	case ui.NodesLoadedMsg:
+		m.rightPane.FinishNodesLoad(msg)
+		return m, nil
+
+	case ui.ApplicationsLoadedMsg:
+		m.rightPane.FinishApplicationsLoad(msg)
+		return m, nil
+
+	case ui.PodDescribedMsg:
+		m.podDetail.Finish(msg)
+		return m, nil
+
+	case ui.OwnerChainMsg:
+		m.ownerTree.FinishChain(msg)
+		return m, nil
+
+	case ui.OwnerChildrenMsg:
+		m.ownerTree.FinishChildren(msg)
+		return m, nil
+
+	case ui.ApplicationDescribedMsg:
+		m.applicationDetail.Finish(msg)
+		return m, nil
+
+	case ui.CopyDoneMsg:
+		m.copyDialog.Finish(msg)
+		switch {
+		case msg.Cancelled:
+			m.notifications.AddWarning("Copy Cancelled", fmt.Sprintf("Stopped copying %s", msg.From))
+		case msg.Err != nil:
+			m.notifications.AddError("Copy Failed", msg.Err.Error())
+		default:
+			m.notifications.AddSuccess("Copy Finished", fmt.Sprintf("Copied %s to %s", msg.From, msg.To))
+		}
+		return m, nil
+
+	case debugSessionEndedMsg:
+		if msg.err != nil {
+			m.notifications.AddError("Debug Session", msg.err.Error())
+		} else {
+			m.notifications.AddInfo("Debug Session Ended",
+				fmt.Sprintf("%s stays in pod %s until the pod is deleted", msg.container, msg.pod.Name))
+		}
+		cmds := []tea.Cmd{m.rightPane.UpdatePods()}
+		if m.podDetail.IsOpen() {
+			cmds = append(cmds, m.podDetail.Refresh())
+		}
+		return m, tea.Batch(cmds...)
+
 	case tickMsg:
 		// Clean up expired notifications on each tick
 		if m.notifications != nil {
@@ -235,31 +417,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.contextSelector.UpdateSpinner()
 		}
 
+		// Sample cluster metrics into the history store regardless of the selected view
+		cmds := []tea.Cmd{tickCmd()}
+		if m.isConnected && m.rightPane != nil {
+			cmds = append(cmds, m.rightPane.SampleMetrics(), m.rightPane.ArchiveEvents())
+		}
+
 		// Update applications if applications view is selected and we're connected
 		if m.isConnected && m.rightPane != nil &&
 			strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "applications") {
-			m.rightPane.UpdateApplications()
+			cmds = append(cmds, m.rightPane.UpdateApplications())
 		}
 
 		// Update pods if pods view is selected and we're connected
 		if m.isConnected && m.rightPane != nil &&
 			strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "pods") {
-			m.rightPane.UpdatePods()
+			cmds = append(cmds, m.rightPane.UpdatePods())
 		}
 
 		// Update nodes if nodes view is selected and we're connected
 		if m.isConnected && m.rightPane != nil &&
 			strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "nodes") {
-			m.rightPane.UpdateNodes()
+			cmds = append(cmds, m.rightPane.UpdateNodes())
 		}
 
-		// Update events if events view is selected and we're connected
+		// The events view refreshes itself from the watcher's buffer when it renders; only the
+		// archive is read in the background
 		if m.isConnected && m.rightPane != nil &&
 			strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "events") {
-			m.rightPane.UpdateEvents()
+			cmds = append(cmds, m.rightPane.QueryArchivedEvents())
 		}
 
-		return m, tickCmd()
+		return m, tea.Batch(cmds...)
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -270,7 +459,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		paneHeight := m.height - 1
 		m.leftPane.Height = paneHeight
 		m.leftPane.Width = m.leftPaneWidth
-		m.rightPane.Height = paneHeight
+		// The right pane lays its tables out in the space inside its border, below the top bar
+		m.rightPane.Height = m.height - 4
 		m.rightPane.Width = m.rightPaneWidth
 		m.footer.Width = m.width
 		return m, nil
@@ -337,8 +527,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case msg.Type == tea.KeyEscape:
 				m.confirmationDialog.Close()
 			case msg.String() == "enter":
+				bulk := m.confirmationDialog.IsBulk()
 				confirmed := m.confirmationDialog.Confirm()
-				if confirmed {
+				if confirmed && bulk {
+					cmd := m.startBulk(m.confirmationDialog.GetAction())
+					return m, cmd
+				} else if app := m.confirmationDialog.GetApplication(); confirmed && app != nil {
+					cmd := m.applicationAction(m.confirmationDialog.GetAction(), *app, 0)
+					return m, cmd
+				} else if confirmed {
 					// Execute the action
 					if selectedPod := m.rightPane.GetSelectedPod(); selectedPod != nil {
 						action := m.confirmationDialog.GetAction()
@@ -349,7 +546,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							} else if m.notifications != nil {
 								m.notifications.AddSuccess("Pod Deleted", fmt.Sprintf("Pod %s deleted successfully", selectedPod.Name))
 								// Refresh pods list
-								m.rightPane.UpdatePods()
+								cmd := m.rightPane.UpdatePods()
+								return m, cmd
 							}
 						} else if action == "restart" {
 							err := m.kubeConfig.RestartPod(m.kubeConfig.CurrentContext, selectedPod.Namespace, selectedPod.Name)
@@ -358,7 +556,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							} else if m.notifications != nil {
 								m.notifications.AddSuccess("Pod Restarted", fmt.Sprintf("Pod %s restarted successfully", selectedPod.Name))
 								// Refresh pods list
-								m.rightPane.UpdatePods()
+								cmd := m.rightPane.UpdatePods()
+								return m, cmd
 							}
 						}
 					}
@@ -371,6 +570,193 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Handle the bulk action progress panel if it's open
+		if m.progressPanel != nil && m.progressPanel.IsOpen() {
+			switch {
+			case msg.Type == tea.KeyEscape:
+				m.progressPanel.Close()
+			case msg.String() == "up":
+				m.progressPanel.ScrollUp()
+			case msg.String() == "down":
+				m.progressPanel.ScrollDown()
+			}
+			return m, nil
+		}
+
+		// Handle debug dialog if it's open
+		if m.debugDialog != nil && m.debugDialog.IsOpen() {
+			switch {
+			case msg.Type == tea.KeyEscape:
+				m.debugDialog.Close()
+			case msg.String() == "enter":
+				cmd := m.startDebug()
+				return m, cmd
+			case msg.String() == "up":
+				m.debugDialog.MoveUp()
+			case msg.String() == "down":
+				m.debugDialog.MoveDown()
+			case msg.String() == "left":
+				m.debugDialog.Left()
+			case msg.String() == "right":
+				m.debugDialog.Right()
+			case msg.Type == tea.KeyBackspace:
+				m.debugDialog.Backspace()
+			default:
+				m.debugDialog.AddChar(msg.String())
+			}
+			return m, nil
+		}
+
+		// Handle copy dialog if it's open; Esc cancels a running copy before it closes the dialog
+		if m.copyDialog != nil && m.copyDialog.IsOpen() {
+			switch {
+			case msg.Type == tea.KeyEscape:
+				if m.copyDialog.IsCopying() {
+					m.copyDialog.Cancel()
+				} else {
+					m.copyDialog.Close()
+				}
+			case msg.String() == "enter":
+				return m, m.copyDialog.Start(m.kubeConfig, m.kubeConfig.CurrentContext)
+			case msg.String() == "up":
+				m.copyDialog.MoveUp()
+			case msg.String() == "down":
+				m.copyDialog.MoveDown()
+			case msg.String() == "left":
+				m.copyDialog.Toggle(-1)
+			case msg.String() == "right":
+				m.copyDialog.Toggle(1)
+			case msg.Type == tea.KeyBackspace:
+				m.copyDialog.Backspace()
+			default:
+				m.copyDialog.AddChar(msg.String())
+			}
+			return m, nil
+		}
+
+		// Handle container picker if it's open
+		if m.containerPicker != nil && m.containerPicker.IsOpen() {
+			switch {
+			case msg.Type == tea.KeyEscape:
+				m.containerPicker.Close()
+			case msg.String() == "up":
+				m.containerPicker.MoveUp()
+			case msg.String() == "down":
+				m.containerPicker.MoveDown()
+			case msg.String() == "p":
+				m.containerPicker.TogglePrevious()
+			case msg.String() == "enter":
+				if container := m.containerPicker.GetSelectedContainer(); container != nil {
+					pod := m.containerPicker.GetPod()
+					m.logsViewer.Open(m.kubeConfig, m.kubeConfig.CurrentContext, pod.Namespace, pod.Name, container.Name, m.containerPicker.IsPrevious())
+				}
+				m.containerPicker.Close()
+			}
+			return m, nil
+		}
+
+		// Handle input dialog if it's open
+		if m.inputDialog != nil && m.inputDialog.IsOpen() {
+			switch {
+			case msg.Type == tea.KeyEscape:
+				m.inputDialog.Close()
+			case msg.String() == "enter":
+				input := m.inputDialog.GetInput()
+				action := m.inputDialog.GetAction()
+				m.inputDialog.Close()
+				switch action {
+				case "tail":
+					if input != "" {
+						cmd := m.tailLogs(input)
+						return m, cmd
+					}
+				case "selector":
+					// An empty selector lists all pods again
+					cmd, err := m.rightPane.SetPodsSelector(input)
+					if err != nil {
+						m.notifications.AddError("Invalid Selector", err.Error())
+					}
+					return m, cmd
+				case "applicationSelector":
+					// An empty selector lists all applications again
+					cmd, err := m.rightPane.SetApplicationsSelector(input)
+					if err != nil {
+						m.notifications.AddError("Invalid Selector", err.Error())
+					}
+					return m, cmd
+				case "label":
+					changes, err := k8s.ParseLabelChanges(input)
+					if err != nil {
+						m.notifications.AddError("Invalid Labels", err.Error())
+					} else {
+						m.bulkLabels = changes
+						m.confirmationDialog.OpenBulk("label", m.bulkTargets, changes.String())
+					}
+				case "scale":
+					replicas, err := strconv.ParseInt(input, 10, 32)
+					if err != nil || replicas < 0 {
+						m.notifications.AddError("Invalid Replicas", fmt.Sprintf("%q is not a replica count", input))
+					} else {
+						cmd := m.applicationAction("scale", m.scaleTarget, int32(replicas))
+						return m, cmd
+					}
+				}
+			case msg.Type == tea.KeyBackspace:
+				m.inputDialog.Backspace()
+			default:
+				if len(msg.String()) == 1 {
+					m.inputDialog.AddChar(msg.String())
+				}
+			}
+			return m, nil
+		}
+
+		// Handle the export choice in the logs viewer
+		if m.logsViewer != nil && m.logsViewer.IsOpen() && m.logsViewer.IsChoosingExport() {
+			scope := ""
+			switch msg.String() {
+			case "v":
+				scope = ui.LogExportVisible
+			case "b":
+				scope = ui.LogExportBuffer
+			case "a":
+				scope = ui.LogExportFull
+			case "esc":
+				m.logsViewer.CancelInput()
+			}
+			if scope == "" {
+				return m, nil
+			}
+			job, err := m.logsViewer.ExportJob(scope)
+			if err != nil {
+				m.notifications.AddError("Export Failed", err.Error())
+				return m, nil
+			}
+			m.notifications.AddInfo("Exporting Logs", "Writing logs to a file...")
+			return m, func() tea.Msg {
+				path, err := job()
+				return logExportMsg{path: path, err: err}
+			}
+		}
+
+		// Handle the search or filter being typed in the logs viewer
+		if m.logsViewer != nil && m.logsViewer.IsOpen() && m.logsViewer.IsEditing() {
+			switch {
+			case msg.Type == tea.KeyEscape:
+				m.logsViewer.CancelInput()
+			case msg.String() == "enter":
+				// An invalid filter stays open with its error shown
+				m.logsViewer.ConfirmInput()
+			case msg.Type == tea.KeyBackspace:
+				m.logsViewer.InputBackspace()
+			default:
+				if len(msg.String()) == 1 {
+					m.logsViewer.AddInputChar(msg.String())
+				}
+			}
+			return m, nil
+		}
+
 		// Handle logs viewer if it's open
 		if m.logsViewer != nil && m.logsViewer.IsOpen() {
 			switch {
@@ -386,6 +772,44 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.logsViewer.PageDown()
 			case msg.String() == "f":
 				m.logsViewer.ToggleFollow()
+			case msg.String() == "p":
+				m.logsViewer.TogglePrevious()
+			case msg.String() == "/":
+				m.logsViewer.StartSearch()
+			case msg.String() == "&":
+				m.logsViewer.StartFilter()
+			case msg.String() == "n":
+				m.logsViewer.NextMatch()
+			case msg.String() == "N":
+				m.logsViewer.PrevMatch()
+			case msg.String() == "left":
+				m.logsViewer.PanLeft()
+			case msg.String() == "right":
+				m.logsViewer.PanRight()
+			case msg.String() == "W":
+				if err := m.logsViewer.ToggleWrap(); err != nil {
+					m.notifications.AddError("Settings", err.Error())
+				}
+			case msg.String() == "t":
+				if err := m.logsViewer.CycleTimestamps(); err != nil {
+					m.notifications.AddError("Settings", err.Error())
+				}
+			case msg.String() == "L":
+				if err := m.logsViewer.ToggleLevelColors(); err != nil {
+					m.notifications.AddError("Settings", err.Error())
+				}
+			case msg.String() == "o":
+				m.logsViewer.StartOptions()
+			case msg.String() == "x":
+				m.logsViewer.StartExport()
+			case msg.String() == "w":
+				m.logsViewer.StartWhere()
+			case msg.String() == "c":
+				m.logsViewer.StartFields()
+			case msg.String() == "s":
+				m.logsViewer.ToggleStructured()
+			case msg.String() == "enter":
+				m.logsViewer.ToggleExpand()
 			}
 			return m, nil
 		}
@@ -416,6 +840,112 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Handle owner tree if it's open; YAML opens on top of it
+		if m.ownerTree != nil && m.ownerTree.IsOpen() {
+			switch {
+			case msg.Type == tea.KeyEscape:
+				m.ownerTree.Close()
+			case msg.String() == "up":
+				m.ownerTree.MoveUp()
+			case msg.String() == "down":
+				m.ownerTree.MoveDown()
+			case msg.String() == "left", msg.String() == "u":
+				m.ownerTree.Owner()
+			case msg.String() == "right", msg.String() == "enter":
+				cmd := m.ownerTree.Children()
+				return m, cmd
+			case msg.String() == " ":
+				m.ownerTree.ToggleExpand()
+			case msg.String() == "r":
+				cmd := m.ownerTree.Refresh()
+				return m, cmd
+			case msg.String() == "y":
+				if ref := m.ownerTree.GetSelected(); ref != nil {
+					m.yamlViewer.OpenObject(m.kubeConfig, m.kubeConfig.CurrentContext, *ref)
+				}
+			case msg.String() == "g":
+				if ref := m.ownerTree.GetSelected(); ref != nil && objectView(*ref) == "" {
+					// Keep the tree open rather than trading it for the YAML 'y' already shows
+					m.notifications.AddInfo("No Table", fmt.Sprintf("%s objects have no table, press 'y' for the YAML", ref.Kind))
+				} else if ref != nil {
+					m.ownerTree.Close()
+					m.podDetail.Close()
+					m.applicationDetail.Close()
+					m.jumpToObject(*ref)
+				}
+			}
+			return m, nil
+		}
+
+		// Handle pod detail view if it's open; logs and YAML open on top of it
+		if m.podDetail != nil && m.podDetail.IsOpen() {
+			switch {
+			case msg.Type == tea.KeyEscape:
+				m.podDetail.Close()
+			case msg.String() == "up":
+				m.podDetail.ScrollUp()
+			case msg.String() == "down":
+				m.podDetail.ScrollDown()
+			case msg.String() == "pgup":
+				m.podDetail.PageUp()
+			case msg.String() == "pgdn":
+				m.podDetail.PageDown()
+			case msg.String() == "r":
+				cmd := m.podDetail.Refresh()
+				return m, cmd
+			case msg.String() == "l":
+				m.openPodLogs(m.podDetail.GetPod())
+			case msg.String() == "y":
+				pod := m.podDetail.GetPod()
+				m.yamlViewer.Open(m.kubeConfig, m.kubeConfig.CurrentContext, pod.Namespace, pod.Name)
+			case msg.String() == "D":
+				m.debugDialog.Open(m.podDetail.GetPod())
+			case msg.String() == "c":
+				m.copyDialog.Open(m.podDetail.GetPod())
+			case msg.String() == "u":
+				cmd := m.openOwnerTree(m.podDetail.GetPod())
+				return m, cmd
+			}
+			return m, nil
+		}
+
+		// Handle application detail view if it's open; its pods open in the pod detail on top of it
+		if m.applicationDetail != nil && m.applicationDetail.IsOpen() {
+			switch {
+			case msg.Type == tea.KeyEscape:
+				m.applicationDetail.Close()
+			case msg.String() == "up":
+				m.applicationDetail.MoveUp()
+			case msg.String() == "down":
+				m.applicationDetail.MoveDown()
+			case msg.String() == "pgup":
+				m.applicationDetail.PageUp()
+			case msg.String() == "pgdn":
+				m.applicationDetail.PageDown()
+			case msg.String() == "enter":
+				if pod := m.applicationDetail.GetSelectedPod(); pod != nil {
+					cmd := m.podDetail.Open(m.kubeConfig, m.kubeConfig.CurrentContext, *pod)
+					return m, cmd
+				}
+			case msg.String() == "l":
+				if pod := m.applicationDetail.GetSelectedPod(); pod != nil {
+					m.openPodLogs(*pod)
+				}
+			case msg.String() == "r":
+				cmd := m.applicationDetail.Refresh()
+				return m, cmd
+			case msg.String() == "y":
+				m.yamlViewer.OpenObject(m.kubeConfig, m.kubeConfig.CurrentContext, m.applicationDetail.GetApplication().Reference())
+			case msg.String() == "s":
+				m.openScale(m.applicationDetail.GetApplication())
+			case msg.String() == "R":
+				m.confirmRestart(m.applicationDetail.GetApplication())
+			case msg.String() == "d":
+				m.confirmationDialog.OpenApplication("delete", m.applicationDetail.GetApplication())
+			}
+			return m, nil
+		}
+
 		// Handle timeframe input if it's open
 		if m.timeframeInputPane != nil && m.timeframeInputPane.IsOpen() {
 			switch {
@@ -430,7 +960,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							m.notifications.AddError("Invalid Input", err.Error())
 						} else if m.notifications != nil {
 							m.notifications.AddSuccess("Timeframe Updated",
-								fmt.Sprintf("Now showing events from the past %s minutes", input))
+								fmt.Sprintf("Now showing events from %s", eventsTable.TimeframeDescription()))
 						}
 					}
 				}
@@ -479,6 +1009,56 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Handle typing in the pods search
+		if m.focusedPane == FocusRightPane && m.rightPane != nil && m.rightPane.IsPodsSearchMode() &&
+			strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "pods") {
+			switch {
+			case msg.Type == tea.KeyEscape:
+				m.rightPane.TogglePodsSearch()
+			case msg.String() == "enter":
+				m.rightPane.FinishPodsSearch()
+			case msg.String() == "up":
+				m.rightPane.MovePodsUp()
+			case msg.String() == "down":
+				m.rightPane.MovePodsDown()
+			case msg.Type == tea.KeyBackspace:
+				if query := m.rightPane.GetPodsSearchQuery(); len(query) > 0 {
+					m.rightPane.UpdatePodsSearch(query[:len(query)-1])
+				}
+			default:
+				if len(msg.String()) == 1 {
+					m.rightPane.UpdatePodsSearch(m.rightPane.GetPodsSearchQuery() + msg.String())
+				}
+			}
+			return m, nil
+		}
+
+		// Handle the events filter bar if it's being edited
+		if eventsTable := m.rightPane.GetEventsTable(); eventsTable != nil && eventsTable.IsFilterEditing() {
+			switch {
+			case msg.Type == tea.KeyEscape:
+				eventsTable.StopFilterEditing()
+			case msg.String() == "enter":
+				if err := eventsTable.GetFilterError(); err != nil {
+					m.notifications.AddError("Invalid Filter", err.Error())
+				} else {
+					eventsTable.StopFilterEditing()
+					if err := m.rightPane.SetEventFilter(eventsTable.GetFilterInput()); err != nil {
+						m.notifications.AddError("Events Filter", err.Error())
+					}
+				}
+			case msg.Type == tea.KeyBackspace:
+				if input := eventsTable.GetFilterInput(); len(input) > 0 {
+					eventsTable.UpdateFilterInput(input[:len(input)-1])
+				}
+			default:
+				if len(msg.String()) == 1 {
+					eventsTable.UpdateFilterInput(eventsTable.GetFilterInput() + msg.String())
+				}
+			}
+			return m, nil
+		}
+
 		if m.leftPane.SearchMode && m.focusedPane == FocusLeftPane {
 			switch {
 			case msg.String() == "1":
@@ -533,30 +1113,80 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.rightPane.SetSearchMode(m.leftPane.SearchMode)
 				} else if m.focusedPane == FocusRightPane && strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "pods") {
 					m.rightPane.TogglePodsSearch()
+				} else if m.focusedPane == FocusRightPane && strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "events") {
+					if eventsTable := m.rightPane.GetEventsTable(); eventsTable != nil {
+						eventsTable.StartFilterEditing()
+					}
 				}
 			case "up":
 				if m.focusedPane == FocusLeftPane {
 					m.leftPane.MoveUp()
 				} else if m.focusedPane == FocusRightPane && strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "pods") {
 					m.rightPane.MovePodsUp()
+				} else if m.focusedPane == FocusRightPane && strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "events") {
+					if eventsTable := m.rightPane.GetEventsTable(); eventsTable != nil {
+						eventsTable.MoveUp()
+					}
+				} else if m.focusedPane == FocusRightPane && strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "nodes") {
+					m.rightPane.MoveNodesUp()
+				} else if m.focusedPane == FocusRightPane && strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "applications") {
+					m.rightPane.MoveApplicationsUp()
 				}
 			case "down":
 				if m.focusedPane == FocusLeftPane {
 					m.leftPane.MoveDown()
 				} else if m.focusedPane == FocusRightPane && strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "pods") {
 					m.rightPane.MovePodsDown()
+				} else if m.focusedPane == FocusRightPane && strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "events") {
+					if eventsTable := m.rightPane.GetEventsTable(); eventsTable != nil {
+						eventsTable.MoveDown()
+					}
+				} else if m.focusedPane == FocusRightPane && strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "nodes") {
+					m.rightPane.MoveNodesDown()
+				} else if m.focusedPane == FocusRightPane && strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "applications") {
+					m.rightPane.MoveApplicationsDown()
 				}
 			case "l":
 				// Handle logs command for pods view
 				if m.focusedPane == FocusRightPane && m.rightPane != nil &&
 					strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "pods") {
 					if selectedPod := m.rightPane.GetSelectedPod(); selectedPod != nil {
-						containerName := ""
-						if len(selectedPod.Containers) > 0 {
-							containerName = selectedPod.Containers[0].Name
+						m.openPodLogs(*selectedPod)
+					}
+				}
+			case "L":
+				// Tail the marked pods, or every pod of a workload or label selector in pods view
+				if m.focusedPane == FocusRightPane && m.rightPane != nil &&
+					strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "pods") {
+					if marked := m.rightPane.GetMarkedPods(); len(marked) > 0 {
+						m.tailPods(marked)
+						break
+					}
+					m.logTailNamespace = m.namespaceSelector.GetSelectedNamespaceRaw()
+					initial := ""
+					if selectedPod := m.rightPane.GetSelectedPod(); selectedPod != nil {
+						m.logTailNamespace = selectedPod.Namespace
+						// Start from the workload that owns the selected pod
+						if len(selectedPod.OwnerReferences) > 0 {
+							initial = strings.ToLower(selectedPod.OwnerReferences[0])
 						}
-						m.logsViewer.Open(m.kubeConfig, m.kubeConfig.CurrentContext, selectedPod.Namespace, selectedPod.Name, containerName)
 					}
+					m.inputDialog.Open("tail", "Tail Logs",
+						"deployment/name or a label selector (e.g., app=api)",
+						"Workloads: deployment, statefulset, daemonset, replicaset, job", initial)
+				}
+			case "f":
+				// Filter pods or applications by label and field selectors in their views
+				if m.focusedPane == FocusRightPane && m.rightPane != nil &&
+					strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "pods") {
+					m.inputDialog.Open("selector", "Pod Selector",
+						"app=web,tier!=cache,env in (prod),status.phase=Running",
+						"Labels and metadata./spec./status. fields • Empty to show all pods", m.rightPane.GetPodsSelector())
+				} else if m.focusedPane == FocusRightPane && m.rightPane != nil &&
+					strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "applications") {
+					m.inputDialog.Open("applicationSelector", "Application Selector",
+						"app=web,tier!=cache,env in (prod),metadata.name=api",
+						"Labels and metadata./spec./status. fields • Empty to show all applications", m.rightPane.GetApplicationsSelector())
 				}
 			case "e":
 				// Handle exec command for pods view
@@ -566,29 +1196,110 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						m.execTerminal.Open(m.kubeConfig.CurrentContext, selectedPod.Namespace, selectedPod.Name)
 					}
 				}
-			case "d":
-				// Handle delete command for pods view
+			case "D":
+				// Debug the selected pod with an ephemeral container in pods view
+				if m.focusedPane == FocusRightPane && m.rightPane != nil &&
+					strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "pods") {
+					if selectedPod := m.rightPane.GetSelectedPod(); selectedPod != nil {
+						m.debugDialog.Open(*selectedPod)
+					}
+				}
+			case "u":
+				// Show the owners of the selected pod up to its workload in pods view
 				if m.focusedPane == FocusRightPane && m.rightPane != nil &&
 					strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "pods") {
 					if selectedPod := m.rightPane.GetSelectedPod(); selectedPod != nil {
+						cmd := m.openOwnerTree(*selectedPod)
+						return m, cmd
+					}
+				}
+			case "c":
+				// Copy files to or from the selected pod in pods view
+				if m.focusedPane == FocusRightPane && m.rightPane != nil &&
+					strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "pods") {
+					if selectedPod := m.rightPane.GetSelectedPod(); selectedPod != nil {
+						m.copyDialog.Open(*selectedPod)
+					}
+				}
+			case "d":
+				// Handle delete command for pods and applications view
+				if m.focusedPane == FocusRightPane && m.rightPane != nil &&
+					strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "pods") {
+					if marked := m.rightPane.GetMarkedPods(); len(marked) > 0 {
+						m.confirmBulk("delete", marked)
+					} else if selectedPod := m.rightPane.GetSelectedPod(); selectedPod != nil {
 						m.confirmationDialog.Open("delete", selectedPod.Name, selectedPod.Namespace)
 					}
+				} else if m.focusedPane == FocusRightPane && m.rightPane != nil &&
+					strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "applications") {
+					if app := m.rightPane.GetSelectedApplication(); app != nil {
+						m.confirmationDialog.OpenApplication("delete", *app)
+					}
 				}
 			case "r":
-				// Handle restart command for pods view
+				// Handle restart command for pods and applications view
 				if m.focusedPane == FocusRightPane && m.rightPane != nil &&
 					strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "pods") {
-					if selectedPod := m.rightPane.GetSelectedPod(); selectedPod != nil {
+					if marked := m.rightPane.GetMarkedPods(); len(marked) > 0 {
+						m.confirmBulk("restart", marked)
+					} else if selectedPod := m.rightPane.GetSelectedPod(); selectedPod != nil {
 						m.confirmationDialog.Open("restart", selectedPod.Name, selectedPod.Namespace)
 					}
+				} else if m.focusedPane == FocusRightPane && m.rightPane != nil &&
+					strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "applications") {
+					if app := m.rightPane.GetSelectedApplication(); app != nil {
+						m.confirmRestart(*app)
+					}
+				}
+			case "s":
+				// Scale the selected application in applications view
+				if m.focusedPane == FocusRightPane && m.rightPane != nil &&
+					strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "applications") {
+					if app := m.rightPane.GetSelectedApplication(); app != nil {
+						m.openScale(*app)
+					}
+				}
+			case "T":
+				// Label the marked pods, or the selected one, in pods view
+				if m.focusedPane == FocusRightPane && m.rightPane != nil &&
+					strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "pods") {
+					targets := m.rightPane.GetMarkedPods()
+					if len(targets) == 0 {
+						if selectedPod := m.rightPane.GetSelectedPod(); selectedPod != nil {
+							targets = []k8s.PodInfo{*selectedPod}
+						}
+					}
+					if len(targets) > 0 {
+						m.bulkTargets = targets
+						m.inputDialog.Open("label", fmt.Sprintf("Label %d Pods", len(targets)),
+							"key=value to set, key- to remove (e.g., tier=cache,stale-)",
+							"Changes apply to every pod after one confirmation", "")
+					}
+				}
+			case " ":
+				// Mark the selected pod for a bulk action in pods view
+				if m.focusedPane == FocusRightPane && m.rightPane != nil &&
+					strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "pods") {
+					m.rightPane.TogglePodMark()
+				}
+			case "a":
+				// Mark every pod the search and selector show in pods view
+				if m.focusedPane == FocusRightPane && m.rightPane != nil &&
+					strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "pods") {
+					m.rightPane.MarkAllPods()
 				}
 			case "y":
-				// Handle YAML view command for pods view
+				// Handle YAML view command for pods and applications view
 				if m.focusedPane == FocusRightPane && m.rightPane != nil &&
 					strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "pods") {
 					if selectedPod := m.rightPane.GetSelectedPod(); selectedPod != nil {
 						m.yamlViewer.Open(m.kubeConfig, m.kubeConfig.CurrentContext, selectedPod.Namespace, selectedPod.Name)
 					}
+				} else if m.focusedPane == FocusRightPane && m.rightPane != nil &&
+					strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "applications") {
+					if app := m.rightPane.GetSelectedApplication(); app != nil {
+						m.yamlViewer.OpenObject(m.kubeConfig, m.kubeConfig.CurrentContext, app.Reference())
+					}
 				}
 			case "t":
 				// Handle timeframe adjustment for events view
@@ -598,6 +1309,36 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						m.timeframeInputPane.Open()
 					}
 				}
+			case "A":
+				// Toggle the on-disk event archive for events view
+				if m.focusedPane == FocusRightPane && m.rightPane != nil &&
+					strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "events") {
+					enabled, err := m.rightPane.ToggleEventArchive()
+					if err != nil {
+						m.notifications.AddError("Event Archive", err.Error())
+					} else if enabled {
+						archive := m.rightPane.GetEventArchive()
+						m.notifications.AddSuccess("Event Archive Enabled",
+							fmt.Sprintf("Events are kept for %d days in %s",
+								int(archive.Retention().Hours()/24), archive.Dir()))
+					} else {
+						m.notifications.AddInfo("Event Archive Disabled", "Events already archived are kept on disk")
+					}
+				}
+			case "o", "O":
+				// Sort the table by the next column, or flip the direction
+				if m.focusedPane == FocusRightPane && m.rightPane != nil {
+					if err := m.rightPane.CycleSort(msg.String() == "O"); err != nil {
+						m.notifications.AddError("Settings", err.Error())
+					}
+				}
+			case "g":
+				// Toggle grouping by object and reason for events view
+				if m.focusedPane == FocusRightPane && strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "events") {
+					if eventsTable := m.rightPane.GetEventsTable(); eventsTable != nil {
+						eventsTable.ToggleGrouped()
+					}
+				}
 			case "enter":
 				if m.focusedPane == FocusLeftPane {
 					resourceSelected := m.leftPane.ToggleExpand()
@@ -606,12 +1347,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if resourceSelected {
 						m.focusedPane = FocusRightPane
 					}
+				} else if strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "pods") {
+					// Describe the selected pod
+					if selectedPod := m.rightPane.GetSelectedPod(); selectedPod != nil {
+						cmd := m.podDetail.Open(m.kubeConfig, m.kubeConfig.CurrentContext, *selectedPod)
+						return m, cmd
+					}
+				} else if strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "applications") {
+					// Show the pods, conditions and events of the selected application
+					if app := m.rightPane.GetSelectedApplication(); app != nil {
+						cmd := m.applicationDetail.Open(m.kubeConfig, m.kubeConfig.CurrentContext, *app)
+						return m, cmd
+					}
+				} else if strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "events") {
+					// Expand or collapse the selected group in grouped events view,
+					// otherwise jump to the object the event is about
+					if eventsTable := m.rightPane.GetEventsTable(); eventsTable != nil && !eventsTable.ToggleExpand() {
+						if event := eventsTable.GetSelectedEvent(); event != nil {
+							m.jumpToObject(event.InvolvedObject)
+						}
+					}
 				}
 			}
 			switch msg.Type {
 			case tea.KeyEscape:
 				if m.focusedPane == FocusLeftPane {
 					m.leftPane.Collapse()
+				} else if strings.Contains(strings.ToLower(m.leftPane.SelectedItem), "pods") {
+					m.rightPane.ClearPodMarks()
 				}
 			}
 		}
@@ -620,6 +1383,366 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// tailLogs opens the logs of every pod matching a workload such as "deployment/api" or a label selector
+func (m *Model) tailLogs(target string) tea.Cmd {
+	m.tailRuns++
+	run := m.tailRuns
+	kubeConfig, contextName, namespace := m.kubeConfig, m.kubeConfig.CurrentContext, m.logTailNamespace
+	return func() tea.Msg {
+		selector, err := kubeConfig.ResolveLogSelector(contextName, namespace, target)
+		return logTailResolvedMsg{run: run, context: contextName, namespace: namespace, target: target, selector: selector, err: err}
+	}
+}
+
+// openLogTail opens the logs of the pods a tail target resolved to, unless another tail was started
+// or the context switched since
+func (m *Model) openLogTail(msg logTailResolvedMsg) {
+	if msg.run != m.tailRuns || msg.context != m.kubeConfig.CurrentContext {
+		return
+	}
+	if msg.err != nil {
+		m.notifications.AddError("Tail Logs", msg.err.Error())
+		return
+	}
+	if msg.selector == "" || msg.selector == "<none>" {
+		m.notifications.AddError("Tail Logs", fmt.Sprintf("%s selects every pod, refusing to tail them all", msg.target))
+		return
+	}
+	m.logsViewer.OpenAggregate(m.kubeConfig, msg.context, msg.namespace, msg.selector, msg.target)
+}
+
+// openPodLogs opens the logs of a pod, asking which container when there is a choice
+func (m *Model) openPodLogs(pod k8s.PodInfo) {
+	if len(pod.Containers) > 1 {
+		m.containerPicker.Open(pod)
+		return
+	}
+	containerName := ""
+	if len(pod.Containers) > 0 {
+		containerName = pod.Containers[0].Name
+	}
+	m.logsViewer.Open(m.kubeConfig, m.kubeConfig.CurrentContext, pod.Namespace, pod.Name, containerName, false)
+}
+
+// openOwnerTree shows the chain of owners of a pod; the returned command resolves them
+func (m *Model) openOwnerTree(pod k8s.PodInfo) tea.Cmd {
+	return m.ownerTree.Open(m.kubeConfig, m.kubeConfig.CurrentContext, k8s.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Namespace:  pod.Namespace,
+		Name:       pod.Name,
+	})
+}
+
+// tailPods opens the logs of a chosen set of pods
+func (m *Model) tailPods(pods []k8s.PodInfo) {
+	keys := make([]string, len(pods))
+	namespace := pods[0].Namespace
+	for i, pod := range pods {
+		keys[i] = pod.Namespace + "/" + pod.Name
+		if pod.Namespace != namespace {
+			// The tailer watches each of the pods' namespaces
+			namespace = ""
+		}
+	}
+	m.logsViewer.OpenPods(m.kubeConfig, m.kubeConfig.CurrentContext, namespace, keys, fmt.Sprintf("%d marked pods", len(pods)))
+}
+
+// startDebug adds the debug container chosen in the debug dialog to its pod
+func (m *Model) startDebug() tea.Cmd {
+	if m.debugDialog.IsStarting() {
+		return nil
+	}
+	image := m.debugDialog.GetImage()
+	if image == "" {
+		m.debugDialog.SetErrorText("Type the image to run")
+		return nil
+	}
+
+	m.debugRuns++
+	run := m.debugRuns
+	pod, target := m.debugDialog.GetPod(), m.debugDialog.GetTarget()
+	kubeConfig, contextName := m.kubeConfig, m.kubeConfig.CurrentContext
+	m.debugDialog.SetStarting("debug container")
+	return func() tea.Msg {
+		container, err := kubeConfig.AddDebugContainer(contextName, pod.Namespace, pod.Name, image, target)
+		return debugContainerMsg{run: run, pod: pod, container: container, err: err}
+	}
+}
+
+// handleDebugContainer waits for an added debug container to run and then attaches to it. A
+// container whose dialog was closed meanwhile is left running without attaching.
+func (m *Model) handleDebugContainer(msg debugContainerMsg) tea.Cmd {
+	waiting := msg.run == m.debugRuns && m.debugDialog.IsOpen()
+	if msg.err != nil {
+		if waiting {
+			m.debugDialog.SetError(msg.err)
+		} else {
+			m.notifications.AddError("Debug Container", msg.err.Error())
+		}
+		return nil
+	}
+
+	kubeConfig, contextName := m.kubeConfig, m.kubeConfig.CurrentContext
+	if !msg.running {
+		if waiting {
+			m.debugDialog.SetStarting(msg.container)
+		}
+		return func() tea.Msg {
+			err := kubeConfig.WaitForDebugContainer(contextName, msg.pod.Namespace, msg.pod.Name, msg.container)
+			return debugContainerMsg{run: msg.run, pod: msg.pod, container: msg.container, running: true, err: err}
+		}
+	}
+
+	if !waiting {
+		m.notifications.AddInfo("Debug Container Running",
+			fmt.Sprintf("%s in pod %s, attach with: kubectl attach -it -n %s %s -c %s",
+				msg.container, msg.pod.Name, msg.pod.Namespace, msg.pod.Name, msg.container))
+		return nil
+	}
+	m.debugDialog.Close()
+
+	session, err := kubeConfig.AttachContainer(contextName, msg.pod.Namespace, msg.pod.Name, msg.container)
+	if err != nil {
+		m.notifications.AddError("Debug Container", err.Error())
+		return nil
+	}
+	return tea.Exec(session, func(err error) tea.Msg {
+		return debugSessionEndedMsg{pod: msg.pod, container: msg.container, err: err}
+	})
+}
+
+// openScale asks for the new replica count of an application that has one
+func (m *Model) openScale(app k8s.ApplicationInfo) {
+	if !k8s.CanScale(app.Type) {
+		m.notifications.AddWarning("Cannot Scale", fmt.Sprintf("A %s has no replica count to scale", app.Type))
+		return
+	}
+	m.scaleTarget = app
+	m.inputDialog.Open("scale", fmt.Sprintf("Scale %s %s", app.Type, app.Name), "number of replicas",
+		fmt.Sprintf("Currently %d desired, %d ready • 0 stops every pod", app.Replicas, app.ReadyReplicas),
+		strconv.Itoa(int(app.Replicas)))
+}
+
+// confirmRestart asks before rolling out the pods of an application again
+func (m *Model) confirmRestart(app k8s.ApplicationInfo) {
+	if !k8s.CanRestart(app.Type) {
+		m.notifications.AddWarning("Cannot Restart", fmt.Sprintf("A %s cannot be restarted; delete its pods instead", app.Type))
+		return
+	}
+	m.confirmationDialog.OpenApplication("restart", app)
+}
+
+// applicationAction scales, restarts or deletes an application in the background
+func (m *Model) applicationAction(action string, app k8s.ApplicationInfo, replicas int32) tea.Cmd {
+	kubeConfig, contextName := m.kubeConfig, m.kubeConfig.CurrentContext
+	return func() tea.Msg {
+		var err error
+		switch action {
+		case "scale":
+			err = kubeConfig.ScaleApplication(contextName, app.Namespace, app.Type, app.Name, replicas)
+		case "restart":
+			err = kubeConfig.RestartApplication(contextName, app.Namespace, app.Type, app.Name)
+		case "delete":
+			err = kubeConfig.DeleteApplication(contextName, app.Namespace, app.Type, app.Name)
+		}
+		return applicationActionMsg{action: action, app: app, replicas: replicas, err: err}
+	}
+}
+
+// handleApplicationAction reports how an application action went and refreshes what shows it
+func (m *Model) handleApplicationAction(msg applicationActionMsg) tea.Cmd {
+	name := strings.ToLower(msg.app.Type) + "/" + msg.app.Name
+	if msg.err != nil {
+		m.notifications.AddError("Application Action Failed", fmt.Sprintf("Could not %s %s: %v", msg.action, name, msg.err))
+		return nil
+	}
+
+	switch msg.action {
+	case "scale":
+		m.notifications.AddSuccess("Application Scaled", fmt.Sprintf("Scaled %s to %d replicas", name, msg.replicas))
+	case "restart":
+		m.notifications.AddSuccess("Application Restarted", fmt.Sprintf("Rolling out new pods for %s", name))
+	case "delete":
+		m.notifications.AddSuccess("Application Deleted", fmt.Sprintf("Deleted %s", name))
+	}
+
+	cmd := m.rightPane.UpdateApplications()
+	if m.applicationDetail.IsOpen() {
+		current := m.applicationDetail.GetApplication()
+		sameApp := current.Type == msg.app.Type && current.Namespace == msg.app.Namespace && current.Name == msg.app.Name
+		if sameApp && msg.action == "delete" {
+			m.applicationDetail.Close()
+		} else {
+			return tea.Batch(cmd, m.applicationDetail.Refresh())
+		}
+	}
+	return cmd
+}
+
+// bulkConcurrency is how many pods a bulk action works on at the same time
+const bulkConcurrency = 5
+
+// bulkJob is a bulk action running over a set of pods, a few at a time
+type bulkJob struct {
+	run   int
+	verb  string // what was done, for the summary, e.g. "Deleted"
+	pods  []k8s.PodInfo
+	apply func(pod k8s.PodInfo) error
+	next  int
+	done  int
+}
+
+// confirmBulk asks once for an action on the marked pods
+func (m *Model) confirmBulk(action string, pods []k8s.PodInfo) {
+	m.bulkTargets = pods
+	m.bulkLabels = nil
+	m.confirmationDialog.OpenBulk(action, pods, "")
+}
+
+// startBulk runs a confirmed bulk action over the pods waiting for it and opens the progress panel
+func (m *Model) startBulk(action string) tea.Cmd {
+	pods := m.bulkTargets
+	m.bulkTargets = nil
+	if m.bulk != nil {
+		m.notifications.AddWarning("Bulk Action Running", "Wait for the current bulk action to finish")
+		return nil
+	}
+
+	kubeConfig, contextName := m.kubeConfig, m.kubeConfig.CurrentContext
+	var title, verb string
+	var apply func(pod k8s.PodInfo) error
+	switch action {
+	case "delete":
+		title = fmt.Sprintf("🗑  Deleting %d pods", len(pods))
+		verb = "Deleted"
+		apply = func(pod k8s.PodInfo) error {
+			return kubeConfig.DeletePod(contextName, pod.Namespace, pod.Name)
+		}
+	case "restart":
+		title = fmt.Sprintf("🔄 Restarting %d pods", len(pods))
+		verb = "Restarted"
+		apply = func(pod k8s.PodInfo) error {
+			return kubeConfig.RestartPod(contextName, pod.Namespace, pod.Name)
+		}
+	case "label":
+		changes := m.bulkLabels
+		title = fmt.Sprintf("🏷  Labelling %d pods: %s", len(pods), changes)
+		verb = "Labelled"
+		apply = func(pod k8s.PodInfo) error {
+			return kubeConfig.LabelPod(contextName, pod.Namespace, pod.Name, changes)
+		}
+	default:
+		return nil
+	}
+
+	names := make([]string, len(pods))
+	for i, pod := range pods {
+		names[i] = pod.Namespace + "/" + pod.Name
+	}
+	m.bulkRuns++
+	m.bulk = &bulkJob{run: m.bulkRuns, verb: verb, pods: pods, apply: apply}
+	m.progressPanel.Start(title, names)
+
+	cmds := make([]tea.Cmd, 0, bulkConcurrency)
+	for range min(bulkConcurrency, len(pods)) {
+		cmds = append(cmds, m.nextBulkCmd())
+	}
+	return tea.Batch(cmds...)
+}
+
+// nextBulkCmd applies the bulk action to the next pod that has not been started
+func (m *Model) nextBulkCmd() tea.Cmd {
+	job := m.bulk
+	if job == nil || job.next >= len(job.pods) {
+		return nil
+	}
+	index, pod, apply, run := job.next, job.pods[job.next], job.apply, job.run
+	job.next++
+	return func() tea.Msg {
+		return bulkResultMsg{run: run, index: index, err: apply(pod)}
+	}
+}
+
+// handleBulkResult records a pod's result and starts the next pod, or wraps up when all are done
+func (m *Model) handleBulkResult(msg bulkResultMsg) tea.Cmd {
+	job := m.bulk
+	if job == nil || job.run != msg.run {
+		return nil
+	}
+	m.progressPanel.Report(msg.index, msg.err)
+	job.done++
+	if job.done < len(job.pods) {
+		return m.nextBulkCmd()
+	}
+
+	m.bulk = nil
+	succeeded, failed, _ := m.progressPanel.Counts()
+	summary := fmt.Sprintf("%s %d of %d pods", job.verb, succeeded, len(job.pods))
+	if failed > 0 {
+		m.notifications.AddWarning("Bulk Action Finished", fmt.Sprintf("%s, %d failed", summary, failed))
+	} else {
+		m.notifications.AddSuccess("Bulk Action Finished", summary)
+		m.rightPane.ClearPodMarks()
+	}
+	return m.rightPane.UpdatePods()
+}
+
+// objectView returns the navigation item whose table lists objects of the referenced kind, or ""
+// for kinds that have no table
+func objectView(ref k8s.ObjectReference) string {
+	switch {
+	case ref.Kind == "Pod" && (ref.APIVersion == "" || ref.APIVersion == "v1"):
+		return "Pods"
+	case ref.Kind == "Node" && (ref.APIVersion == "" || ref.APIVersion == "v1"):
+		return "Nodes"
+	case (ref.Kind == "Deployment" || ref.Kind == "StatefulSet" || ref.Kind == "DaemonSet" || ref.Kind == "ReplicaSet") &&
+		(ref.APIVersion == "" || ref.APIVersion == "apps/v1"):
+		return "Applications"
+	case (ref.Kind == "Job" || ref.Kind == "CronJob") && (ref.APIVersion == "" || ref.APIVersion == "batch/v1"):
+		return "Applications"
+	}
+	return ""
+}
+
+// jumpToObject shows the object an event refers to: its table with the cursor on it, or its YAML
+// for kinds that have no table
+func (m *Model) jumpToObject(ref k8s.ObjectReference) {
+	if ref.Kind == "" || ref.Name == "" {
+		m.notifications.AddError("No Object", "This event does not reference an object")
+		return
+	}
+
+	view := objectView(ref)
+	if view == "" {
+		m.yamlViewer.OpenObject(m.kubeConfig, m.kubeConfig.CurrentContext, ref)
+		return
+	}
+
+	// Namespaced tables only list the selected namespace, so switch to the object's namespace
+	if ref.Namespace != "" && m.namespaceSelector != nil {
+		if current := m.namespaceSelector.GetSelectedNamespaceRaw(); current != "" && current != ref.Namespace {
+			m.namespaceSelector.SetSelectedNamespace(ref.Namespace)
+			m.rightPane.SetNamespace(ref.Namespace)
+			m.notifications.AddInfo("Namespace changed", fmt.Sprintf("Now using namespace: %s", ref.Namespace))
+		}
+	}
+
+	m.leftPane.SelectItem(view)
+	m.rightPane.SetSelectedItem(m.leftPane.SelectedItem)
+	switch view {
+	case "Pods":
+		m.rightPane.SelectPod(ref.Namespace, ref.Name)
+	case "Nodes":
+		m.rightPane.SelectNode(ref.Name)
+	case "Applications":
+		m.rightPane.SelectApplication(ref.Kind, ref.Namespace, ref.Name)
+	}
+
+	m.rightPane.SetSearchMode(m.leftPane.SearchMode)
+	m.focusedPane = FocusRightPane
+}
+
 func (m Model) View() string {
 	if m.width == 0 || m.height == 0 {
 		return "Loading..."
@@ -791,6 +1914,31 @@ func (m Model) View() string {
 		return m.renderWithOverlay(fullUI, confirmationOverlay)
 	}
 
+	if m.progressPanel != nil && m.progressPanel.IsOpen() {
+		progressOverlay := m.progressPanel.Render(m.width, m.height)
+		return m.renderWithOverlay(fullUI, progressOverlay)
+	}
+
+	if m.debugDialog != nil && m.debugDialog.IsOpen() {
+		debugOverlay := m.debugDialog.Render(m.width, m.height)
+		return m.renderWithOverlay(fullUI, debugOverlay)
+	}
+
+	if m.copyDialog != nil && m.copyDialog.IsOpen() {
+		copyOverlay := m.copyDialog.Render(m.width, m.height)
+		return m.renderWithOverlay(fullUI, copyOverlay)
+	}
+
+	if m.containerPicker != nil && m.containerPicker.IsOpen() {
+		pickerOverlay := m.containerPicker.Render(m.width, m.height)
+		return m.renderWithOverlay(fullUI, pickerOverlay)
+	}
+
+	if m.inputDialog != nil && m.inputDialog.IsOpen() {
+		inputOverlay := m.inputDialog.Render(m.width, m.height)
+		return m.renderWithOverlay(fullUI, inputOverlay)
+	}
+
 	if m.logsViewer != nil && m.logsViewer.IsOpen() {
 		logsOverlay := m.logsViewer.Render(m.width, m.height)
 		return m.renderWithOverlay(fullUI, logsOverlay)
@@ -806,6 +1954,21 @@ func (m Model) View() string {
 		return m.renderWithOverlay(fullUI, execOverlay)
 	}
 
+	if m.ownerTree != nil && m.ownerTree.IsOpen() {
+		treeOverlay := m.ownerTree.Render(m.width, m.height)
+		return m.renderWithOverlay(fullUI, treeOverlay)
+	}
+
+	if m.podDetail != nil && m.podDetail.IsOpen() {
+		detailOverlay := m.podDetail.Render(m.width, m.height)
+		return m.renderWithOverlay(fullUI, detailOverlay)
+	}
+
+	if m.applicationDetail != nil && m.applicationDetail.IsOpen() {
+		applicationOverlay := m.applicationDetail.Render(m.width, m.height)
+		return m.renderWithOverlay(fullUI, applicationOverlay)
+	}
+
 	if m.timeframeInputPane != nil && m.timeframeInputPane.IsOpen() {
 		// Render the timeframe input as an overlay over the main UI
 		timeframeOverlay := m.timeframeInputPane.Render(m.width, m.height)
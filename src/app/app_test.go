@@ -0,0 +1,161 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"peek/src/k8s"
+	"peek/src/ui"
+)
+
+func TestConfirmedBulkActionStarts(t *testing.T) {
+	m := Model{
+		kubeConfig:         &k8s.KubeConfig{CurrentContext: "test"},
+		notifications:      ui.NewNotificationManager(),
+		confirmationDialog: ui.NewConfirmationDialog(),
+		progressPanel:      ui.NewProgressPanel(),
+	}
+	pods := []k8s.PodInfo{
+		{Name: "api-1", Namespace: "default"},
+		{Name: "api-2", Namespace: "default"},
+	}
+	m.confirmBulk("delete", pods)
+	m.confirmationDialog.MoveLeft() // Yes
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+
+	if m.confirmationDialog.IsOpen() {
+		t.Error("confirmation dialog is still open")
+	}
+	if m.bulk == nil {
+		t.Fatal("bulk action did not start")
+	}
+	if len(m.bulk.pods) != len(pods) {
+		t.Errorf("bulk action runs over %d pods, want %d", len(m.bulk.pods), len(pods))
+	}
+	if !m.progressPanel.IsOpen() {
+		t.Error("progress panel is not open")
+	}
+	if cmd == nil {
+		t.Error("no command was returned to run the action")
+	}
+}
+
+func TestBulkActionRunsToCompletion(t *testing.T) {
+	m := Model{
+		kubeConfig:         &k8s.KubeConfig{CurrentContext: "test"},
+		notifications:      ui.NewNotificationManager(),
+		confirmationDialog: ui.NewConfirmationDialog(),
+		progressPanel:      ui.NewProgressPanel(),
+		rightPane:          ui.NewRightPane(80, 24),
+	}
+	var pods []k8s.PodInfo
+	for _, name := range []string{"api-1", "api-2", "api-3", "api-4", "api-5", "api-6", "api-7"} {
+		pods = append(pods, k8s.PodInfo{Name: name, Namespace: "default"})
+	}
+	m.confirmBulk("restart", pods)
+	m.confirmationDialog.MoveLeft() // Yes
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.bulk == nil {
+		t.Fatal("bulk action did not start")
+	}
+	if m.bulk.next != bulkConcurrency {
+		t.Fatalf("started %d pods at once, want %d", m.bulk.next, bulkConcurrency)
+	}
+	run := m.bulk.run
+
+	// A result of an earlier run is dropped
+	updated, _ = m.Update(bulkResultMsg{run: run - 1, index: 0})
+	m = updated.(Model)
+	if _, _, pending := m.progressPanel.Counts(); pending != len(pods) {
+		t.Fatalf("%d pods pending after a stale result, want %d", pending, len(pods))
+	}
+
+	for i := range pods {
+		var err error
+		if i == 3 {
+			err = fmt.Errorf("forbidden")
+		}
+		var cmd tea.Cmd
+		updated, cmd = m.Update(bulkResultMsg{run: run, index: i, err: err})
+		m = updated.(Model)
+		// Each result starts the next pod that has not been started
+		if i < len(pods)-bulkConcurrency && cmd == nil {
+			t.Errorf("result %d did not start the next pod", i)
+		}
+	}
+
+	if m.bulk != nil {
+		t.Error("bulk action still running after every pod reported")
+	}
+	succeeded, failed, pending := m.progressPanel.Counts()
+	if succeeded != len(pods)-1 || failed != 1 || pending != 0 {
+		t.Errorf("counts %d succeeded, %d failed, %d pending, want %d, 1, 0", succeeded, failed, pending, len(pods)-1)
+	}
+}
+
+func TestDeclinedBulkActionDoesNotStart(t *testing.T) {
+	m := Model{
+		kubeConfig:         &k8s.KubeConfig{CurrentContext: "test"},
+		notifications:      ui.NewNotificationManager(),
+		confirmationDialog: ui.NewConfirmationDialog(),
+		progressPanel:      ui.NewProgressPanel(),
+	}
+	m.confirmBulk("delete", []k8s.PodInfo{{Name: "api-1", Namespace: "default"}})
+
+	// The dialog defaults to "No"
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+
+	if m.bulk != nil {
+		t.Error("bulk action started although it was declined")
+	}
+}
+
+func TestConfirmedApplicationActionRuns(t *testing.T) {
+	for _, action := range []string{"delete", "restart"} {
+		m := Model{
+			kubeConfig:         &k8s.KubeConfig{CurrentContext: "test"},
+			notifications:      ui.NewNotificationManager(),
+			confirmationDialog: ui.NewConfirmationDialog(),
+		}
+		app := k8s.ApplicationInfo{Name: "api", Type: "Deployment", Namespace: "default"}
+		m.confirmationDialog.OpenApplication(action, app)
+		m.confirmationDialog.MoveLeft() // Yes
+
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = updated.(Model)
+
+		if m.confirmationDialog.IsOpen() {
+			t.Errorf("%s: confirmation dialog is still open", action)
+		}
+		if cmd == nil {
+			t.Errorf("%s: no command was returned to run the action", action)
+		}
+	}
+}
+
+func TestObjectView(t *testing.T) {
+	tests := []struct {
+		ref  k8s.ObjectReference
+		want string
+	}{
+		{k8s.ObjectReference{Kind: "Pod", APIVersion: "v1"}, "Pods"},
+		{k8s.ObjectReference{Kind: "Node"}, "Nodes"},
+		{k8s.ObjectReference{Kind: "Deployment", APIVersion: "apps/v1"}, "Applications"},
+		{k8s.ObjectReference{Kind: "ReplicaSet", APIVersion: "apps/v1"}, "Applications"},
+		{k8s.ObjectReference{Kind: "CronJob", APIVersion: "batch/v1"}, "Applications"},
+		{k8s.ObjectReference{Kind: "Deployment", APIVersion: "example.com/v1"}, ""},
+		{k8s.ObjectReference{Kind: "Service", APIVersion: "v1"}, ""},
+	}
+	for _, tt := range tests {
+		if got := objectView(tt.ref); got != tt.want {
+			t.Errorf("objectView(%s %s) = %q, want %q", tt.ref.APIVersion, tt.ref.Kind, got, tt.want)
+		}
+	}
+}
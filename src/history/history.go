@@ -0,0 +1,160 @@
+package history
+
+import (
+	"sync"
+	"time"
+)
+
+// Metric names recorded by the cluster sampler
+const (
+	PodsRunning   = "pods.running"
+	PodsPending   = "pods.pending"
+	PodsFailed    = "pods.failed"
+	NodesReady    = "nodes.ready"
+	NodesNotReady = "nodes.notready"
+	WarningEvents = "events.warning"
+	CPUUsage      = "usage.cpu"
+	MemUsage      = "usage.memory"
+)
+
+// Sample is a single timestamped value
+type Sample struct {
+	Time  time.Time
+	Value int64
+}
+
+// Series is a fixed-capacity ring buffer of samples, oldest first
+type Series struct {
+	samples []Sample
+	start   int
+	count   int
+}
+
+// NewSeries creates a series that keeps at most capacity samples
+func NewSeries(capacity int) *Series {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &Series{
+		samples: make([]Sample, capacity),
+	}
+}
+
+// Add appends a sample, overwriting the oldest one when the buffer is full
+func (s *Series) Add(t time.Time, value int64) {
+	index := (s.start + s.count) % len(s.samples)
+	s.samples[index] = Sample{Time: t, Value: value}
+	if s.count < len(s.samples) {
+		s.count++
+	} else {
+		s.start = (s.start + 1) % len(s.samples)
+	}
+}
+
+// Len returns the number of samples currently held
+func (s *Series) Len() int {
+	return s.count
+}
+
+// Since returns the samples taken at or after t, oldest first
+func (s *Series) Since(t time.Time) []Sample {
+	var result []Sample
+	for i := 0; i < s.count; i++ {
+		sample := s.samples[(s.start+i)%len(s.samples)]
+		if !sample.Time.Before(t) {
+			result = append(result, sample)
+		}
+	}
+	return result
+}
+
+// Latest returns the most recent sample
+func (s *Series) Latest() (Sample, bool) {
+	if s.count == 0 {
+		return Sample{}, false
+	}
+	return s.samples[(s.start+s.count-1)%len(s.samples)], true
+}
+
+// Store holds named series sampled at a fixed interval; it is safe for concurrent use
+type Store struct {
+	mu       sync.RWMutex
+	series   map[string]*Series
+	capacity int
+	interval time.Duration
+}
+
+// NewStore creates a store that keeps retention worth of samples taken every interval. Like
+// time.NewTicker, it panics when the interval is not positive.
+func NewStore(retention, interval time.Duration) *Store {
+	if interval <= 0 {
+		panic("history: non-positive interval for NewStore")
+	}
+	return &Store{
+		series:   make(map[string]*Series),
+		capacity: int(retention / interval),
+		interval: interval,
+	}
+}
+
+// Interval returns the sampling interval
+func (st *Store) Interval() time.Duration {
+	return st.interval
+}
+
+// Retention returns how far back the store keeps samples
+func (st *Store) Retention() time.Duration {
+	return time.Duration(st.capacity) * st.interval
+}
+
+// Record adds a sample to the named series, creating it if needed
+func (st *Store) Record(name string, t time.Time, value int64) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	series, ok := st.series[name]
+	if !ok {
+		series = NewSeries(st.capacity)
+		st.series[name] = series
+	}
+	series.Add(t, value)
+}
+
+// Values returns the values of the named series within the given window, oldest first
+func (st *Store) Values(name string, window time.Duration) []int64 {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+
+	series, ok := st.series[name]
+	if !ok {
+		return nil
+	}
+
+	samples := series.Since(time.Now().Add(-window))
+	values := make([]int64, len(samples))
+	for i, sample := range samples {
+		values[i] = sample.Value
+	}
+	return values
+}
+
+// Latest returns the most recent value of the named series
+func (st *Store) Latest(name string) (int64, bool) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+
+	series, ok := st.series[name]
+	if !ok {
+		return 0, false
+	}
+	sample, ok := series.Latest()
+	return sample.Value, ok
+}
+
+// Reset drops all series, e.g. after switching contexts
+func (st *Store) Reset() {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.series = make(map[string]*Series)
+}
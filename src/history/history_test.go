@@ -0,0 +1,92 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSeriesSince(t *testing.T) {
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return base.Add(time.Duration(seconds) * time.Second) }
+
+	tests := []struct {
+		name     string
+		capacity int
+		added    int // samples added at 0s, 1s, 2s, ... with the value of their second
+		since    time.Time
+		want     []int64
+	}{
+		{name: "empty", capacity: 4, added: 0, since: at(0), want: nil},
+		{name: "not wrapped", capacity: 4, added: 3, since: at(0), want: []int64{0, 1, 2}},
+		{name: "full", capacity: 4, added: 4, since: at(0), want: []int64{0, 1, 2, 3}},
+		{name: "wrapped", capacity: 4, added: 6, since: at(0), want: []int64{2, 3, 4, 5}},
+		{name: "wrapped twice", capacity: 4, added: 11, since: at(0), want: []int64{7, 8, 9, 10}},
+		{name: "cut mid ring", capacity: 4, added: 6, since: at(4), want: []int64{4, 5}},
+		{name: "cut at start of ring", capacity: 4, added: 6, since: at(3), want: []int64{3, 4, 5}},
+		{name: "cut between samples", capacity: 4, added: 6, since: at(3).Add(time.Millisecond), want: []int64{4, 5}},
+		{name: "cut after latest", capacity: 4, added: 6, since: at(6), want: nil},
+		{name: "capacity clamped", capacity: 0, added: 3, since: at(0), want: []int64{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series := NewSeries(tt.capacity)
+			for i := 0; i < tt.added; i++ {
+				series.Add(at(i), int64(i))
+			}
+			if want := min(tt.added, max(tt.capacity, 1)); series.Len() != want {
+				t.Errorf("Len() = %d, want %d", series.Len(), want)
+			}
+
+			samples := series.Since(tt.since)
+			if len(samples) != len(tt.want) {
+				t.Fatalf("Since() returned %d samples, want %v", len(samples), tt.want)
+			}
+			for i, sample := range samples {
+				if sample.Value != tt.want[i] || !sample.Time.Equal(at(int(tt.want[i]))) {
+					t.Errorf("sample %d = %d at %s, want %d", i, sample.Value, sample.Time, tt.want[i])
+				}
+			}
+
+			latest, ok := series.Latest()
+			if ok != (tt.added > 0) || (ok && latest.Value != int64(tt.added-1)) {
+				t.Errorf("Latest() = %d, %v, want %d", latest.Value, ok, tt.added-1)
+			}
+		})
+	}
+}
+
+func TestNewStoreRejectsNonPositiveInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewStore(time.Minute, %s) did not panic", interval)
+				}
+			}()
+			NewStore(time.Minute, interval)
+		}()
+	}
+}
+
+func TestStoreRetention(t *testing.T) {
+	st := NewStore(time.Minute, 10*time.Second)
+	if got := st.Retention(); got != time.Minute {
+		t.Errorf("Retention() = %s, want %s", got, time.Minute)
+	}
+
+	now := time.Now()
+	for i := 10; i >= 0; i-- {
+		st.Record(PodsRunning, now.Add(-time.Duration(i)*10*time.Second), int64(10-i))
+	}
+	// The store keeps 6 samples; of those, the last 3 fall in the window
+	if got := st.Values(PodsRunning, 25*time.Second); len(got) != 3 || got[0] != 8 || got[2] != 10 {
+		t.Errorf("Values() = %v, want [8 9 10]", got)
+	}
+	if got := st.Values(PodsRunning, time.Hour); len(got) != 6 || got[0] != 5 {
+		t.Errorf("Values() = %v, want the 6 retained samples from 5", got)
+	}
+	if got, ok := st.Latest(PodsRunning); !ok || got != 10 {
+		t.Errorf("Latest() = %d, %v, want 10", got, ok)
+	}
+}
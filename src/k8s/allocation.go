@@ -0,0 +1,163 @@
+package k8s
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// nonTerminalPodsSelector selects pods that still hold their resource requests on a node
+const nonTerminalPodsSelector = "status.phase!=Succeeded,status.phase!=Failed"
+
+// calculateAllocations sums the requests and limits of non-terminal pods per node and per namespace
+func calculateAllocations(nodes []corev1.Node, pods []corev1.Pod) (byNode, byNamespace []ResourceAllocation) {
+	nodeAllocations := make(map[string]*ResourceAllocation)
+	for _, node := range nodes {
+		allocation := &ResourceAllocation{Name: node.Name}
+		if cpu, ok := node.Status.Allocatable[corev1.ResourceCPU]; ok {
+			allocation.CPUAllocatable = cpu.MilliValue()
+		}
+		if mem, ok := node.Status.Allocatable[corev1.ResourceMemory]; ok {
+			allocation.MemAllocatable = mem.Value()
+		}
+		nodeAllocations[node.Name] = allocation
+	}
+
+	namespaceAllocations := make(map[string]*ResourceAllocation)
+	for i := range pods {
+		pod := &pods[i]
+		if isTerminalPod(pod) {
+			continue
+		}
+
+		requests, limits := podRequestsAndLimits(pod)
+
+		// Pods that are not scheduled yet only count towards their namespace
+		if allocation, ok := nodeAllocations[pod.Spec.NodeName]; ok {
+			allocation.add(requests, limits)
+		}
+
+		allocation, ok := namespaceAllocations[pod.Namespace]
+		if !ok {
+			allocation = &ResourceAllocation{Name: pod.Namespace}
+			namespaceAllocations[pod.Namespace] = allocation
+		}
+		allocation.add(requests, limits)
+	}
+
+	for _, allocation := range nodeAllocations {
+		byNode = append(byNode, *allocation)
+	}
+	sort.Slice(byNode, func(i, j int) bool {
+		return byNode[i].Name < byNode[j].Name
+	})
+
+	for _, allocation := range namespaceAllocations {
+		byNamespace = append(byNamespace, *allocation)
+	}
+	// Heaviest namespaces first
+	sort.Slice(byNamespace, func(i, j int) bool {
+		if byNamespace[i].CPURequests != byNamespace[j].CPURequests {
+			return byNamespace[i].CPURequests > byNamespace[j].CPURequests
+		}
+		return byNamespace[i].Name < byNamespace[j].Name
+	})
+
+	return byNode, byNamespace
+}
+
+// add accumulates one pod's requests and limits
+func (a *ResourceAllocation) add(requests, limits corev1.ResourceList) {
+	a.Pods++
+	if cpu, ok := requests[corev1.ResourceCPU]; ok {
+		a.CPURequests += cpu.MilliValue()
+	}
+	if mem, ok := requests[corev1.ResourceMemory]; ok {
+		a.MemRequests += mem.Value()
+	}
+	if cpu, ok := limits[corev1.ResourceCPU]; ok {
+		a.CPULimits += cpu.MilliValue()
+	}
+	if mem, ok := limits[corev1.ResourceMemory]; ok {
+		a.MemLimits += mem.Value()
+	}
+}
+
+// Overcommitted reports whether the summed limits exceed the allocatable resources
+func (a ResourceAllocation) Overcommitted() bool {
+	return (a.CPUAllocatable > 0 && a.CPULimits > a.CPUAllocatable) ||
+		(a.MemAllocatable > 0 && a.MemLimits > a.MemAllocatable)
+}
+
+// podRequestsAndLimits computes the effective requests and limits of a pod the way the scheduler
+// accounts for them: the larger of the app containers (plus sidecars) and any single init
+// container, plus the pod overhead
+func podRequestsAndLimits(pod *corev1.Pod) (requests, limits corev1.ResourceList) {
+	requests = corev1.ResourceList{}
+	limits = corev1.ResourceList{}
+
+	for _, container := range pod.Spec.Containers {
+		addResourceList(requests, container.Resources.Requests)
+		addResourceList(limits, container.Resources.Limits)
+	}
+
+	// Sidecars (restartable init containers) keep running next to the app containers,
+	// while regular init containers run one at a time alongside the sidecars started before them
+	sidecarRequests := corev1.ResourceList{}
+	sidecarLimits := corev1.ResourceList{}
+	initRequests := corev1.ResourceList{}
+	initLimits := corev1.ResourceList{}
+	for _, container := range pod.Spec.InitContainers {
+		if container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			addResourceList(sidecarRequests, container.Resources.Requests)
+			addResourceList(sidecarLimits, container.Resources.Limits)
+			continue
+		}
+
+		stepRequests := corev1.ResourceList{}
+		stepLimits := corev1.ResourceList{}
+		addResourceList(stepRequests, container.Resources.Requests)
+		addResourceList(stepLimits, container.Resources.Limits)
+		addResourceList(stepRequests, sidecarRequests)
+		addResourceList(stepLimits, sidecarLimits)
+		maxResourceList(initRequests, stepRequests)
+		maxResourceList(initLimits, stepLimits)
+	}
+	addResourceList(requests, sidecarRequests)
+	addResourceList(limits, sidecarLimits)
+	maxResourceList(requests, initRequests)
+	maxResourceList(limits, initLimits)
+
+	if pod.Spec.Overhead != nil {
+		addResourceList(requests, pod.Spec.Overhead)
+		addResourceList(limits, pod.Spec.Overhead)
+	}
+
+	return requests, limits
+}
+
+// addResourceList adds every quantity in src to dst
+func addResourceList(dst, src corev1.ResourceList) {
+	for name, quantity := range src {
+		if existing, ok := dst[name]; ok {
+			existing.Add(quantity)
+			dst[name] = existing
+		} else {
+			dst[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+// maxResourceList sets each quantity in dst to the larger of dst and src
+func maxResourceList(dst, src corev1.ResourceList) {
+	for name, quantity := range src {
+		if existing, ok := dst[name]; !ok || quantity.Cmp(existing) > 0 {
+			dst[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+// isTerminalPod reports whether a pod has finished and released its resources
+func isTerminalPod(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
@@ -0,0 +1,174 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// restartedAtAnnotation is the pod template annotation `kubectl rollout restart` sets; changing it
+// makes the controller replace every pod
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// CanScale reports whether a type of application has a replica count that can be changed
+func CanScale(appType string) bool {
+	switch appType {
+	case "Deployment", "StatefulSet", "ReplicaSet":
+		return true
+	}
+	return false
+}
+
+// CanRestart reports whether a type of application can roll out its pods again. ReplicaSets do not
+// replace pods when their template changes, and jobs run to completion instead.
+func CanRestart(appType string) bool {
+	switch appType {
+	case "Deployment", "StatefulSet", "DaemonSet":
+		return true
+	}
+	return false
+}
+
+func (k *KubeConfig) applicationClient(contextName string) (*kubernetes.Clientset, error) {
+	// Create a temporary client config for the specified context
+	tempConfig := clientcmd.NewNonInteractiveClientConfig(
+		*k.config,
+		contextName,
+		&clientcmd.ConfigOverrides{},
+		nil,
+	)
+
+	restConfig, err := tempConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client config: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+	return clientset, nil
+}
+
+// ScaleApplication sets the replica count of a Deployment, StatefulSet or ReplicaSet through its
+// scale subresource, like `kubectl scale`
+func (k *KubeConfig) ScaleApplication(contextName, namespace, appType, name string, replicas int32) error {
+	if !CanScale(appType) {
+		return fmt.Errorf("%s has no replica count to scale", appType)
+	}
+	if replicas < 0 {
+		return fmt.Errorf("replicas must not be negative, got %d", replicas)
+	}
+
+	clientset, err := k.applicationClient(contextName)
+	if err != nil {
+		return err
+	}
+
+	patch := []byte(fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	switch appType {
+	case "Deployment":
+		_, err = clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}, "scale")
+	case "StatefulSet":
+		_, err = clientset.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}, "scale")
+	case "ReplicaSet":
+		_, err = clientset.AppsV1().ReplicaSets(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}, "scale")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to scale %s: %w", appType, err)
+	}
+
+	return nil
+}
+
+// RestartApplication rolls out the pods of a Deployment, StatefulSet or DaemonSet again, like
+// `kubectl rollout restart`, so they are replaced following the update strategy
+func (k *KubeConfig) RestartApplication(contextName, namespace, appType, name string) error {
+	if !CanRestart(appType) {
+		return fmt.Errorf("%s cannot be restarted", appType)
+	}
+
+	clientset, err := k.applicationClient(contextName)
+	if err != nil {
+		return err
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"spec": map[string]any{
+			"template": map[string]any{
+				"metadata": map[string]any{
+					"annotations": map[string]string{
+						restartedAtAnnotation: time.Now().Format(time.RFC3339),
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build restart patch: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	switch appType {
+	case "Deployment":
+		_, err = clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "StatefulSet":
+		_, err = clientset.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "DaemonSet":
+		_, err = clientset.AppsV1().DaemonSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to restart %s: %w", appType, err)
+	}
+
+	return nil
+}
+
+// DeleteApplication deletes a workload. Its pods, and the jobs of a CronJob, are deleted in the
+// background as kubectl does; the API would otherwise orphan the pods of a Job.
+func (k *KubeConfig) DeleteApplication(contextName, namespace, appType, name string) error {
+	clientset, err := k.applicationClient(contextName)
+	if err != nil {
+		return err
+	}
+
+	propagation := metav1.DeletePropagationBackground
+	options := metav1.DeleteOptions{PropagationPolicy: &propagation}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	switch appType {
+	case "Deployment":
+		err = clientset.AppsV1().Deployments(namespace).Delete(ctx, name, options)
+	case "StatefulSet":
+		err = clientset.AppsV1().StatefulSets(namespace).Delete(ctx, name, options)
+	case "DaemonSet":
+		err = clientset.AppsV1().DaemonSets(namespace).Delete(ctx, name, options)
+	case "ReplicaSet":
+		err = clientset.AppsV1().ReplicaSets(namespace).Delete(ctx, name, options)
+	case "Job":
+		err = clientset.BatchV1().Jobs(namespace).Delete(ctx, name, options)
+	case "CronJob":
+		err = clientset.BatchV1().CronJobs(namespace).Delete(ctx, name, options)
+	default:
+		return fmt.Errorf("unsupported application type %q", appType)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", appType, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,308 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DescribeApplication gathers the detail of a workload: its rollout strategy and images, its
+// conditions, the pods its selector matches and its events. The pods of a CronJob are those of
+// the jobs it created.
+func (k *KubeConfig) DescribeApplication(contextName, namespace, appType, name string) (*ApplicationDescription, error) {
+	clientset, err := k.applicationClient(contextName)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var description *ApplicationDescription
+	var selectors []*metav1.LabelSelector
+	switch appType {
+	case "Deployment":
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment: %w", err)
+		}
+		description = describeDeployment(deployment)
+		selectors = append(selectors, deployment.Spec.Selector)
+	case "StatefulSet":
+		statefulSet, err := clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get statefulset: %w", err)
+		}
+		description = describeStatefulSet(statefulSet)
+		selectors = append(selectors, statefulSet.Spec.Selector)
+	case "DaemonSet":
+		daemonSet, err := clientset.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get daemonset: %w", err)
+		}
+		description = describeDaemonSet(daemonSet)
+		selectors = append(selectors, daemonSet.Spec.Selector)
+	case "ReplicaSet":
+		replicaSet, err := clientset.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get replicaset: %w", err)
+		}
+		description = describeReplicaSet(replicaSet)
+		selectors = append(selectors, replicaSet.Spec.Selector)
+	case "Job":
+		job, err := clientset.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get job: %w", err)
+		}
+		description = describeJob(job)
+		selectors = append(selectors, job.Spec.Selector)
+	case "CronJob":
+		cronJob, err := clientset.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cronjob: %w", err)
+		}
+		description = describeCronJob(cronJob)
+		selectors, description.PodsError = cronJobSelectors(ctx, clientset, cronJob)
+	default:
+		return nil, fmt.Errorf("unsupported application type %q", appType)
+	}
+	description.Application.Health = applicationHealth(description.Application)
+
+	if description.PodsError == nil {
+		description.Pods, description.PodsError = selectedPods(ctx, clientset, namespace, selectors)
+	}
+	description.Events, description.EventsError = objectEvents(ctx, clientset, appType, namespace, name, types.UID(description.UID))
+	return description, nil
+}
+
+func describeDeployment(deployment *appsv1.Deployment) *ApplicationDescription {
+	strategy := string(deployment.Spec.Strategy.Type)
+	if rolling := deployment.Spec.Strategy.RollingUpdate; rolling != nil {
+		strategy += fmt.Sprintf(" (max surge %s, max unavailable %s)",
+			formatIntOrString(rolling.MaxSurge), formatIntOrString(rolling.MaxUnavailable))
+	}
+
+	description := &ApplicationDescription{
+		Application:       deploymentApplication(deployment),
+		UID:               string(deployment.UID),
+		Selector:          metav1.FormatLabelSelector(deployment.Spec.Selector),
+		Images:            templateImages(deployment.Spec.Template),
+		Strategy:          strategy,
+		UpdatedReplicas:   deployment.Status.UpdatedReplicas,
+		AvailableReplicas: deployment.Status.AvailableReplicas,
+	}
+	for _, condition := range deployment.Status.Conditions {
+		description.Conditions = append(description.Conditions, PodConditionInfo{
+			Type:               string(condition.Type),
+			Status:             string(condition.Status),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: condition.LastTransitionTime.Time,
+		})
+	}
+	return description
+}
+
+func describeStatefulSet(statefulSet *appsv1.StatefulSet) *ApplicationDescription {
+	strategy := string(statefulSet.Spec.UpdateStrategy.Type)
+	if rolling := statefulSet.Spec.UpdateStrategy.RollingUpdate; rolling != nil && rolling.Partition != nil && *rolling.Partition > 0 {
+		strategy += fmt.Sprintf(" (partition %d)", *rolling.Partition)
+	}
+
+	description := &ApplicationDescription{
+		Application:       statefulSetApplication(statefulSet),
+		UID:               string(statefulSet.UID),
+		Selector:          metav1.FormatLabelSelector(statefulSet.Spec.Selector),
+		Images:            templateImages(statefulSet.Spec.Template),
+		Strategy:          strategy,
+		UpdatedReplicas:   statefulSet.Status.UpdatedReplicas,
+		AvailableReplicas: statefulSet.Status.AvailableReplicas,
+	}
+	for _, condition := range statefulSet.Status.Conditions {
+		description.Conditions = append(description.Conditions, PodConditionInfo{
+			Type:               string(condition.Type),
+			Status:             string(condition.Status),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: condition.LastTransitionTime.Time,
+		})
+	}
+	return description
+}
+
+func describeDaemonSet(daemonSet *appsv1.DaemonSet) *ApplicationDescription {
+	strategy := string(daemonSet.Spec.UpdateStrategy.Type)
+	if rolling := daemonSet.Spec.UpdateStrategy.RollingUpdate; rolling != nil {
+		strategy += fmt.Sprintf(" (max surge %s, max unavailable %s)",
+			formatIntOrString(rolling.MaxSurge), formatIntOrString(rolling.MaxUnavailable))
+	}
+
+	description := &ApplicationDescription{
+		Application:       daemonSetApplication(daemonSet),
+		UID:               string(daemonSet.UID),
+		Selector:          metav1.FormatLabelSelector(daemonSet.Spec.Selector),
+		Images:            templateImages(daemonSet.Spec.Template),
+		Strategy:          strategy,
+		UpdatedReplicas:   daemonSet.Status.UpdatedNumberScheduled,
+		AvailableReplicas: daemonSet.Status.NumberAvailable,
+	}
+	for _, condition := range daemonSet.Status.Conditions {
+		description.Conditions = append(description.Conditions, PodConditionInfo{
+			Type:               string(condition.Type),
+			Status:             string(condition.Status),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: condition.LastTransitionTime.Time,
+		})
+	}
+	return description
+}
+
+func describeReplicaSet(replicaSet *appsv1.ReplicaSet) *ApplicationDescription {
+	description := &ApplicationDescription{
+		Application:       replicaSetApplication(replicaSet),
+		UID:               string(replicaSet.UID),
+		Selector:          metav1.FormatLabelSelector(replicaSet.Spec.Selector),
+		Images:            templateImages(replicaSet.Spec.Template),
+		AvailableReplicas: replicaSet.Status.AvailableReplicas,
+	}
+	for _, condition := range replicaSet.Status.Conditions {
+		description.Conditions = append(description.Conditions, PodConditionInfo{
+			Type:               string(condition.Type),
+			Status:             string(condition.Status),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: condition.LastTransitionTime.Time,
+		})
+	}
+	return description
+}
+
+func describeJob(job *batchv1.Job) *ApplicationDescription {
+	completions := "any"
+	if job.Spec.Completions != nil {
+		completions = fmt.Sprintf("%d", *job.Spec.Completions)
+	}
+	parts := []string{"completions " + completions}
+	if job.Spec.Parallelism != nil {
+		parts = append(parts, fmt.Sprintf("parallelism %d", *job.Spec.Parallelism))
+	}
+	if job.Spec.BackoffLimit != nil {
+		parts = append(parts, fmt.Sprintf("backoff limit %d", *job.Spec.BackoffLimit))
+	}
+
+	description := &ApplicationDescription{
+		Application: jobApplication(job),
+		UID:         string(job.UID),
+		Selector:    metav1.FormatLabelSelector(job.Spec.Selector),
+		Images:      templateImages(job.Spec.Template),
+		Strategy:    strings.Join(parts, ", "),
+	}
+	for _, condition := range job.Status.Conditions {
+		description.Conditions = append(description.Conditions, PodConditionInfo{
+			Type:               string(condition.Type),
+			Status:             string(condition.Status),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: condition.LastTransitionTime.Time,
+		})
+	}
+	return description
+}
+
+func describeCronJob(cronJob *batchv1.CronJob) *ApplicationDescription {
+	description := &ApplicationDescription{
+		Application: cronJobApplication(cronJob),
+		UID:         string(cronJob.UID),
+		Images:      templateImages(cronJob.Spec.JobTemplate.Spec.Template),
+		Strategy:    "concurrency " + string(cronJob.Spec.ConcurrencyPolicy),
+		Schedule:    cronJob.Spec.Schedule,
+	}
+	if cronJob.Spec.TimeZone != nil {
+		description.Schedule += " (" + *cronJob.Spec.TimeZone + ")"
+	}
+	if cronJob.Status.LastScheduleTime != nil {
+		description.LastSchedule = cronJob.Status.LastScheduleTime.Time
+	}
+	return description
+}
+
+// cronJobSelectors returns the pod selectors of the jobs a CronJob created
+func cronJobSelectors(ctx context.Context, clientset *kubernetes.Clientset, cronJob *batchv1.CronJob) ([]*metav1.LabelSelector, error) {
+	jobs, err := clientset.BatchV1().Jobs(cronJob.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	var selectors []*metav1.LabelSelector
+	for _, job := range jobs.Items {
+		if owner := metav1.GetControllerOf(&job); owner != nil && owner.UID == cronJob.UID {
+			selectors = append(selectors, job.Spec.Selector)
+		}
+	}
+	return selectors, nil
+}
+
+// selectedPods lists the pods matching any of the selectors, by name. A selector matching every
+// pod is refused rather than listing the whole namespace as the workload's pods.
+func selectedPods(ctx context.Context, clientset *kubernetes.Clientset, namespace string, selectors []*metav1.LabelSelector) ([]PodInfo, error) {
+	seen := make(map[string]bool)
+	var pods []PodInfo
+	for _, labelSelector := range selectors {
+		if labelSelector == nil {
+			return nil, fmt.Errorf("the workload has no pod selector")
+		}
+		selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pod selector: %w", err)
+		}
+		if selector.Empty() {
+			return nil, fmt.Errorf("the pod selector matches every pod")
+		}
+
+		list, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods: %w", err)
+		}
+		for i := range list.Items {
+			if !seen[list.Items[i].Name] {
+				seen[list.Items[i].Name] = true
+				pods = append(pods, convertPodToPodInfo(&list.Items[i]))
+			}
+		}
+	}
+
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+	return pods, nil
+}
+
+// templateImages lists the images of a pod template's containers, init containers first
+func templateImages(template corev1.PodTemplateSpec) []string {
+	var images []string
+	for _, container := range template.Spec.InitContainers {
+		images = append(images, container.Image)
+	}
+	for _, container := range template.Spec.Containers {
+		images = append(images, container.Image)
+	}
+	return images
+}
+
+func formatIntOrString(value *intstr.IntOrString) string {
+	if value == nil {
+		return "<unset>"
+	}
+	return value.String()
+}
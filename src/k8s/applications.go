@@ -8,13 +8,13 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
 // GetApplications retrieves application workloads from the specified Kubernetes context and namespace
-func (k *KubeConfig) GetApplications(contextName, namespace string) ([]ApplicationInfo, error) {
+// that match the selector; a nil selector matches every workload
+func (k *KubeConfig) GetApplications(contextName, namespace string, selector *Selector) ([]ApplicationInfo, error) {
 	// Create a temporary client config for the specified context
 	tempConfig := clientcmd.NewNonInteractiveClientConfig(
 		*k.config,
@@ -43,128 +43,153 @@ func (k *KubeConfig) GetApplications(contextName, namespace string) ([]Applicati
 	var applications []ApplicationInfo
 
 	// Get Deployments
-	deployments, err := getDeployments(ctx, clientset, namespace)
+	deployments, err := getDeployments(ctx, clientset, namespace, selector)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get deployments: %w", err)
 	}
 	applications = append(applications, deployments...)
 
 	// Get DaemonSets
-	daemonSets, err := getDaemonSets(ctx, clientset, namespace)
+	daemonSets, err := getDaemonSets(ctx, clientset, namespace, selector)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get daemonsets: %w", err)
 	}
 	applications = append(applications, daemonSets...)
 
 	// Get StatefulSets
-	statefulSets, err := getStatefulSets(ctx, clientset, namespace)
+	statefulSets, err := getStatefulSets(ctx, clientset, namespace, selector)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get statefulsets: %w", err)
 	}
 	applications = append(applications, statefulSets...)
 
 	// Get ReplicaSets (only standalone ones, not owned by Deployments)
-	replicaSets, err := getReplicaSets(ctx, clientset, namespace)
+	replicaSets, err := getReplicaSets(ctx, clientset, namespace, selector)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get replicasets: %w", err)
 	}
 	applications = append(applications, replicaSets...)
 
 	// Get Jobs
-	jobs, err := getJobs(ctx, clientset, namespace)
+	jobs, err := getJobs(ctx, clientset, namespace, selector)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get jobs: %w", err)
 	}
 	applications = append(applications, jobs...)
 
 	// Get CronJobs
-	cronJobs, err := getCronJobs(ctx, clientset, namespace)
+	cronJobs, err := getCronJobs(ctx, clientset, namespace, selector)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cronjobs: %w", err)
 	}
 	applications = append(applications, cronJobs...)
 
+	for i := range applications {
+		applications[i].Health = applicationHealth(applications[i])
+	}
+
 	return applications, nil
 }
 
-func getDeployments(ctx context.Context, clientset *kubernetes.Clientset, namespace string) ([]ApplicationInfo, error) {
-	deployments, err := clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+func getDeployments(ctx context.Context, clientset *kubernetes.Clientset, namespace string, selector *Selector) ([]ApplicationInfo, error) {
+	deployments, err := clientset.AppsV1().Deployments(namespace).List(ctx, selector.ListOptions())
 	if err != nil {
 		return nil, err
 	}
 
 	var applications []ApplicationInfo
 	for _, deployment := range deployments.Items {
-		status := getDeploymentStatus(&deployment)
-		app := ApplicationInfo{
-			Name:          deployment.Name,
-			Type:          "Deployment",
-			Namespace:     deployment.Namespace,
-			Status:        status,
-			Replicas:      *deployment.Spec.Replicas,
-			ReadyReplicas: deployment.Status.ReadyReplicas,
-			CreationTime:  deployment.CreationTimestamp.Time,
-			Labels:        deployment.Labels,
-			Conditions:    getDeploymentConditions(&deployment),
+		// Field selectors the API server does not support are applied here
+		if !selector.Matches(&deployment) {
+			continue
 		}
-		applications = append(applications, app)
+
+		applications = append(applications, deploymentApplication(&deployment))
 	}
 
 	return applications, nil
 }
 
-func getDaemonSets(ctx context.Context, clientset *kubernetes.Clientset, namespace string) ([]ApplicationInfo, error) {
-	daemonSets, err := clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+func deploymentApplication(deployment *appsv1.Deployment) ApplicationInfo {
+	return ApplicationInfo{
+		Name:          deployment.Name,
+		Type:          "Deployment",
+		Namespace:     deployment.Namespace,
+		Status:        getDeploymentStatus(deployment),
+		Replicas:      *deployment.Spec.Replicas,
+		ReadyReplicas: deployment.Status.ReadyReplicas,
+		CreationTime:  deployment.CreationTimestamp.Time,
+		Labels:        deployment.Labels,
+		Conditions:    getDeploymentConditions(deployment),
+	}
+}
+
+func getDaemonSets(ctx context.Context, clientset *kubernetes.Clientset, namespace string, selector *Selector) ([]ApplicationInfo, error) {
+	daemonSets, err := clientset.AppsV1().DaemonSets(namespace).List(ctx, selector.ListOptions())
 	if err != nil {
 		return nil, err
 	}
 
 	var applications []ApplicationInfo
 	for _, daemonSet := range daemonSets.Items {
-		status := getDaemonSetStatus(&daemonSet)
-		app := ApplicationInfo{
-			Name:          daemonSet.Name,
-			Type:          "DaemonSet",
-			Namespace:     daemonSet.Namespace,
-			Status:        status,
-			Replicas:      daemonSet.Status.DesiredNumberScheduled,
-			ReadyReplicas: daemonSet.Status.NumberReady,
-			CreationTime:  daemonSet.CreationTimestamp.Time,
-			Labels:        daemonSet.Labels,
+		// Field selectors the API server does not support are applied here
+		if !selector.Matches(&daemonSet) {
+			continue
 		}
-		applications = append(applications, app)
+
+		applications = append(applications, daemonSetApplication(&daemonSet))
 	}
 
 	return applications, nil
 }
 
-func getStatefulSets(ctx context.Context, clientset *kubernetes.Clientset, namespace string) ([]ApplicationInfo, error) {
-	statefulSets, err := clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+func daemonSetApplication(daemonSet *appsv1.DaemonSet) ApplicationInfo {
+	return ApplicationInfo{
+		Name:          daemonSet.Name,
+		Type:          "DaemonSet",
+		Namespace:     daemonSet.Namespace,
+		Status:        getDaemonSetStatus(daemonSet),
+		Replicas:      daemonSet.Status.DesiredNumberScheduled,
+		ReadyReplicas: daemonSet.Status.NumberReady,
+		CreationTime:  daemonSet.CreationTimestamp.Time,
+		Labels:        daemonSet.Labels,
+	}
+}
+
+func getStatefulSets(ctx context.Context, clientset *kubernetes.Clientset, namespace string, selector *Selector) ([]ApplicationInfo, error) {
+	statefulSets, err := clientset.AppsV1().StatefulSets(namespace).List(ctx, selector.ListOptions())
 	if err != nil {
 		return nil, err
 	}
 
 	var applications []ApplicationInfo
 	for _, statefulSet := range statefulSets.Items {
-		status := getStatefulSetStatus(&statefulSet)
-		app := ApplicationInfo{
-			Name:          statefulSet.Name,
-			Type:          "StatefulSet",
-			Namespace:     statefulSet.Namespace,
-			Status:        status,
-			Replicas:      *statefulSet.Spec.Replicas,
-			ReadyReplicas: statefulSet.Status.ReadyReplicas,
-			CreationTime:  statefulSet.CreationTimestamp.Time,
-			Labels:        statefulSet.Labels,
+		// Field selectors the API server does not support are applied here
+		if !selector.Matches(&statefulSet) {
+			continue
 		}
-		applications = append(applications, app)
+
+		applications = append(applications, statefulSetApplication(&statefulSet))
 	}
 
 	return applications, nil
 }
 
-func getReplicaSets(ctx context.Context, clientset *kubernetes.Clientset, namespace string) ([]ApplicationInfo, error) {
-	replicaSets, err := clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+func statefulSetApplication(statefulSet *appsv1.StatefulSet) ApplicationInfo {
+	return ApplicationInfo{
+		Name:          statefulSet.Name,
+		Type:          "StatefulSet",
+		Namespace:     statefulSet.Namespace,
+		Status:        getStatefulSetStatus(statefulSet),
+		Replicas:      *statefulSet.Spec.Replicas,
+		ReadyReplicas: statefulSet.Status.ReadyReplicas,
+		CreationTime:  statefulSet.CreationTimestamp.Time,
+		Labels:        statefulSet.Labels,
+	}
+}
+
+func getReplicaSets(ctx context.Context, clientset *kubernetes.Clientset, namespace string, selector *Selector) ([]ApplicationInfo, error) {
+	replicaSets, err := clientset.AppsV1().ReplicaSets(namespace).List(ctx, selector.ListOptions())
 	if err != nil {
 		return nil, err
 	}
@@ -175,26 +200,31 @@ func getReplicaSets(ctx context.Context, clientset *kubernetes.Clientset, namesp
 		if isOwnedByDeployment(&replicaSet) {
 			continue
 		}
-
-		status := getReplicaSetStatus(&replicaSet)
-		app := ApplicationInfo{
-			Name:          replicaSet.Name,
-			Type:          "ReplicaSet",
-			Namespace:     replicaSet.Namespace,
-			Status:        status,
-			Replicas:      *replicaSet.Spec.Replicas,
-			ReadyReplicas: replicaSet.Status.ReadyReplicas,
-			CreationTime:  replicaSet.CreationTimestamp.Time,
-			Labels:        replicaSet.Labels,
+		if !selector.Matches(&replicaSet) {
+			continue
 		}
-		applications = append(applications, app)
+
+		applications = append(applications, replicaSetApplication(&replicaSet))
 	}
 
 	return applications, nil
 }
 
-func getJobs(ctx context.Context, clientset *kubernetes.Clientset, namespace string) ([]ApplicationInfo, error) {
-	jobs, err := clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+func replicaSetApplication(replicaSet *appsv1.ReplicaSet) ApplicationInfo {
+	return ApplicationInfo{
+		Name:          replicaSet.Name,
+		Type:          "ReplicaSet",
+		Namespace:     replicaSet.Namespace,
+		Status:        getReplicaSetStatus(replicaSet),
+		Replicas:      *replicaSet.Spec.Replicas,
+		ReadyReplicas: replicaSet.Status.ReadyReplicas,
+		CreationTime:  replicaSet.CreationTimestamp.Time,
+		Labels:        replicaSet.Labels,
+	}
+}
+
+func getJobs(ctx context.Context, clientset *kubernetes.Clientset, namespace string, selector *Selector) ([]ApplicationInfo, error) {
+	jobs, err := clientset.BatchV1().Jobs(namespace).List(ctx, selector.ListOptions())
 	if err != nil {
 		return nil, err
 	}
@@ -205,60 +235,72 @@ func getJobs(ctx context.Context, clientset *kubernetes.Clientset, namespace str
 		if isOwnedByCronJob(&job) {
 			continue
 		}
-
-		status := getJobStatus(&job)
-		replicas := int32(1)
-		if job.Spec.Parallelism != nil {
-			replicas = *job.Spec.Parallelism
-		}
-		
-		app := ApplicationInfo{
-			Name:          job.Name,
-			Type:          "Job",
-			Namespace:     job.Namespace,
-			Status:        status,
-			Replicas:      replicas,
-			ReadyReplicas: job.Status.Succeeded,
-			CreationTime:  job.CreationTimestamp.Time,
-			Labels:        job.Labels,
+		if !selector.Matches(&job) {
+			continue
 		}
-		applications = append(applications, app)
+
+		applications = append(applications, jobApplication(&job))
 	}
 
 	return applications, nil
 }
 
-func getCronJobs(ctx context.Context, clientset *kubernetes.Clientset, namespace string) ([]ApplicationInfo, error) {
+func jobApplication(job *batchv1.Job) ApplicationInfo {
+	replicas := int32(1)
+	if job.Spec.Parallelism != nil {
+		replicas = *job.Spec.Parallelism
+	}
+
+	return ApplicationInfo{
+		Name:          job.Name,
+		Type:          "Job",
+		Namespace:     job.Namespace,
+		Status:        getJobStatus(job),
+		Replicas:      replicas,
+		ReadyReplicas: job.Status.Succeeded,
+		CreationTime:  job.CreationTimestamp.Time,
+		Labels:        job.Labels,
+	}
+}
+
+func getCronJobs(ctx context.Context, clientset *kubernetes.Clientset, namespace string, selector *Selector) ([]ApplicationInfo, error) {
 	// Try v1 first, then fall back to v1beta1 for older clusters
-	cronJobs, err := clientset.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	cronJobs, err := clientset.BatchV1().CronJobs(namespace).List(ctx, selector.ListOptions())
 	if err != nil {
 		// Fall back to v1beta1
-		cronJobsV1Beta1, err := clientset.BatchV1beta1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+		cronJobsV1Beta1, err := clientset.BatchV1beta1().CronJobs(namespace).List(ctx, selector.ListOptions())
 		if err != nil {
 			return nil, err
 		}
-		return convertCronJobsV1Beta1(cronJobsV1Beta1), nil
+		return convertCronJobsV1Beta1(cronJobsV1Beta1, selector), nil
 	}
 
 	var applications []ApplicationInfo
 	for _, cronJob := range cronJobs.Items {
-		status := getCronJobStatus(&cronJob)
-		app := ApplicationInfo{
-			Name:         cronJob.Name,
-			Type:         "CronJob",
-			Namespace:    cronJob.Namespace,
-			Status:       status,
-			Replicas:     1, // CronJobs don't have replicas, use 1 for display
-			ReadyReplicas: 1,
-			CreationTime: cronJob.CreationTimestamp.Time,
-			Labels:       cronJob.Labels,
+		// Field selectors the API server does not support are applied here
+		if !selector.Matches(&cronJob) {
+			continue
 		}
-		applications = append(applications, app)
+
+		applications = append(applications, cronJobApplication(&cronJob))
 	}
 
 	return applications, nil
 }
 
+func cronJobApplication(cronJob *batchv1.CronJob) ApplicationInfo {
+	return ApplicationInfo{
+		Name:          cronJob.Name,
+		Type:          "CronJob",
+		Namespace:     cronJob.Namespace,
+		Status:        getCronJobStatus(cronJob),
+		Replicas:      1, // CronJobs don't have replicas, use 1 for display
+		ReadyReplicas: 1,
+		CreationTime:  cronJob.CreationTimestamp.Time,
+		Labels:        cronJob.Labels,
+	}
+}
+
 // Helper functions for status determination
 func getDeploymentStatus(deployment *appsv1.Deployment) string {
 	for _, condition := range deployment.Status.Conditions {
@@ -330,6 +372,46 @@ func getCronJobStatus(cronJob *batchv1.CronJob) string {
 	return "Ready"
 }
 
+// applicationHealth summarises the status an application's status helper found with how many of
+// its replicas are ready
+func applicationHealth(app ApplicationInfo) string {
+	switch app.Status {
+	case "Running", "Complete", "Ready":
+		return HealthHealthy
+	case "Failed":
+		return HealthFailed
+	case "Suspended":
+		return HealthSuspended
+	}
+
+	// Progressing and Pending: jobs and cron jobs are waiting for their pods to start or finish,
+	// controllers are missing ready replicas
+	switch {
+	case app.Type == "Job" || app.Type == "CronJob":
+		return HealthProgressing
+	case app.Replicas == 0:
+		return HealthHealthy
+	case app.ReadyReplicas == 0:
+		return HealthUnavailable
+	default:
+		return HealthDegraded
+	}
+}
+
+// Reference identifies the application's workload object, e.g. for its YAML
+func (app ApplicationInfo) Reference() ObjectReference {
+	apiVersion := "apps/v1"
+	if app.Type == "Job" || app.Type == "CronJob" {
+		apiVersion = "batch/v1"
+	}
+	return ObjectReference{
+		APIVersion: apiVersion,
+		Kind:       app.Type,
+		Namespace:  app.Namespace,
+		Name:       app.Name,
+	}
+}
+
 // Helper functions
 func isOwnedByDeployment(replicaSet *appsv1.ReplicaSet) bool {
 	for _, owner := range replicaSet.OwnerReferences {
@@ -359,9 +441,13 @@ func getDeploymentConditions(deployment *appsv1.Deployment) []string {
 	return conditions
 }
 
-func convertCronJobsV1Beta1(cronJobsV1Beta1 *batchv1beta1.CronJobList) []ApplicationInfo {
+func convertCronJobsV1Beta1(cronJobsV1Beta1 *batchv1beta1.CronJobList, selector *Selector) []ApplicationInfo {
 	var applications []ApplicationInfo
 	for _, cronJob := range cronJobsV1Beta1.Items {
+		if !selector.Matches(&cronJob) {
+			continue
+		}
+
 		status := "Ready"
 		if cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend {
 			status = "Suspended"
@@ -0,0 +1,213 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// debugContainerStartTimeout is how long a debug container may take to pull its image and start
+const debugContainerStartTimeout = 3 * time.Minute
+
+// AddDebugContainer adds an ephemeral container running image to a pod, like `kubectl debug`,
+// and returns its name. With a target container the debug container shares that container's
+// process namespace, so its processes and filesystem under /proc/1/root are visible.
+func (k *KubeConfig) AddDebugContainer(contextName, namespace, podName, image, target string) (string, error) {
+	// Create a temporary client config for the specified context
+	tempConfig := clientcmd.NewNonInteractiveClientConfig(
+		*k.config,
+		contextName,
+		&clientcmd.ConfigOverrides{},
+		nil,
+	)
+
+	restConfig, err := tempConfig.ClientConfig()
+	if err != nil {
+		return "", fmt.Errorf("failed to get client config: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get pod: %w", err)
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		return "", fmt.Errorf("pod %s is %s, debug containers can only be added to running pods", podName, pod.Status.Phase)
+	}
+
+	names := make(map[string]bool)
+	for _, container := range pod.Spec.InitContainers {
+		names[container.Name] = true
+	}
+	for _, container := range pod.Spec.Containers {
+		names[container.Name] = true
+	}
+	for _, container := range pod.Spec.EphemeralContainers {
+		names[container.Name] = true
+	}
+	if target != "" && !isAppContainer(pod, target) {
+		return "", fmt.Errorf("pod %s has no container %s to target", podName, target)
+	}
+	name := "debugger-" + utilrand.String(5)
+	for names[name] {
+		name = "debugger-" + utilrand.String(5)
+	}
+
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:                     name,
+			Image:                    image,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		},
+		TargetContainerName: target,
+	})
+
+	_, err = clientset.CoreV1().Pods(namespace).UpdateEphemeralContainers(ctx, podName, pod, metav1.UpdateOptions{})
+	if apierrors.IsNotFound(err) {
+		return "", fmt.Errorf("the cluster does not support ephemeral containers (needs Kubernetes 1.23 or later)")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to add debug container: %w", err)
+	}
+	return name, nil
+}
+
+// WaitForDebugContainer waits until an ephemeral container is running. It gives up early when
+// the container cannot start, e.g. when its image cannot be pulled.
+func (k *KubeConfig) WaitForDebugContainer(contextName, namespace, podName, containerName string) error {
+	// Create a temporary client config for the specified context
+	tempConfig := clientcmd.NewNonInteractiveClientConfig(
+		*k.config,
+		contextName,
+		&clientcmd.ConfigOverrides{},
+		nil,
+	)
+
+	restConfig, err := tempConfig.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get client config: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), debugContainerStartTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get pod: %w", err)
+		}
+		for _, status := range pod.Status.EphemeralContainerStatuses {
+			if status.Name != containerName {
+				continue
+			}
+			switch {
+			case status.State.Running != nil:
+				return nil
+			case status.State.Terminated != nil:
+				return fmt.Errorf("debug container %s exited: %s", containerName, terminationReason(status.State.Terminated))
+			case status.State.Waiting != nil && isStuckWaitingReason(status.State.Waiting.Reason):
+				return fmt.Errorf("debug container %s cannot start: %s %s",
+					containerName, status.State.Waiting.Reason, status.State.Waiting.Message)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("debug container %s did not start within %s", containerName, debugContainerStartTimeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// AttachContainer returns a terminal session attached to a container's main process, like
+// `kubectl attach -it`
+func (k *KubeConfig) AttachContainer(contextName, namespace, podName, containerName string) (*TerminalSession, error) {
+	// Create a temporary client config for the specified context
+	tempConfig := clientcmd.NewNonInteractiveClientConfig(
+		*k.config,
+		contextName,
+		&clientcmd.ConfigOverrides{},
+		nil,
+	)
+
+	restConfig, err := tempConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client config: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	request := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podName).
+		SubResource("attach").
+		VersionedParams(&corev1.PodAttachOptions{
+			Container: containerName,
+			Stdin:     true,
+			Stdout:    true,
+			TTY:       true,
+		}, scheme.ParameterCodec)
+
+	return &TerminalSession{
+		restConfig: restConfig,
+		url:        request.URL(),
+		banner: fmt.Sprintf("Attached to %s in pod %s/%s. Exit the shell to return to peek.\n"+
+			"If you don't see a command prompt, try pressing enter.", containerName, namespace, podName),
+	}, nil
+}
+
+// isAppContainer reports whether a pod has an app container with the name
+func isAppContainer(pod *corev1.Pod, name string) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isStuckWaitingReason reports whether a waiting container will not start without intervention
+func isStuckWaitingReason(reason string) bool {
+	switch reason {
+	case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "ErrImageNeverPull",
+		"CreateContainerConfigError", "CreateContainerError", "RunContainerError":
+		return true
+	}
+	return false
+}
+
+func terminationReason(state *corev1.ContainerStateTerminated) string {
+	if state.Reason != "" {
+		return fmt.Sprintf("%s (exit code %d)", state.Reason, state.ExitCode)
+	}
+	return fmt.Sprintf("exit code %d", state.ExitCode)
+}
@@ -0,0 +1,262 @@
+package k8s
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// eventArchiveDateLayout names the daily archive files
+const eventArchiveDateLayout = "2006-01-02"
+
+// EventArchive persists observed events for one context to daily JSON lines files, so events can
+// be reviewed long after the API server has expired them
+type EventArchive struct {
+	dir       string
+	retention time.Duration
+
+	mu        sync.Mutex
+	written   map[string]time.Time // series key -> last seen time already on disk
+	lastPrune time.Time
+}
+
+// OpenEventArchive opens (creating if needed) the archive of a context below baseDir
+func OpenEventArchive(baseDir, contextName string, retention time.Duration) (*EventArchive, error) {
+	dir := filepath.Join(baseDir, "events", archiveDirName(contextName))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create event archive: %w", err)
+	}
+
+	archive := &EventArchive{
+		dir:       dir,
+		retention: retention,
+		written:   make(map[string]time.Time),
+	}
+	if err := archive.Prune(); err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// Dir returns the directory the archive is stored in
+func (a *EventArchive) Dir() string {
+	return a.dir
+}
+
+// Retention returns how long archived events are kept
+func (a *EventArchive) Retention() time.Duration {
+	return a.retention
+}
+
+// Record appends the events that changed since they were last recorded and returns how many were written
+func (a *EventArchive) Record(events []EventInfo) (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Group new records by the day they were last seen
+	byDay := make(map[string][]EventInfo)
+	for _, event := range events {
+		seen := event.LastSeen()
+		if seen.IsZero() {
+			continue
+		}
+		if written, ok := a.written[eventSeriesKey(event)]; ok && !seen.After(written) {
+			continue
+		}
+		day := seen.UTC().Format(eventArchiveDateLayout)
+		byDay[day] = append(byDay[day], event)
+	}
+
+	recorded := 0
+	for day, dayEvents := range byDay {
+		if err := a.appendDay(day, dayEvents); err != nil {
+			return recorded, err
+		}
+		for _, event := range dayEvents {
+			a.written[eventSeriesKey(event)] = event.LastSeen()
+		}
+		recorded += len(dayEvents)
+	}
+
+	if time.Since(a.lastPrune) > time.Hour {
+		if err := a.pruneLocked(); err != nil {
+			return recorded, err
+		}
+	}
+
+	return recorded, nil
+}
+
+// Query returns the archived events last seen between from and to, most recent first
+func (a *EventArchive) Query(from, to time.Time) ([]EventInfo, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Nothing older than the retention period is left on disk
+	if oldest := time.Now().Add(-a.retention); from.Before(oldest) {
+		from = oldest
+	}
+
+	var events []EventInfo
+	lastDay := to.UTC().Format(eventArchiveDateLayout)
+	for day := from.UTC(); ; day = day.AddDate(0, 0, 1) {
+		name := day.Format(eventArchiveDateLayout)
+		dayEvents, err := a.readDay(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range dayEvents {
+			seen := event.LastSeen()
+			if !seen.Before(from) && !seen.After(to) {
+				events = append(events, event)
+			}
+		}
+		if name >= lastDay {
+			break
+		}
+	}
+
+	// A series is recorded again every time it recurs, keep its latest state
+	return MergeEvents(events), nil
+}
+
+// Prune deletes the daily files that fall entirely outside the retention period
+func (a *EventArchive) Prune() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.pruneLocked()
+}
+
+func (a *EventArchive) pruneLocked() error {
+	a.lastPrune = time.Now()
+
+	entries, err := os.ReadDir(a.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read event archive: %w", err)
+	}
+
+	cutoff := time.Now().Add(-a.retention).UTC().Format(eventArchiveDateLayout)
+	for _, entry := range entries {
+		day, ok := strings.CutSuffix(entry.Name(), ".jsonl")
+		if !ok || entry.IsDir() || day >= cutoff {
+			continue
+		}
+		if err := os.Remove(filepath.Join(a.dir, entry.Name())); err != nil {
+			return fmt.Errorf("failed to prune event archive: %w", err)
+		}
+	}
+
+	for key, written := range a.written {
+		if written.UTC().Format(eventArchiveDateLayout) < cutoff {
+			delete(a.written, key)
+		}
+	}
+	return nil
+}
+
+func (a *EventArchive) appendDay(day string, events []EventInfo) error {
+	file, err := os.OpenFile(filepath.Join(a.dir, day+".jsonl"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open event archive: %w", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return fmt.Errorf("failed to write event archive: %w", err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write event archive: %w", err)
+	}
+	return nil
+}
+
+func (a *EventArchive) readDay(day string) ([]EventInfo, error) {
+	file, err := os.Open(filepath.Join(a.dir, day+".jsonl"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open event archive: %w", err)
+	}
+	defer file.Close()
+
+	var events []EventInfo
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var event EventInfo
+		// Skip lines that were cut short, e.g. by a crash while writing
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read event archive: %w", err)
+	}
+	return events, nil
+}
+
+// MergeEvents combines event lists, keeping one entry per series with its latest state, most recent first
+func MergeEvents(lists ...[]EventInfo) []EventInfo {
+	merged := make(map[string]EventInfo)
+	for _, events := range lists {
+		for _, event := range events {
+			key := eventSeriesKey(event)
+			existing, ok := merged[key]
+			if !ok {
+				merged[key] = event
+				continue
+			}
+
+			first := earliest(existing.FirstTimestamp, event.FirstTimestamp)
+			if event.LastSeen().After(existing.LastSeen()) {
+				existing = event
+			}
+			existing.FirstTimestamp = first
+			merged[key] = existing
+		}
+	}
+
+	events := make([]EventInfo, 0, len(merged))
+	for _, event := range merged {
+		events = append(events, event)
+	}
+	sortEventsByTime(events)
+	return events
+}
+
+// earliest returns the earlier of two times, ignoring zero values
+func earliest(a, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
+	}
+	return a
+}
+
+// archiveDirName turns a context name (which may be an ARN or URL) into a safe directory name.
+// Unsafe characters are replaced to keep the name readable, and a short hash of the raw name keeps
+// contexts that differ only in those characters, or are named "." or "..", apart.
+func archiveDirName(contextName string) string {
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, contextName)
+	sum := sha256.Sum256([]byte(contextName))
+	return fmt.Sprintf("%s-%x", safe, sum[:4])
+}
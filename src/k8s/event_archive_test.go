@@ -0,0 +1,39 @@
+package k8s
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestArchiveDirName(t *testing.T) {
+	contexts := []string{
+		"arn:aws:eks:eu-west-1:123456789012:cluster/prod",
+		"arn:aws:eks:eu-west-1:123456789012:cluster_prod",
+		"arn_aws_eks_eu-west-1_123456789012_cluster_prod",
+		"https://api.example.com:6443",
+		".",
+		"..",
+		"",
+		"kind-dev",
+	}
+
+	seen := make(map[string]string)
+	for _, context := range contexts {
+		name := archiveDirName(context)
+		if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\:`) {
+			t.Errorf("archiveDirName(%q) = %q, which is not a safe directory name", context, name)
+		}
+		if filepath.Base(name) != name {
+			t.Errorf("archiveDirName(%q) = %q, which is not a single path element", context, name)
+		}
+		if other, ok := seen[name]; ok {
+			t.Errorf("contexts %q and %q share the archive directory %q", other, context, name)
+		}
+		seen[name] = context
+
+		if again := archiveDirName(context); again != name {
+			t.Errorf("archiveDirName(%q) is not stable: %q then %q", context, name, again)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package k8s
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// eventFilterFields maps the field names accepted in filter queries to their canonical name
+var eventFilterFields = map[string]string{
+	"type":      "type",
+	"ns":        "namespace",
+	"namespace": "namespace",
+	"reason":    "reason",
+	"kind":      "kind",
+	"name":      "name",
+	"object":    "object",
+	"source":    "source",
+	"msg":       "message",
+	"message":   "message",
+}
+
+// EventFilter matches events against a query made of field predicates and free text, e.g.
+// `type=Warning ns=payments reason~Back.*Off kind=Pod source=kubelet "liveness probe"`.
+// Predicates use = (equals), != (not equals), ~ (regex) or !~ (regex does not match); all
+// predicates and free text terms must match. Comparisons are case-insensitive.
+type EventFilter struct {
+	query      string
+	predicates []eventPredicate
+	terms      []string
+}
+
+type eventPredicate struct {
+	field   string
+	value   string
+	pattern *regexp.Regexp
+	negate  bool
+}
+
+// ParseEventFilter parses a filter query; an empty query matches every event
+func ParseEventFilter(query string) (*EventFilter, error) {
+	tokens, err := splitFilterQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &EventFilter{query: strings.TrimSpace(query)}
+	for _, t := range tokens {
+		// A quoted token is free text even when it contains an operator
+		token := t.text
+		opIndex := strings.IndexAny(token, "=~")
+		if t.quoted || opIndex <= 0 {
+			filter.terms = append(filter.terms, strings.ToLower(token))
+			continue
+		}
+
+		name := token[:opIndex]
+		op := token[opIndex : opIndex+1]
+		value := token[opIndex+1:]
+		negate := strings.HasSuffix(name, "!")
+		name = strings.TrimSuffix(name, "!")
+
+		field, ok := eventFilterFields[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown filter field %q (use type, ns, reason, kind, name, object, source or msg)", name)
+		}
+
+		predicate := eventPredicate{field: field, value: value, negate: negate}
+		if op == "~" {
+			pattern, err := regexp.Compile("(?i)" + value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern for %s: %w", name, err)
+			}
+			predicate.pattern = pattern
+		}
+		filter.predicates = append(filter.predicates, predicate)
+	}
+
+	return filter, nil
+}
+
+// String returns the query the filter was parsed from
+func (f *EventFilter) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.query
+}
+
+// IsEmpty reports whether the filter matches every event
+func (f *EventFilter) IsEmpty() bool {
+	return f == nil || (len(f.predicates) == 0 && len(f.terms) == 0)
+}
+
+// Matches reports whether an event satisfies every predicate and free text term
+func (f *EventFilter) Matches(event EventInfo) bool {
+	if f.IsEmpty() {
+		return true
+	}
+
+	for _, predicate := range f.predicates {
+		value := eventFieldValue(event, predicate.field)
+		var matched bool
+		if predicate.pattern != nil {
+			matched = predicate.pattern.MatchString(value)
+		} else {
+			matched = strings.EqualFold(value, predicate.value)
+		}
+		if matched == predicate.negate {
+			return false
+		}
+	}
+
+	if len(f.terms) > 0 {
+		text := strings.ToLower(strings.Join([]string{
+			event.Type, event.Reason, event.Object, event.Message, event.Namespace, event.Source,
+		}, " "))
+		for _, term := range f.terms {
+			if !strings.Contains(text, term) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Apply returns the events that match the filter
+func (f *EventFilter) Apply(events []EventInfo) []EventInfo {
+	if f.IsEmpty() {
+		return events
+	}
+
+	var matched []EventInfo
+	for _, event := range events {
+		if f.Matches(event) {
+			matched = append(matched, event)
+		}
+	}
+	return matched
+}
+
+// eventFieldValue returns the value of a canonical filter field
+func eventFieldValue(event EventInfo, field string) string {
+	switch field {
+	case "type":
+		return event.Type
+	case "namespace":
+		return event.Namespace
+	case "reason":
+		return event.Reason
+	case "kind":
+		kind, _, _ := strings.Cut(event.Object, "/")
+		return kind
+	case "name":
+		_, name, _ := strings.Cut(event.Object, "/")
+		return name
+	case "object":
+		return event.Object
+	case "source":
+		return event.Source
+	case "message":
+		return event.Message
+	}
+	return ""
+}
+
+// filterToken is a whitespace-separated part of a query; quoted tokens start with a double quote
+type filterToken struct {
+	text   string
+	quoted bool
+}
+
+// splitFilterQuery splits a query on whitespace, keeping double-quoted sections together
+func splitFilterQuery(query string) ([]filterToken, error) {
+	var tokens []filterToken
+	var current strings.Builder
+	inQuotes := false
+	hasToken := false
+	quoted := false
+
+	for _, r := range query {
+		switch {
+		case r == '"':
+			if !hasToken {
+				quoted = true
+			}
+			inQuotes = !inQuotes
+			hasToken = true
+		case (r == ' ' || r == '\t') && !inQuotes:
+			if hasToken {
+				tokens = append(tokens, filterToken{text: current.String(), quoted: quoted})
+				current.Reset()
+				hasToken = false
+				quoted = false
+			}
+		default:
+			current.WriteRune(r)
+			hasToken = true
+		}
+	}
+
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quote in filter")
+	}
+	if hasToken {
+		tokens = append(tokens, filterToken{text: current.String(), quoted: quoted})
+	}
+	return tokens, nil
+}
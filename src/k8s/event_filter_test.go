@@ -0,0 +1,66 @@
+package k8s
+
+import "testing"
+
+func TestEventFilter(t *testing.T) {
+	backOff := EventInfo{Type: "Warning", Reason: "BackOff", Object: "Pod/web-0", Namespace: "payments",
+		Source: "kubelet", Message: "Back-off restarting failed container, exit code=137"}
+	scheduled := EventInfo{Type: "Normal", Reason: "Scheduled", Object: "Pod/web-1", Namespace: "default",
+		Source: "default-scheduler", Message: "Successfully assigned default/web-1 to node-1"}
+	unnamed := EventInfo{Type: "Normal", Object: "Node/node-1", Message: "Node rebooted"}
+
+	tests := []struct {
+		query string
+		want  []EventInfo
+	}{
+		{query: "", want: []EventInfo{backOff, scheduled, unnamed}},
+		{query: "type=warning", want: []EventInfo{backOff}},
+		{query: "ns=payments kind=Pod", want: []EventInfo{backOff}},
+		{query: "type!=Warning", want: []EventInfo{scheduled, unnamed}},
+		{query: "reason~^back.*off$", want: []EventInfo{backOff}},
+		{query: "reason!~off", want: []EventInfo{scheduled, unnamed}},
+		{query: "name=web-1 source=default-scheduler", want: []EventInfo{scheduled}},
+		{query: "object=Node/node-1", want: []EventInfo{unnamed}},
+		// Free text matches any field, and quoted text keeps its spaces and operators
+		{query: "node-1", want: []EventInfo{scheduled, unnamed}},
+		{query: `"failed container"`, want: []EventInfo{backOff}},
+		{query: `"exit code=137"`, want: []EventInfo{backOff}},
+		{query: `"code~13"`, want: nil},
+		{query: `msg="node rebooted"`, want: []EventInfo{unnamed}},
+		// An empty value matches events without that field
+		{query: "reason=", want: []EventInfo{unnamed}},
+		{query: "reason!=", want: []EventInfo{backOff, scheduled}},
+		{query: `""`, want: []EventInfo{backOff, scheduled, unnamed}},
+	}
+
+	events := []EventInfo{backOff, scheduled, unnamed}
+	for _, tt := range tests {
+		filter, err := ParseEventFilter(tt.query)
+		if err != nil {
+			t.Errorf("ParseEventFilter(%q) failed: %v", tt.query, err)
+			continue
+		}
+		got := filter.Apply(events)
+		if len(got) != len(tt.want) {
+			t.Errorf("%q matched %d events, want %d", tt.query, len(got), len(tt.want))
+			continue
+		}
+		for i := range got {
+			if got[i].Reason != tt.want[i].Reason || got[i].Object != tt.want[i].Object {
+				t.Errorf("%q matched %s %s, want %s %s", tt.query, got[i].Reason, got[i].Object, tt.want[i].Reason, tt.want[i].Object)
+			}
+		}
+	}
+}
+
+func TestParseEventFilterErrors(t *testing.T) {
+	for _, query := range []string{
+		"color=red",       // unknown field
+		"reason~(back",    // invalid pattern
+		`"liveness probe`, // unterminated quote
+	} {
+		if _, err := ParseEventFilter(query); err == nil {
+			t.Errorf("ParseEventFilter(%q) succeeded, want an error", query)
+		}
+	}
+}
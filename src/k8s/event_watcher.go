@@ -0,0 +1,520 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// EventsAPIEventsV1 is the preferred events API
+	EventsAPIEventsV1 = "events.k8s.io/v1"
+	// EventsAPICoreV1 is the legacy events API used when events.k8s.io/v1 is not served
+	EventsAPICoreV1 = "v1"
+
+	// eventBufferRetention is how long events stay in the local buffer after they were last seen
+	eventBufferRetention = 24 * time.Hour
+	// eventBufferMaxSeries caps the local buffer size on very noisy clusters
+	eventBufferMaxSeries = 5000
+	// eventBufferPruneSeries is what an overfull buffer is pruned down to, so the next new series
+	// does not trigger another full prune
+	eventBufferPruneSeries = eventBufferMaxSeries * 9 / 10
+	// eventWatchRetryDelay is how long to wait before re-establishing a failed watch
+	eventWatchRetryDelay = 5 * time.Second
+)
+
+// EventWatcher keeps a deduplicated local buffer of cluster events fed by a watch, so
+// views can read events without re-listing them from the API server on every refresh
+type EventWatcher struct {
+	kubeConfig  *KubeConfig
+	contextName string
+
+	mu      sync.RWMutex
+	series  map[string]*eventSeries // keyed by series key
+	objects map[string]string       // event object "namespace/name" -> series key
+	version uint64
+	synced  bool
+	api     string
+	err     error
+	cancel  context.CancelFunc
+}
+
+// eventSeries aggregates every event object that reports the same occurrence
+type eventSeries struct {
+	info   EventInfo
+	counts map[string]int32 // event object "namespace/name" -> count
+}
+
+// NewEventWatcher creates an event watcher for the specified context; call Start to begin watching
+func (k *KubeConfig) NewEventWatcher(contextName string) *EventWatcher {
+	return &EventWatcher{
+		kubeConfig:  k,
+		contextName: contextName,
+		series:      make(map[string]*eventSeries),
+		objects:     make(map[string]string),
+	}
+}
+
+// Start begins watching events in the background
+func (w *EventWatcher) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	go w.run(ctx)
+}
+
+// Stop ends the watch; the buffered events remain readable
+func (w *EventWatcher) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cancel != nil {
+		w.cancel()
+		w.cancel = nil
+	}
+}
+
+// Version increases every time the buffer changes
+func (w *EventWatcher) Version() uint64 {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.version
+}
+
+// Synced reports whether the initial list has completed
+func (w *EventWatcher) Synced() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.synced
+}
+
+// API returns the events API in use (EventsAPIEventsV1 or EventsAPICoreV1)
+func (w *EventWatcher) API() string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.api
+}
+
+// Err returns the last watch error, or nil if the watch is healthy
+func (w *EventWatcher) Err() error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.err
+}
+
+// Retention returns how long events stay in the buffer after they were last seen
+func (w *EventWatcher) Retention() time.Duration {
+	return eventBufferRetention
+}
+
+// Events returns the buffered events last seen at or after since, most recent first
+func (w *EventWatcher) Events(since time.Time) []EventInfo {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	var events []EventInfo
+	for _, series := range w.series {
+		if !series.info.LastSeen().Before(since) {
+			events = append(events, series.info)
+		}
+	}
+
+	sortEventsByTime(events)
+	return events
+}
+
+// RecentWarnings returns the warning and error events seen within the given window, most recent first
+func (w *EventWatcher) RecentWarnings(window time.Duration) []EventInfo {
+	var warnings []EventInfo
+	for _, event := range w.Events(time.Now().Add(-window)) {
+		// Only include Warning, Error, and Failed events for overview
+		if event.Type == "Warning" || event.Type == "Error" || event.Type == "Failed" {
+			warnings = append(warnings, event)
+		}
+	}
+	return warnings
+}
+
+func (w *EventWatcher) run(ctx context.Context) {
+	// Create a temporary client config for the specified context
+	tempConfig := clientcmd.NewNonInteractiveClientConfig(
+		*w.kubeConfig.config,
+		w.contextName,
+		&clientcmd.ConfigOverrides{},
+		nil,
+	)
+
+	restConfig, err := tempConfig.ClientConfig()
+	if err != nil {
+		w.setErr(fmt.Errorf("failed to get client config: %w", err))
+		return
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		w.setErr(fmt.Errorf("failed to create client: %w", err))
+		return
+	}
+
+	// Prefer events.k8s.io/v1 and fall back to core/v1 on clusters that don't serve it
+	api := EventsAPIEventsV1
+	for ctx.Err() == nil {
+		w.setAPI(api)
+
+		if api == EventsAPIEventsV1 {
+			err = w.watchEventsV1(ctx, clientset)
+			if apierrors.IsNotFound(err) {
+				api = EventsAPICoreV1
+				continue
+			}
+		} else {
+			err = w.watchCoreV1(ctx, clientset)
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			w.setErr(err)
+		}
+
+		// Back off before relisting
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(eventWatchRetryDelay):
+		}
+	}
+}
+
+// watchEventsV1 lists and then watches events.k8s.io/v1 events until the watch can no longer be resumed
+func (w *EventWatcher) watchEventsV1(ctx context.Context, clientset *kubernetes.Clientset) error {
+	listCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	list, err := clientset.EventsV1().Events("").List(listCtx, metav1.ListOptions{})
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to list events: %w", err)
+	}
+
+	for i := range list.Items {
+		w.upsert(eventObjectKey(list.Items[i].Namespace, list.Items[i].Name), convertEventsV1Event(&list.Items[i]))
+	}
+	w.markSynced()
+
+	return w.watchFrom(ctx, list.ResourceVersion, func(resourceVersion string) (watch.Interface, error) {
+		return clientset.EventsV1().Events("").Watch(ctx, metav1.ListOptions{ResourceVersion: resourceVersion, AllowWatchBookmarks: true})
+	}, func(object any) (string, string, EventInfo, bool) {
+		event, ok := object.(*eventsv1.Event)
+		if !ok {
+			return "", "", EventInfo{}, false
+		}
+		return eventObjectKey(event.Namespace, event.Name), event.ResourceVersion, convertEventsV1Event(event), true
+	})
+}
+
+// watchCoreV1 lists and then watches core/v1 events until the watch can no longer be resumed
+func (w *EventWatcher) watchCoreV1(ctx context.Context, clientset *kubernetes.Clientset) error {
+	listCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	list, err := clientset.CoreV1().Events("").List(listCtx, metav1.ListOptions{})
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to list events: %w", err)
+	}
+
+	for i := range list.Items {
+		w.upsert(eventObjectKey(list.Items[i].Namespace, list.Items[i].Name), convertCoreV1Event(&list.Items[i]))
+	}
+	w.markSynced()
+
+	return w.watchFrom(ctx, list.ResourceVersion, func(resourceVersion string) (watch.Interface, error) {
+		return clientset.CoreV1().Events("").Watch(ctx, metav1.ListOptions{ResourceVersion: resourceVersion, AllowWatchBookmarks: true})
+	}, func(object any) (string, string, EventInfo, bool) {
+		event, ok := object.(*corev1.Event)
+		if !ok {
+			return "", "", EventInfo{}, false
+		}
+		return eventObjectKey(event.Namespace, event.Name), event.ResourceVersion, convertCoreV1Event(event), true
+	})
+}
+
+// watchFrom consumes a watch, resuming from the last seen resource version whenever the server
+// closes it. It returns when the resource version expires and a relist is required.
+func (w *EventWatcher) watchFrom(ctx context.Context, resourceVersion string,
+	start func(resourceVersion string) (watch.Interface, error),
+	convert func(object any) (key, resourceVersion string, info EventInfo, ok bool)) error {
+
+	for ctx.Err() == nil {
+		watcher, err := start(resourceVersion)
+		if err != nil {
+			return fmt.Errorf("failed to watch events: %w", err)
+		}
+		w.setErr(nil)
+
+		for watchEvent := range watcher.ResultChan() {
+			switch watchEvent.Type {
+			case watch.Added, watch.Modified:
+				key, rv, info, ok := convert(watchEvent.Object)
+				if !ok {
+					continue
+				}
+				resourceVersion = rv
+				w.upsert(key, info)
+			case watch.Deleted:
+				// Keep expired events in the local buffer; only track the resource version
+				if _, rv, _, ok := convert(watchEvent.Object); ok {
+					resourceVersion = rv
+				}
+			case watch.Bookmark:
+				if object, ok := watchEvent.Object.(metav1.Object); ok {
+					resourceVersion = object.GetResourceVersion()
+				}
+			case watch.Error:
+				watcher.Stop()
+				status := apierrors.FromObject(watchEvent.Object)
+				if apierrors.IsResourceExpired(status) || apierrors.IsGone(status) {
+					// Our resource version is too old, the caller has to relist
+					return nil
+				}
+				return fmt.Errorf("event watch failed: %w", status)
+			}
+		}
+		watcher.Stop()
+	}
+
+	return nil
+}
+
+// upsert records an observed event object in its series
+func (w *EventWatcher) upsert(objectKey string, info EventInfo) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	key := eventSeriesKey(info)
+
+	// The same event object may have been re-keyed, e.g. when its message changed
+	if previousKey, ok := w.objects[objectKey]; ok && previousKey != key {
+		if previous, ok := w.series[previousKey]; ok {
+			delete(previous.counts, objectKey)
+			if len(previous.counts) == 0 {
+				delete(w.series, previousKey)
+			} else {
+				previous.info.Count = sumCounts(previous.counts)
+			}
+		}
+	}
+	w.objects[objectKey] = key
+
+	series, ok := w.series[key]
+	if !ok {
+		series = &eventSeries{info: info, counts: make(map[string]int32)}
+		w.series[key] = series
+	}
+	series.counts[objectKey] = info.Count
+
+	// Keep the widest time span and the details of the latest report
+	first := series.info.FirstTimestamp
+	if first.IsZero() || (!info.FirstTimestamp.IsZero() && info.FirstTimestamp.Before(first)) {
+		first = info.FirstTimestamp
+	}
+	if !info.LastSeen().Before(series.info.LastSeen()) {
+		series.info = info
+	}
+	series.info.FirstTimestamp = first
+	series.info.Count = sumCounts(series.counts)
+
+	w.version++
+	w.pruneLocked()
+}
+
+// pruneLocked drops series that are too old or exceed the buffer size; w.mu must be held
+func (w *EventWatcher) pruneLocked() {
+	if len(w.series) <= eventBufferMaxSeries && w.version%100 != 0 {
+		return
+	}
+
+	cutoff := time.Now().Add(-eventBufferRetention)
+	type entry struct {
+		key  string
+		last time.Time
+	}
+	var entries []entry
+	for key, series := range w.series {
+		entries = append(entries, entry{key: key, last: series.info.LastSeen()})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].last.After(entries[j].last)
+	})
+
+	for i, e := range entries {
+		if i >= eventBufferPruneSeries || e.last.Before(cutoff) {
+			delete(w.series, e.key)
+		}
+	}
+	for objectKey, key := range w.objects {
+		if _, ok := w.series[key]; !ok {
+			delete(w.objects, objectKey)
+		}
+	}
+}
+
+func (w *EventWatcher) markSynced() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.synced = true
+	w.version++
+}
+
+func (w *EventWatcher) setAPI(api string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.api = api
+}
+
+func (w *EventWatcher) setErr(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.err = err
+	w.version++
+}
+
+// convertEventsV1Event converts an events.k8s.io/v1 event to EventInfo
+func convertEventsV1Event(event *eventsv1.Event) EventInfo {
+	info := EventInfo{
+		Type:      event.Type,
+		Reason:    event.Reason,
+		Object:    fmt.Sprintf("%s/%s", event.Regarding.Kind, event.Regarding.Name),
+		Message:   event.Note,
+		Count:     event.DeprecatedCount,
+		Namespace: event.Namespace,
+		Source:    event.ReportingController,
+		InvolvedObject: ObjectReference{
+			APIVersion: event.Regarding.APIVersion,
+			Kind:       event.Regarding.Kind,
+			Namespace:  event.Regarding.Namespace,
+			Name:       event.Regarding.Name,
+			UID:        string(event.Regarding.UID),
+		},
+	}
+	if info.Source == "" {
+		info.Source = event.DeprecatedSource.Component
+	}
+
+	// Prefer the new timestamps and fall back to the deprecated ones
+	info.FirstTimestamp = event.EventTime.Time
+	if info.FirstTimestamp.IsZero() {
+		info.FirstTimestamp = event.DeprecatedFirstTimestamp.Time
+	}
+	info.LastTimestamp = event.DeprecatedLastTimestamp.Time
+	if event.Series != nil {
+		info.Count = event.Series.Count
+		info.LastTimestamp = event.Series.LastObservedTime.Time
+	}
+	if info.LastTimestamp.IsZero() {
+		info.LastTimestamp = info.FirstTimestamp
+	}
+	if info.FirstTimestamp.IsZero() {
+		info.FirstTimestamp = event.CreationTimestamp.Time
+		info.LastTimestamp = event.CreationTimestamp.Time
+	}
+	if info.Count == 0 {
+		info.Count = 1
+	}
+
+	return info
+}
+
+// convertCoreV1Event converts a core/v1 event to EventInfo
+func convertCoreV1Event(event *corev1.Event) EventInfo {
+	info := EventInfo{
+		Type:           event.Type,
+		Reason:         event.Reason,
+		Object:         fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name),
+		Message:        event.Message,
+		Count:          event.Count,
+		FirstTimestamp: event.FirstTimestamp.Time,
+		LastTimestamp:  event.LastTimestamp.Time,
+		Namespace:      event.Namespace,
+		Source:         event.Source.Component,
+		InvolvedObject: ObjectReference{
+			APIVersion: event.InvolvedObject.APIVersion,
+			Kind:       event.InvolvedObject.Kind,
+			Namespace:  event.InvolvedObject.Namespace,
+			Name:       event.InvolvedObject.Name,
+			UID:        string(event.InvolvedObject.UID),
+		},
+	}
+	if info.Source == "" {
+		info.Source = event.ReportingController
+	}
+
+	// Events emitted through the new API only carry EventTime and Series
+	if info.FirstTimestamp.IsZero() {
+		info.FirstTimestamp = event.EventTime.Time
+	}
+	if event.Series != nil {
+		info.Count = event.Series.Count
+		info.LastTimestamp = event.Series.LastObservedTime.Time
+	}
+	if info.LastTimestamp.IsZero() {
+		info.LastTimestamp = info.FirstTimestamp
+	}
+
+	// If timestamps are zero, use event metadata
+	if info.FirstTimestamp.IsZero() {
+		info.FirstTimestamp = event.CreationTimestamp.Time
+		info.LastTimestamp = event.CreationTimestamp.Time
+	}
+	if info.Count == 0 {
+		info.Count = 1
+	}
+
+	return info
+}
+
+// eventSeriesKey identifies an occurrence independently of the event object that reported it
+func eventSeriesKey(info EventInfo) string {
+	return fmt.Sprintf("%s|%s|%s|%s|%s", info.Namespace, info.Object, info.Type, info.Reason, info.Message)
+}
+
+func eventObjectKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// LastSeen returns when an event was last seen: its last timestamp, or its first when it has none
+func (info EventInfo) LastSeen() time.Time {
+	if !info.LastTimestamp.IsZero() {
+		return info.LastTimestamp
+	}
+	return info.FirstTimestamp
+}
+
+func sumCounts(counts map[string]int32) int32 {
+	var total int32
+	for _, count := range counts {
+		total += count
+	}
+	return total
+}
+
+// sortEventsByTime sorts events by last seen time (most recent first)
+func sortEventsByTime(events []EventInfo) {
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].LastSeen().After(events[j].LastSeen())
+	})
+}
@@ -1,157 +1,12 @@
 package k8s
 
 import (
-	"context"
 	"fmt"
 	"sort"
 	"strings"
 	"time"
-
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/clientcmd"
 )
 
-// GetEvents retrieves events from the specified Kubernetes context within the given timeframe
-func (k *KubeConfig) GetEvents(contextName string, timeframeMinutes int) ([]EventInfo, error) {
-	// Create a temporary client config for the specified context
-	tempConfig := clientcmd.NewNonInteractiveClientConfig(
-		*k.config,
-		contextName,
-		&clientcmd.ConfigOverrides{},
-		nil,
-	)
-
-	restConfig, err := tempConfig.ClientConfig()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get client config: %w", err)
-	}
-
-	// Set a reasonable timeout
-	restConfig.Timeout = 10 * time.Second
-
-	clientset, err := kubernetes.NewForConfig(restConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create client: %w", err)
-	}
-
-	// Create a context with timeout for the API call
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	// Calculate time threshold
-	timeThreshold := time.Now().Add(-time.Duration(timeframeMinutes) * time.Minute)
-
-	// Get events from all namespaces
-	eventList, err := clientset.CoreV1().Events("").List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to list events: %w", err)
-	}
-
-	var events []EventInfo
-	for _, event := range eventList.Items {
-		// Filter events by timeframe - check LastTimestamp first, then FirstTimestamp
-		eventTime := event.LastTimestamp.Time
-		if eventTime.IsZero() {
-			eventTime = event.FirstTimestamp.Time
-		}
-
-		// Skip events older than our timeframe
-		if eventTime.Before(timeThreshold) {
-			continue
-		}
-
-		eventInfo := EventInfo{
-			Type:           event.Type,
-			Reason:         event.Reason,
-			Object:         fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name),
-			Message:        event.Message,
-			Count:          event.Count,
-			FirstTimestamp: event.FirstTimestamp.Time,
-			LastTimestamp:  event.LastTimestamp.Time,
-			Namespace:      event.Namespace,
-			Source:         event.Source.Component,
-		}
-
-		events = append(events, eventInfo)
-	}
-
-	// Sort events by timestamp (most recent first)
-	sort.Slice(events, func(i, j int) bool {
-		timeI := events[i].LastTimestamp
-		if timeI.IsZero() {
-			timeI = events[i].FirstTimestamp
-		}
-		timeJ := events[j].LastTimestamp
-		if timeJ.IsZero() {
-			timeJ = events[j].FirstTimestamp
-		}
-		return timeI.After(timeJ)
-	})
-
-	return events, nil
-}
-
-// getRecentEvents retrieves recent warning and error events for metrics display
-func (k *KubeConfig) getRecentEvents(ctx context.Context, clientset *kubernetes.Clientset) ([]EventInfo, error) {
-	// Get events from the last 10 minutes for overview display
-	eventList, err := clientset.CoreV1().Events("").List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to list events: %w", err)
-	}
-
-	var events []EventInfo
-	cutoff := time.Now().Add(-10 * time.Minute)
-
-	for _, event := range eventList.Items {
-		// Only include Warning, Error, and Failed events for overview
-		if event.Type != "Warning" && event.Type != "Error" && event.Type != "Failed" {
-			continue
-		}
-
-		info := EventInfo{
-			Type:           event.Type,
-			Reason:         event.Reason,
-			Object:         fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name),
-			Message:        event.Message,
-			Count:          event.Count,
-			FirstTimestamp: event.FirstTimestamp.Time,
-			LastTimestamp:  event.LastTimestamp.Time,
-			Namespace:      event.Namespace,
-			Source:         event.Source.Component,
-		}
-
-		// If timestamps are zero, use event metadata
-		if info.FirstTimestamp.IsZero() && event.CreationTimestamp.Time != (time.Time{}) {
-			info.FirstTimestamp = event.CreationTimestamp.Time
-			info.LastTimestamp = event.CreationTimestamp.Time
-		}
-
-		// Skip events older than cutoff
-		eventTime := info.LastTimestamp
-		if eventTime.IsZero() {
-			eventTime = info.FirstTimestamp
-		}
-		if eventTime.Before(cutoff) {
-			continue
-		}
-
-		events = append(events, info)
-	}
-
-	// Sort events by last seen time (most recent first)
-	sort.Slice(events, func(i, j int) bool {
-		return events[i].LastTimestamp.After(events[j].LastTimestamp)
-	})
-
-	// Limit to most recent 20 events
-	if len(events) > 20 {
-		events = events[:20]
-	}
-
-	return events, nil
-}
-
 // GetEventColor returns the appropriate color code for an event type
 func GetEventColor(eventType string) string {
 	switch strings.ToLower(eventType) {
@@ -197,3 +52,59 @@ func TruncateString(s string, maxLen int) string {
 	}
 	return s[:maxLen-3] + "..."
 }
+
+// EventTypeSeverity ranks an event type: Normal, then Warning, then Error
+func EventTypeSeverity(eventType string) int {
+	switch strings.ToLower(eventType) {
+	case "warning":
+		return 1
+	case "error":
+		return 2
+	}
+	return 0
+}
+
+// GroupEvents collapses events by involved object and reason, most recently seen group first
+func GroupEvents(events []EventInfo) []EventGroup {
+	var groups []EventGroup
+	index := make(map[string]int)
+
+	for _, event := range events {
+		key := fmt.Sprintf("%s|%s|%s", event.Namespace, event.Object, event.Reason)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, EventGroup{
+				Key:       key,
+				Type:      event.Type,
+				Reason:    event.Reason,
+				Object:    event.Object,
+				Namespace: event.Namespace,
+			})
+		}
+
+		group := &groups[i]
+		group.Events = append(group.Events, event)
+		group.Count += max(event.Count, 1)
+		// A group is as severe as its worst occurrence
+		if EventTypeSeverity(event.Type) > EventTypeSeverity(group.Type) {
+			group.Type = event.Type
+		}
+		if first := event.FirstTimestamp; !first.IsZero() && (group.FirstTimestamp.IsZero() || first.Before(group.FirstTimestamp)) {
+			group.FirstTimestamp = first
+		}
+		if last := event.LastSeen(); last.After(group.LastTimestamp) {
+			group.LastTimestamp = last
+			group.Message = event.Message
+		}
+	}
+
+	for i := range groups {
+		sortEventsByTime(groups[i].Events)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].LastTimestamp.After(groups[j].LastTimestamp)
+	})
+	return groups
+}
@@ -0,0 +1,102 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGroupEvents(t *testing.T) {
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return base.Add(time.Duration(minutes) * time.Minute) }
+
+	tests := []struct {
+		name   string
+		events []EventInfo
+		want   []EventGroup // without Events, whose order is checked separately
+	}{
+		{
+			name: "same object and reason",
+			events: []EventInfo{
+				{Type: "Normal", Reason: "Pulled", Object: "Pod/web-0", Namespace: "default", Message: "first", Count: 2, FirstTimestamp: at(0), LastTimestamp: at(5)},
+				{Type: "Normal", Reason: "Pulled", Object: "Pod/web-0", Namespace: "default", Message: "latest", Count: 3, FirstTimestamp: at(2), LastTimestamp: at(9)},
+			},
+			want: []EventGroup{
+				{Key: "default|Pod/web-0|Pulled", Type: "Normal", Reason: "Pulled", Object: "Pod/web-0", Namespace: "default",
+					Message: "latest", Count: 5, FirstTimestamp: at(0), LastTimestamp: at(9)},
+			},
+		},
+		{
+			name: "different namespace, object or reason",
+			events: []EventInfo{
+				{Type: "Normal", Reason: "Pulled", Object: "Pod/web-0", Namespace: "default", LastTimestamp: at(1)},
+				{Type: "Normal", Reason: "Pulled", Object: "Pod/web-0", Namespace: "staging", LastTimestamp: at(2)},
+				{Type: "Normal", Reason: "Pulled", Object: "Pod/web-1", Namespace: "default", LastTimestamp: at(3)},
+				{Type: "Normal", Reason: "Started", Object: "Pod/web-0", Namespace: "default", LastTimestamp: at(4)},
+			},
+			want: []EventGroup{
+				{Key: "default|Pod/web-0|Started", Type: "Normal", Reason: "Started", Object: "Pod/web-0", Namespace: "default", Count: 1, LastTimestamp: at(4)},
+				{Key: "default|Pod/web-1|Pulled", Type: "Normal", Reason: "Pulled", Object: "Pod/web-1", Namespace: "default", Count: 1, LastTimestamp: at(3)},
+				{Key: "staging|Pod/web-0|Pulled", Type: "Normal", Reason: "Pulled", Object: "Pod/web-0", Namespace: "staging", Count: 1, LastTimestamp: at(2)},
+				{Key: "default|Pod/web-0|Pulled", Type: "Normal", Reason: "Pulled", Object: "Pod/web-0", Namespace: "default", Count: 1, LastTimestamp: at(1)},
+			},
+		},
+		{
+			name: "first seen only",
+			events: []EventInfo{
+				{Type: "Normal", Reason: "Killing", Object: "Pod/web-0", Message: "older", FirstTimestamp: at(3)},
+				{Type: "Normal", Reason: "Killing", Object: "Pod/web-0", Message: "newer", FirstTimestamp: at(7)},
+			},
+			want: []EventGroup{
+				{Key: "|Pod/web-0|Killing", Type: "Normal", Reason: "Killing", Object: "Pod/web-0",
+					Message: "newer", Count: 2, FirstTimestamp: at(3), LastTimestamp: at(7)},
+			},
+		},
+		{
+			name: "worst type wins",
+			events: []EventInfo{
+				{Type: "Warning", Reason: "BackOff", Object: "Pod/web-0", LastTimestamp: at(1)},
+				{Type: "Error", Reason: "BackOff", Object: "Pod/web-0", LastTimestamp: at(2)},
+				{Type: "Warning", Reason: "BackOff", Object: "Pod/web-0", LastTimestamp: at(3)},
+				{Type: "Normal", Reason: "BackOff", Object: "Pod/web-0", LastTimestamp: at(4)},
+			},
+			want: []EventGroup{
+				{Key: "|Pod/web-0|BackOff", Type: "Error", Reason: "BackOff", Object: "Pod/web-0", Count: 4, LastTimestamp: at(4)},
+			},
+		},
+		{
+			name: "warning after normal",
+			events: []EventInfo{
+				{Type: "Normal", Reason: "Unhealthy", Object: "Pod/web-0", LastTimestamp: at(1)},
+				{Type: "Warning", Reason: "Unhealthy", Object: "Pod/web-0", LastTimestamp: at(2)},
+			},
+			want: []EventGroup{
+				{Key: "|Pod/web-0|Unhealthy", Type: "Warning", Reason: "Unhealthy", Object: "Pod/web-0", Count: 2, LastTimestamp: at(2)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups := GroupEvents(tt.events)
+			if len(groups) != len(tt.want) {
+				t.Fatalf("got %d groups, want %d", len(groups), len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := groups[i]
+				if got.Key != want.Key || got.Type != want.Type || got.Reason != want.Reason || got.Object != want.Object ||
+					got.Namespace != want.Namespace || got.Message != want.Message || got.Count != want.Count ||
+					!got.FirstTimestamp.Equal(want.FirstTimestamp) || !got.LastTimestamp.Equal(want.LastTimestamp) {
+					t.Errorf("group %d = %+v, want %+v", i, got, want)
+				}
+				if int(got.Count) < len(got.Events) {
+					t.Errorf("group %d counts %d for %d events", i, got.Count, len(got.Events))
+				}
+				for j := 1; j < len(got.Events); j++ {
+					if got.Events[j].LastSeen().After(got.Events[j-1].LastSeen()) {
+						t.Errorf("group %d events are not most recent first", i)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package k8s
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// LogFilter matches log lines against field predicates, e.g. `level>=warn user.id=42 path~^/api`.
+// Predicates use = (equals), != (not equals), ~ (regex) or !~ (regex does not match) on any field
+// of a structured line; level also accepts >= and <=. All predicates must match. Lines that are not
+// JSON or logfmt only have a level, guessed from their text.
+type LogFilter struct {
+	query      string
+	predicates []logPredicate
+}
+
+type logPredicate struct {
+	field   string
+	op      string
+	value   string
+	pattern *regexp.Regexp
+}
+
+// ParseLogFilter parses a log filter query; an empty query matches every line
+func ParseLogFilter(query string) (*LogFilter, error) {
+	tokens, err := splitFilterQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &LogFilter{query: strings.TrimSpace(query)}
+	for _, t := range tokens {
+		token := t.text
+		opIndex := strings.IndexAny(token, "=~<>!")
+		if t.quoted || opIndex <= 0 {
+			return nil, fmt.Errorf("expected field=value, got %q", token)
+		}
+
+		field := token[:opIndex]
+		rest := token[opIndex:]
+		var op string
+		for _, candidate := range []string{"!=", "!~", ">=", "<=", "=", "~"} {
+			if strings.HasPrefix(rest, candidate) {
+				op = candidate
+				break
+			}
+		}
+		if op == "" {
+			return nil, fmt.Errorf("unknown operator in %q (use =, !=, ~, !~, >= or <=)", token)
+		}
+		value := rest[len(op):]
+
+		predicate := logPredicate{field: field, op: op, value: value}
+		if field == "level" {
+			predicate.value = NormalizeLogLevel(value)
+		}
+		switch op {
+		case "~", "!~":
+			pattern, err := regexp.Compile("(?i)" + value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern for %s: %w", field, err)
+			}
+			predicate.pattern = pattern
+		case ">=", "<=":
+			if field != "level" || !IsLogLevel(predicate.value) {
+				return nil, fmt.Errorf("%s only compares levels (trace, debug, info, warn, error, fatal)", op)
+			}
+		}
+		filter.predicates = append(filter.predicates, predicate)
+	}
+
+	return filter, nil
+}
+
+// String returns the query the filter was parsed from
+func (f *LogFilter) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.query
+}
+
+// IsEmpty reports whether the filter matches every line
+func (f *LogFilter) IsEmpty() bool {
+	return f == nil || len(f.predicates) == 0
+}
+
+// Matches reports whether a line satisfies every predicate; entry is the parsed line, or nil when
+// the line is not structured
+func (f *LogFilter) Matches(text string, entry *StructuredLog) bool {
+	if f.IsEmpty() {
+		return true
+	}
+
+	for _, predicate := range f.predicates {
+		var value string
+		var found bool
+		if entry != nil {
+			value, found = entry.Field(predicate.field)
+		}
+		if predicate.field == "level" && value == "" {
+			value, found = DetectLogLevel(text), true
+		}
+
+		var matched bool
+		switch predicate.op {
+		case "=":
+			matched = found && strings.EqualFold(value, predicate.value)
+		case "!=":
+			matched = !found || !strings.EqualFold(value, predicate.value)
+		case "~":
+			matched = found && predicate.pattern.MatchString(value)
+		case "!~":
+			matched = !found || !predicate.pattern.MatchString(value)
+		case ">=":
+			matched = value != "" && CompareLogLevels(value, predicate.value) >= 0
+		case "<=":
+			matched = value != "" && CompareLogLevels(value, predicate.value) <= 0
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// logLevelWords match the words that give away the level of an unstructured line, most severe
+// first. They match whole words only, so "error" is found in "3 errors" but not in "stderr", and
+// "trace" not in "stacktrace" or "traceId".
+var logLevelWords = []struct {
+	level string
+	word  *regexp.Regexp
+}{
+	{LogLevelFatal, regexp.MustCompile(`\b(fatal|panic|panicked)\b`)},
+	{LogLevelError, regexp.MustCompile(`\berr(or|ors)?\b`)},
+	{LogLevelWarn, regexp.MustCompile(`\bwarn(ing|ings)?\b`)},
+	{LogLevelInfo, regexp.MustCompile(`\binfo\b`)},
+	{LogLevelDebug, regexp.MustCompile(`\bdebug\b`)},
+	{LogLevelTrace, regexp.MustCompile(`\btrace\b`)},
+}
+
+// DetectLogLevel guesses the level of an unstructured line from the words in it, "" when there are none
+func DetectLogLevel(text string) string {
+	lower := strings.ToLower(text)
+	for _, candidate := range logLevelWords {
+		if candidate.word.MatchString(lower) {
+			return candidate.level
+		}
+	}
+	return ""
+}
@@ -0,0 +1,37 @@
+package k8s
+
+import "testing"
+
+func TestDetectLogLevel(t *testing.T) {
+	tests := []struct {
+		text  string
+		level string
+	}{
+		{"ERROR failed to connect", LogLevelError},
+		{"[err] connection reset", LogLevelError},
+		{"request failed: error: timeout", LogLevelError},
+		{"3 errors while syncing", LogLevelError},
+		{"ERRORS: disk full", LogLevelError},
+		{"server started on :8080", ""},
+		{"received interrupt, shutting down", ""},
+		{"using preferred address 10.0.0.1", ""},
+		{"redirecting stderr to file", ""},
+		{"WARN disk almost full", LogLevelWarn},
+		{"info: interrupt handler installed", LogLevelInfo},
+		{"FATAL: cannot open config", LogLevelFatal},
+		{"panic: runtime error: index out of range", LogLevelFatal},
+		{"recovered from nonfatal condition", ""},
+		{"WARNING: deprecated flag", LogLevelWarn},
+		{"loaded information about 3 peers", ""},
+		{"[DEBUG] cache hit", LogLevelDebug},
+		{"TRACE entering handler", LogLevelTrace},
+		{"request done traceId=4bf92f3577b34da6", ""},
+		{"dumped stacktrace to /tmp/dump", ""},
+	}
+
+	for _, tt := range tests {
+		if level := DetectLogLevel(tt.text); level != tt.level {
+			t.Errorf("DetectLogLevel(%q) = %q, want %q", tt.text, level, tt.level)
+		}
+	}
+}
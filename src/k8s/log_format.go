@@ -0,0 +1,312 @@
+package k8s
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Structured log formats
+const (
+	LogFormatJSON   = "json"
+	LogFormatLogfmt = "logfmt"
+)
+
+// Normalized log levels, from least to most severe
+const (
+	LogLevelTrace = "trace"
+	LogLevelDebug = "debug"
+	LogLevelInfo  = "info"
+	LogLevelWarn  = "warn"
+	LogLevelError = "error"
+	LogLevelFatal = "fatal"
+)
+
+var logLevelRank = map[string]int{
+	LogLevelTrace: 0,
+	LogLevelDebug: 1,
+	LogLevelInfo:  2,
+	LogLevelWarn:  3,
+	LogLevelError: 4,
+	LogLevelFatal: 5,
+}
+
+// Well-known keys, in order of preference
+var (
+	logLevelKeys   = []string{"level", "lvl", "severity", "log.level", "loglevel", "levelname"}
+	logTimeKeys    = []string{"time", "ts", "timestamp", "@timestamp", "t", "date"}
+	logMessageKeys = []string{"msg", "message", "@message", "event"}
+)
+
+// StructuredLog is a JSON or logfmt log line split into its level, time, message and other fields
+type StructuredLog struct {
+	Format  string
+	Level   string // normalized, "" when the line has no level
+	Time    time.Time
+	Message string
+	Fields  map[string]string // every field except level, time and message, nested JSON flattened with dots
+	Keys    []string          // Fields keys in the order they appear
+	Object  any               // the decoded JSON object, nil for logfmt
+}
+
+// ParseStructuredLog detects a JSON or logfmt line. A leading RFC3339 timestamp, as added by the
+// API server when timestamps are requested, is skipped.
+func ParseStructuredLog(line string) (*StructuredLog, bool) {
+	_, text, _ := SplitLogTimestamp(line)
+	text = strings.TrimSpace(text)
+
+	if strings.HasPrefix(text, "{") && strings.HasSuffix(text, "}") {
+		return parseJSONLog(text)
+	}
+	return parseLogfmt(text)
+}
+
+// SplitLogTimestamp splits the RFC3339 timestamp the API server prefixes log lines with from the text
+func SplitLogTimestamp(line string) (time.Time, string, bool) {
+	prefix, rest, ok := strings.Cut(line, " ")
+	if !ok || len(prefix) < len("2006-01-02T15:04:05Z") || prefix[4] != '-' {
+		return time.Time{}, line, false
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, prefix)
+	if err != nil {
+		return time.Time{}, line, false
+	}
+	return timestamp, rest, true
+}
+
+func parseJSONLog(text string) (*StructuredLog, bool) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	var object map[string]any
+	if err := decoder.Decode(&object); err != nil {
+		return nil, false
+	}
+
+	fields := make(map[string]string)
+	flattenJSON("", object, fields)
+
+	entry := &StructuredLog{Format: LogFormatJSON, Object: object}
+	entry.fill(fields, jsonKeyOrder(text, fields))
+	return entry, true
+}
+
+// logfmtPair matches key=value, key="quoted value" or a bare key
+var logfmtPair = regexp.MustCompile(`([^\s=]+)(?:=("(?:[^"\\]|\\.)*"|\S*))?`)
+
+func parseLogfmt(text string) (*StructuredLog, bool) {
+	fields := make(map[string]string)
+	var keys []string
+	pairs := 0
+	for _, match := range logfmtPair.FindAllStringSubmatch(text, -1) {
+		key, value := match[1], match[2]
+		if strings.Contains(match[0], "=") {
+			pairs++
+		}
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		if _, ok := fields[key]; !ok {
+			keys = append(keys, key)
+		}
+		fields[key] = value
+	}
+
+	// Plain text that happens to contain a key=value is not logfmt; require most tokens to be pairs
+	// and a well-known key to be present
+	if pairs < 2 || pairs*2 < len(keys) || (findKey(fields, logLevelKeys) == "" && findKey(fields, logMessageKeys) == "") {
+		return nil, false
+	}
+
+	entry := &StructuredLog{Format: LogFormatLogfmt}
+	entry.fill(fields, keys)
+	return entry, true
+}
+
+// fill takes the level, time and message out of the fields
+func (s *StructuredLog) fill(fields map[string]string, keys []string) {
+	if key := findKey(fields, logLevelKeys); key != "" {
+		s.Level = NormalizeLogLevel(fields[key])
+		delete(fields, key)
+	}
+	if key := findKey(fields, logTimeKeys); key != "" {
+		if t, ok := parseLogTime(fields[key]); ok {
+			s.Time = t
+			delete(fields, key)
+		}
+	}
+	if key := findKey(fields, logMessageKeys); key != "" {
+		s.Message = fields[key]
+		delete(fields, key)
+	}
+
+	s.Fields = fields
+	for _, key := range keys {
+		if _, ok := fields[key]; ok {
+			s.Keys = append(s.Keys, key)
+		}
+	}
+}
+
+// Field returns a field by key; "level", "time" and "msg" return the extracted values
+func (s *StructuredLog) Field(key string) (string, bool) {
+	switch key {
+	case "level":
+		return s.Level, s.Level != ""
+	case "time":
+		return s.Time.Format(time.RFC3339Nano), !s.Time.IsZero()
+	case "msg", "message":
+		return s.Message, true
+	}
+	value, ok := s.Fields[key]
+	return value, ok
+}
+
+// Pretty renders the line as indented JSON
+func (s *StructuredLog) Pretty() string {
+	var object any = s.Object
+	if object == nil {
+		// Rebuild logfmt lines as an object
+		fields := make(map[string]any, len(s.Fields)+3)
+		for key, value := range s.Fields {
+			fields[key] = value
+		}
+		if s.Level != "" {
+			fields["level"] = s.Level
+		}
+		if !s.Time.IsZero() {
+			fields["time"] = s.Time.Format(time.RFC3339Nano)
+		}
+		fields["msg"] = s.Message
+		object = fields
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(object); err != nil {
+		return fmt.Sprintf("%v", object)
+	}
+	return strings.TrimRight(buffer.String(), "\n")
+}
+
+// NormalizeLogLevel maps the many spellings of log levels, including numeric bunyan/pino levels,
+// to the LogLevel constants; unknown levels are returned lowercased
+func NormalizeLogLevel(level string) string {
+	level = strings.ToLower(strings.TrimSpace(level))
+	switch level {
+	case "trace", "trc", "10":
+		return LogLevelTrace
+	case "debug", "dbg", "d", "20":
+		return LogLevelDebug
+	case "info", "inf", "i", "information", "notice", "30":
+		return LogLevelInfo
+	case "warn", "warning", "wrn", "w", "40":
+		return LogLevelWarn
+	case "error", "err", "e", "50":
+		return LogLevelError
+	case "fatal", "panic", "critical", "crit", "dpanic", "emerg", "alert", "60":
+		return LogLevelFatal
+	}
+	return level
+}
+
+// CompareLogLevels orders two normalized levels; unknown levels sort with info
+func CompareLogLevels(a, b string) int {
+	return logLevelRank[orInfo(a)] - logLevelRank[orInfo(b)]
+}
+
+// IsLogLevel reports whether a normalized level is one of the LogLevel constants
+func IsLogLevel(level string) bool {
+	_, ok := logLevelRank[level]
+	return ok
+}
+
+func orInfo(level string) string {
+	if _, ok := logLevelRank[level]; ok {
+		return level
+	}
+	return LogLevelInfo
+}
+
+func findKey(fields map[string]string, keys []string) string {
+	for _, key := range keys {
+		if _, ok := fields[key]; ok {
+			return key
+		}
+	}
+	return ""
+}
+
+// parseLogTime reads RFC3339 times and unix epochs in seconds or milliseconds
+func parseLogTime(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999Z07:00", "2006-01-02 15:04:05.999999999"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	epoch, err := strconv.ParseFloat(value, 64)
+	if err != nil || epoch <= 0 {
+		return time.Time{}, false
+	}
+	if epoch > 1e12 {
+		epoch /= 1000 // milliseconds
+	}
+	seconds, fraction := math.Modf(epoch)
+	return time.Unix(int64(seconds), int64(fraction*1e9)), true
+}
+
+// flattenJSON turns nested objects into dotted keys; arrays are kept as JSON
+func flattenJSON(prefix string, value any, fields map[string]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			flattenJSON(key, child, fields)
+		}
+	case string:
+		fields[prefix] = v
+	case nil:
+		fields[prefix] = "null"
+	case json.Number, bool:
+		fields[prefix] = fmt.Sprint(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			fields[prefix] = fmt.Sprint(v)
+			return
+		}
+		fields[prefix] = string(data)
+	}
+}
+
+// jsonKeyOrder returns the flattened keys in the order they appear in the line, which maps lose
+func jsonKeyOrder(text string, fields map[string]string) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	position := func(key string) int {
+		leaf := key[strings.LastIndex(key, ".")+1:]
+		if i := strings.Index(text, `"`+leaf+`"`); i >= 0 {
+			return i
+		}
+		return len(text)
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		pi, pj := position(keys[i]), position(keys[j])
+		if pi != pj {
+			return pi < pj
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
@@ -0,0 +1,85 @@
+package k8s
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseStructuredLog(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		format  string // "" when the line is not structured
+		level   string
+		time    time.Time
+		message string
+		keys    []string
+	}{
+		{
+			name:    "json",
+			line:    `{"level":"WARNING","ts":"2024-05-01T10:00:00Z","msg":"slow request","path":"/api","latency":{"ms":1200}}`,
+			format:  LogFormatJSON,
+			level:   LogLevelWarn,
+			time:    time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+			message: "slow request",
+			keys:    []string{"path", "latency.ms"},
+		},
+		{
+			name:    "json after the API server timestamp",
+			line:    `2024-05-01T10:00:01.5Z {"severity":"error","message":"connection refused"}`,
+			format:  LogFormatJSON,
+			level:   LogLevelError,
+			message: "connection refused",
+		},
+		{
+			name:    "logfmt",
+			line:    `level=info msg="listening on :8080" addr=0.0.0.0 port=8080`,
+			format:  LogFormatLogfmt,
+			level:   LogLevelInfo,
+			message: "listening on :8080",
+			keys:    []string{"addr", "port"},
+		},
+		{
+			name: "plain text with one pair",
+			line: "retrying request attempt=3",
+		},
+		{
+			name: "plain text mostly made of words",
+			line: "user logged in with level=admin and role=owner from the dashboard today",
+		},
+		{
+			name: "pairs without a well-known key",
+			line: "a=1 b=2 c=3",
+		},
+		{
+			name: "not a JSON object",
+			line: `{"unterminated": true`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, ok := ParseStructuredLog(tt.line)
+			if tt.format == "" {
+				if ok {
+					t.Fatalf("parsed as %s, want plain text", entry.Format)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("not parsed, want %s", tt.format)
+			}
+			if entry.Format != tt.format || entry.Level != tt.level || entry.Message != tt.message {
+				t.Errorf("parsed as %s with level %q and message %q, want %s, %q and %q",
+					entry.Format, entry.Level, entry.Message, tt.format, tt.level, tt.message)
+			}
+			if !entry.Time.Equal(tt.time) {
+				t.Errorf("time %v, want %v", entry.Time, tt.time)
+			}
+			if !slices.Equal(entry.Keys, tt.keys) {
+				t.Errorf("keys %v, want %v", entry.Keys, tt.keys)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package k8s
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// Log stream notices besides LogTailStarted, LogTailStopped and LogTailError
+const (
+	LogTailInterrupted = "interrupted" // the stream ended while the container may still log, reconnecting
+	LogTailResumed     = "resumed"     // the stream is back after an interruption
+)
+
+const (
+	logReconnectMinDelay = time.Second
+	logReconnectMaxDelay = 30 * time.Second
+)
+
+// FollowPodLogs streams the logs of one container to handle until ctx is cancelled or handle returns
+// false. Followed streams that end while the container can still log, because the connection dropped
+// or the container restarted, are reopened from the last line seen without repeating it. Without
+// Follow the logs are read once. The error is nil when the logs ended normally.
+func (k *KubeConfig) FollowPodLogs(ctx context.Context, contextName, namespace, podName string, opts LogOptions, handle func(LogLine) bool) error {
+	// Create a temporary client config for the specified context
+	tempConfig := clientcmd.NewNonInteractiveClientConfig(
+		*k.config,
+		contextName,
+		&clientcmd.ConfigOverrides{},
+		nil,
+	)
+
+	restConfig, err := tempConfig.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get client config: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	return followLogs(ctx, clientset, namespace, podName, podLogOptions(opts), handle)
+}
+
+// followLogs reads a container's logs, reconnecting followed streams as FollowPodLogs describes.
+// Lines must carry timestamps, which is how already seen lines are recognised after reconnecting.
+func followLogs(ctx context.Context, clientset kubernetes.Interface, namespace, podName string, opts corev1.PodLogOptions, handle func(LogLine) bool) error {
+	opts.Timestamps = true
+	container := opts.Container
+	emit := func(line LogLine) bool {
+		line.Pod, line.Container = podName, container
+		return handle(line)
+	}
+
+	resume := newLogResume()
+
+	delay := logReconnectMinDelay
+	interrupted := false
+	for attempt := 0; ; attempt++ {
+		logs, err := clientset.CoreV1().Pods(namespace).GetLogs(podName, &opts).Stream(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if attempt == 0 || !opts.Follow || apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to get logs: %w", err)
+			}
+		} else {
+			if attempt == 0 {
+				if !emit(LogLine{Notice: LogTailStarted}) {
+					logs.Close()
+					return nil
+				}
+			}
+
+			resume.reconnect()
+			scanner := bufio.NewScanner(logs)
+			scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+			for scanner.Scan() {
+				text := scanner.Text()
+				if !resume.fresh(text) {
+					continue
+				}
+
+				if interrupted {
+					interrupted = false
+					delay = logReconnectMinDelay
+					if !emit(LogLine{Notice: LogTailResumed}) {
+						logs.Close()
+						return nil
+					}
+				}
+				if !emit(LogLine{Text: text}) {
+					logs.Close()
+					return nil
+				}
+			}
+			logs.Close()
+			err = scanner.Err()
+		}
+
+		if ctx.Err() != nil || !opts.Follow {
+			return nil
+		}
+
+		// A container that exited for good has nothing more to say
+		if finished, finishErr := containerFinished(ctx, clientset, namespace, podName, container); finished {
+			return finishErr
+		}
+
+		if !interrupted {
+			interrupted = true
+			reason := "stream ended"
+			if err != nil {
+				reason = err.Error()
+			}
+			if !emit(LogLine{Notice: LogTailInterrupted, Text: reason}) {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, logReconnectMaxDelay)
+
+		// Resume where we left off; without a line yet, start from now on
+		since := resume.last
+		if since.IsZero() {
+			since = time.Now()
+		}
+		sinceTime := metav1.NewTime(since)
+		opts.SinceTime = &sinceTime
+		opts.SinceSeconds = nil
+		opts.TailLines = nil
+		opts.LimitBytes = nil
+	}
+}
+
+// containerFinished reports whether a container has terminated and will not be restarted, or the pod is gone
+func containerFinished(ctx context.Context, clientset kubernetes.Interface, namespace, podName, containerName string) (bool, error) {
+	getCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	pod, err := clientset.CoreV1().Pods(namespace).Get(getCtx, podName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, fmt.Errorf("pod %s was deleted", podName)
+		}
+		// Unknown, keep trying
+		return false, nil
+	}
+	if isTerminalPod(pod) || pod.DeletionTimestamp != nil {
+		return true, nil
+	}
+
+	// Init containers only run again after failing, ephemeral containers never do
+	for _, status := range pod.Status.InitContainerStatuses {
+		if status.Name == containerName && status.State.Terminated != nil && status.State.Terminated.ExitCode == 0 {
+			return true, nil
+		}
+	}
+	for _, status := range pod.Status.EphemeralContainerStatuses {
+		if status.Name == containerName && status.State.Terminated != nil {
+			return true, nil
+		}
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != containerName || status.State.Terminated == nil {
+			continue
+		}
+		switch pod.Spec.RestartPolicy {
+		case corev1.RestartPolicyNever:
+			return true, nil
+		case corev1.RestartPolicyOnFailure:
+			return status.State.Terminated.ExitCode == 0, nil
+		}
+	}
+	return false, nil
+}
+
+// logResume recognises the lines a reopened stream repeats. A stream is reopened from the last
+// timestamp seen, and SinceTime has second precision, so a reconnect repeats that whole second.
+type logResume struct {
+	last       time.Time      // the last timestamp seen
+	seenAtLast map[string]int // the lines seen at exactly that time, by count
+	resumeFrom time.Time      // last when the current stream was opened
+	replay     map[string]int // the lines at resumeFrom the current stream has yet to repeat
+}
+
+func newLogResume() *logResume {
+	return &logResume{seenAtLast: make(map[string]int)}
+}
+
+// reconnect starts a new stream, which repeats the lines up to the last one seen
+func (r *logResume) reconnect() {
+	r.resumeFrom = r.last
+	r.replay = maps.Clone(r.seenAtLast)
+}
+
+// fresh records a line of the current stream and reports whether it was not seen before. Lines
+// without a timestamp cannot be recognised and are always fresh.
+func (r *logResume) fresh(text string) bool {
+	timestamp, _, ok := SplitLogTimestamp(text)
+	if !ok {
+		return true
+	}
+	if timestamp.Before(r.resumeFrom) {
+		return false
+	}
+	if timestamp.Equal(r.resumeFrom) && r.replay[text] > 0 {
+		r.replay[text]--
+		return false
+	}
+	if timestamp.After(r.last) {
+		r.last = timestamp
+		clear(r.seenAtLast)
+	}
+	r.seenAtLast[text]++
+	return true
+}
@@ -0,0 +1,82 @@
+package k8s
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLogResumeSkipsRepeatedLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		streams [][]string // the lines of each stream, in order of (re)connecting
+		want    []string
+	}{
+		{
+			name: "single stream",
+			streams: [][]string{{
+				"2024-05-01T10:00:00.1Z a",
+				"2024-05-01T10:00:00.2Z b",
+			}},
+			want: []string{"2024-05-01T10:00:00.1Z a", "2024-05-01T10:00:00.2Z b"},
+		},
+		{
+			name: "lines before the resume point",
+			streams: [][]string{
+				{"2024-05-01T10:00:00.1Z a", "2024-05-01T10:00:00.5Z b"},
+				{"2024-05-01T10:00:00.1Z a", "2024-05-01T10:00:00.3Z late", "2024-05-01T10:00:00.5Z b", "2024-05-01T10:00:01.0Z c"},
+			},
+			want: []string{"2024-05-01T10:00:00.1Z a", "2024-05-01T10:00:00.5Z b", "2024-05-01T10:00:01.0Z c"},
+		},
+		{
+			name: "lines repeated at the resume point",
+			streams: [][]string{
+				{"2024-05-01T10:00:00.5Z a", "2024-05-01T10:00:00.5Z b"},
+				{"2024-05-01T10:00:00.5Z a", "2024-05-01T10:00:00.5Z b", "2024-05-01T10:00:00.5Z c"},
+			},
+			want: []string{"2024-05-01T10:00:00.5Z a", "2024-05-01T10:00:00.5Z b", "2024-05-01T10:00:00.5Z c"},
+		},
+		{
+			name: "identical lines at the resume point are counted",
+			streams: [][]string{
+				{"2024-05-01T10:00:00.5Z tick", "2024-05-01T10:00:00.5Z tick"},
+				{"2024-05-01T10:00:00.5Z tick", "2024-05-01T10:00:00.5Z tick", "2024-05-01T10:00:00.5Z tick"},
+			},
+			want: []string{"2024-05-01T10:00:00.5Z tick", "2024-05-01T10:00:00.5Z tick", "2024-05-01T10:00:00.5Z tick"},
+		},
+		{
+			name: "lines without timestamps are kept",
+			streams: [][]string{
+				{"2024-05-01T10:00:00.5Z a", "continued"},
+				{"2024-05-01T10:00:00.5Z a", "continued", "2024-05-01T10:00:01.0Z b"},
+			},
+			want: []string{"2024-05-01T10:00:00.5Z a", "continued", "continued", "2024-05-01T10:00:01.0Z b"},
+		},
+		{
+			name: "several reconnects",
+			streams: [][]string{
+				{"2024-05-01T10:00:00.5Z a"},
+				{"2024-05-01T10:00:00.5Z a", "2024-05-01T10:00:02.0Z b"},
+				{"2024-05-01T10:00:02.0Z b", "2024-05-01T10:00:02.0Z b"},
+			},
+			want: []string{"2024-05-01T10:00:00.5Z a", "2024-05-01T10:00:02.0Z b", "2024-05-01T10:00:02.0Z b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resume := newLogResume()
+			var got []string
+			for _, stream := range tt.streams {
+				resume.reconnect()
+				for _, line := range stream {
+					if resume.fresh(line) {
+						got = append(got, line)
+					}
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("emitted %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,567 @@
+package k8s
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// Log tail notices, sent alongside log lines when the set of streamed containers changes
+const (
+	LogTailStarted = "started"
+	LogTailStopped = "stopped"
+	LogTailError   = "error"
+)
+
+const (
+	// logTailInitialLines is how many lines of history are shown for containers already running when tailing starts
+	logTailInitialLines = 10
+	// logTailRetryDelay is how long to wait before re-establishing a failed pod watch
+	logTailRetryDelay = 5 * time.Second
+)
+
+// LogLine is one line from an aggregated log tail. Notice is set instead of a log line when
+// a container stream starts or stops, or when a watch or stream fails.
+type LogLine struct {
+	Pod       string
+	Container string
+	Text      string
+	Notice    string
+}
+
+// LogTailer follows the logs of every container in the pods matching a label selector,
+// starting and stopping streams as pods come and go
+type LogTailer struct {
+	kubeConfig  *KubeConfig
+	contextName string
+	namespace   string
+	selector    string
+	pods        map[string]bool // "namespace/pod" keys; when set, only these pods are tailed
+	options     LogOptions
+
+	lines   chan LogLine
+	mu      sync.Mutex
+	streams map[string]context.CancelFunc // "namespace/pod/container" -> stream cancel
+	started map[string]metav1.Time        // "namespace/pod/container" -> start time of the last streamed instance
+	synced  map[string]bool               // namespaces whose pods have been listed
+	cancel  context.CancelFunc
+}
+
+// NewLogTailer creates a log tailer for the pods matching selector; call Start to begin tailing
+func (k *KubeConfig) NewLogTailer(contextName, namespace, selector string) *LogTailer {
+	return &LogTailer{
+		kubeConfig:  k,
+		contextName: contextName,
+		namespace:   namespace,
+		selector:    selector,
+		lines:       make(chan LogLine, 1000),
+		streams:     make(map[string]context.CancelFunc),
+		started:     make(map[string]metav1.Time),
+		synced:      make(map[string]bool),
+	}
+}
+
+// SetOptions sets where the logs of containers already running when tailing starts begin, and
+// how much of them is read; call it before Start. Containers that start later are always read
+// from their first line.
+func (t *LogTailer) SetOptions(opts LogOptions) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.options = opts
+}
+
+// SetPods limits tailing to the given pods, as "namespace/pod" keys; call it before Start
+func (t *LogTailer) SetPods(pods []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pods = podKeySet(pods)
+}
+
+// Start begins watching pods and tailing their logs in the background
+func (t *LogTailer) Start() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.cancel = cancel
+	go t.run(ctx)
+}
+
+// Stop ends the pod watch and every log stream
+func (t *LogTailer) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.cancel != nil {
+		t.cancel()
+		t.cancel = nil
+	}
+}
+
+// Lines returns the channel log lines and notices are delivered on
+func (t *LogTailer) Lines() <-chan LogLine {
+	return t.lines
+}
+
+// Streams returns the number of containers currently being tailed
+func (t *LogTailer) Streams() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.streams)
+}
+
+func (t *LogTailer) run(ctx context.Context) {
+	// Create a temporary client config for the specified context
+	tempConfig := clientcmd.NewNonInteractiveClientConfig(
+		*t.kubeConfig.config,
+		t.contextName,
+		&clientcmd.ConfigOverrides{},
+		nil,
+	)
+
+	restConfig, err := tempConfig.ClientConfig()
+	if err != nil {
+		t.send(ctx, LogLine{Notice: LogTailError, Text: fmt.Sprintf("failed to get client config: %v", err)})
+		return
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		t.send(ctx, LogLine{Notice: LogTailError, Text: fmt.Sprintf("failed to create client: %v", err)})
+		return
+	}
+
+	for _, namespace := range podNamespaces(t.namespace, t.pods) {
+		go t.follow(ctx, clientset, namespace)
+	}
+}
+
+// podNamespaces returns the namespaces to list pods in: the namespace, or, when it is empty and
+// pods are chosen as "namespace/pod" keys, their namespaces rather than the whole cluster
+func podNamespaces(namespace string, pods map[string]bool) []string {
+	if namespace != "" || pods == nil {
+		return []string{namespace}
+	}
+	var namespaces []string
+	for key := range pods {
+		podNamespace, _, _ := strings.Cut(key, "/")
+		if !slices.Contains(namespaces, podNamespace) {
+			namespaces = append(namespaces, podNamespace)
+		}
+	}
+	slices.Sort(namespaces)
+	return namespaces
+}
+
+// follow watches the pods of a namespace, relisting after failures, until the tailer is stopped
+func (t *LogTailer) follow(ctx context.Context, clientset *kubernetes.Clientset, namespace string) {
+	for ctx.Err() == nil {
+		if err := t.watchPods(ctx, clientset, namespace); err != nil && ctx.Err() == nil {
+			t.send(ctx, LogLine{Notice: LogTailError, Text: err.Error()})
+		}
+
+		// Back off before relisting
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(logTailRetryDelay):
+		}
+	}
+}
+
+// watchPods lists the matching pods and then follows changes until the watch can no longer be resumed
+func (t *LogTailer) watchPods(ctx context.Context, clientset *kubernetes.Clientset, namespace string) error {
+	listCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	list, err := clientset.CoreV1().Pods(namespace).List(listCtx, metav1.ListOptions{LabelSelector: t.selector})
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	// Stop streams of pods that disappeared while we were not watching
+	present := make(map[string]bool)
+	for i := range list.Items {
+		if !t.wants(&list.Items[i]) {
+			continue
+		}
+		present[podKey(&list.Items[i])] = true
+		t.syncPod(ctx, clientset, &list.Items[i])
+	}
+	t.stopMissing(namespace, present)
+
+	t.mu.Lock()
+	t.synced[namespace] = true
+	t.mu.Unlock()
+
+	resourceVersion := list.ResourceVersion
+	for ctx.Err() == nil {
+		watcher, err := clientset.CoreV1().Pods(namespace).Watch(ctx, metav1.ListOptions{
+			LabelSelector:   t.selector,
+			ResourceVersion: resourceVersion,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to watch pods: %w", err)
+		}
+
+		for watchEvent := range watcher.ResultChan() {
+			switch watchEvent.Type {
+			case watch.Added, watch.Modified:
+				if pod, ok := watchEvent.Object.(*corev1.Pod); ok {
+					resourceVersion = pod.ResourceVersion
+					if t.wants(pod) {
+						t.syncPod(ctx, clientset, pod)
+					}
+				}
+			case watch.Deleted:
+				if pod, ok := watchEvent.Object.(*corev1.Pod); ok {
+					resourceVersion = pod.ResourceVersion
+					if t.wants(pod) {
+						t.stopPod(podKey(pod))
+					}
+				}
+			case watch.Error:
+				watcher.Stop()
+				status := apierrors.FromObject(watchEvent.Object)
+				if apierrors.IsResourceExpired(status) || apierrors.IsGone(status) {
+					// Our resource version is too old, relist
+					return nil
+				}
+				return fmt.Errorf("pod watch failed: %w", status)
+			}
+		}
+		watcher.Stop()
+	}
+
+	return nil
+}
+
+// wants reports whether a pod matching the selector is one of the pods to tail
+func (t *LogTailer) wants(pod *corev1.Pod) bool {
+	return t.pods == nil || t.pods[podKey(pod)]
+}
+
+// podKey identifies a pod across namespaces as "namespace/pod"
+func podKey(pod *corev1.Pod) string {
+	return pod.Namespace + "/" + pod.Name
+}
+
+// podKeySet turns "namespace/pod" keys into a set; no keys give a nil set, which allows every pod
+func podKeySet(pods []string) map[string]bool {
+	if len(pods) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(pods))
+	for _, pod := range pods {
+		set[pod] = true
+	}
+	return set
+}
+
+// syncPod starts a stream for every running container of the pod that is not streamed yet
+func (t *LogTailer) syncPod(ctx context.Context, clientset *kubernetes.Clientset, pod *corev1.Pod) {
+	if pod.DeletionTimestamp != nil || isTerminalPod(pod) {
+		t.stopPod(podKey(pod))
+		return
+	}
+
+	for _, statuses := range [][]corev1.ContainerStatus{
+		pod.Status.InitContainerStatuses,
+		pod.Status.ContainerStatuses,
+		pod.Status.EphemeralContainerStatuses,
+	} {
+		for _, status := range statuses {
+			if status.State.Running == nil {
+				continue
+			}
+
+			key := podKey(pod) + "/" + status.Name
+			t.mu.Lock()
+			_, streaming := t.streams[key]
+			// A stale status can still report an instance whose stream already ended
+			startedAt := status.State.Running.StartedAt
+			lastStarted, ok := t.started[key]
+			seen := ok && lastStarted.Equal(&startedAt)
+			synced := t.synced[pod.Namespace]
+			if !streaming && !seen {
+				t.started[key] = startedAt
+				streamCtx, cancel := context.WithCancel(ctx)
+				t.streams[key] = cancel
+				options := LogOptions{Container: status.Name, Follow: true}
+				if synced {
+					// Containers that start while tailing are shown from their first line
+					options.SinceTime = startedAt.Time
+				} else {
+					options.SinceTime = t.options.SinceTime
+					options.SinceSeconds = t.options.SinceSeconds
+					options.LimitBytes = t.options.LimitBytes
+					options.TailLines = t.options.TailLines
+					if options.TailLines == 0 && options.SinceTime.IsZero() && options.SinceSeconds == 0 {
+						options.TailLines = logTailInitialLines
+					}
+				}
+				go t.stream(streamCtx, clientset, pod.Namespace, pod.Name, status.Name, podLogOptions(options))
+			}
+			t.mu.Unlock()
+		}
+	}
+}
+
+// stream follows one container until it exits for good or the stream is cancelled
+func (t *LogTailer) stream(ctx context.Context, clientset *kubernetes.Clientset, namespace, podName, containerName string, opts corev1.PodLogOptions) {
+	key := namespace + "/" + podName + "/" + containerName
+	defer func() {
+		t.mu.Lock()
+		if cancel, ok := t.streams[key]; ok {
+			cancel()
+			delete(t.streams, key)
+		}
+		t.mu.Unlock()
+		t.send(ctx, LogLine{Pod: podName, Container: containerName, Notice: LogTailStopped})
+	}()
+
+	err := followLogs(ctx, clientset, namespace, podName, opts, func(line LogLine) bool {
+		return t.send(ctx, line)
+	})
+	if err != nil {
+		t.send(ctx, LogLine{Pod: podName, Container: containerName, Notice: LogTailError, Text: err.Error()})
+	}
+}
+
+// stopPod cancels every stream of a pod, given as a "namespace/pod" key
+func (t *LogTailer) stopPod(pod string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key, cancel := range t.streams {
+		if streamPod(key) == pod {
+			cancel()
+		}
+	}
+	for key := range t.started {
+		if streamPod(key) == pod {
+			delete(t.started, key)
+		}
+	}
+}
+
+// stopMissing cancels the streams of pods in a namespace that are no longer present; watched
+// namespaces are all of them when the namespace is empty
+func (t *LogTailer) stopMissing(namespace string, present map[string]bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	missing := func(key string) bool {
+		pod := streamPod(key)
+		podNamespace, _, _ := strings.Cut(pod, "/")
+		return (namespace == "" || podNamespace == namespace) && !present[pod]
+	}
+	for key, cancel := range t.streams {
+		if missing(key) {
+			cancel()
+		}
+	}
+	for key := range t.started {
+		if missing(key) {
+			delete(t.started, key)
+		}
+	}
+}
+
+// streamPod returns the "namespace/pod" part of a "namespace/pod/container" stream key
+func streamPod(key string) string {
+	return key[:strings.LastIndex(key, "/")]
+}
+
+// send delivers a line unless the tailer was stopped; it reports whether the line was sent
+func (t *LogTailer) send(ctx context.Context, line LogLine) bool {
+	select {
+	case t.lines <- line:
+		return true
+	case <-ctx.Done():
+		// Still report stopped streams when the tailer itself keeps running
+		if line.Notice == LogTailStopped {
+			select {
+			case t.lines <- line:
+			default:
+			}
+		}
+		return false
+	}
+}
+
+// ResolveLogSelector turns a log tail target into a label selector. The target is either a workload
+// such as "deployment/api" (ReplicaSets resolve to their Deployment) or a label selector such as "app=api".
+func (k *KubeConfig) ResolveLogSelector(contextName, namespace, target string) (string, error) {
+	target = strings.TrimSpace(target)
+	kind, name, isWorkload := strings.Cut(target, "/")
+	if !isWorkload {
+		if _, err := labels.Parse(target); err != nil {
+			return "", fmt.Errorf("invalid label selector: %w", err)
+		}
+		return target, nil
+	}
+	if namespace == "" {
+		return "", fmt.Errorf("select a namespace to tail %s", target)
+	}
+
+	// Create a temporary client config for the specified context
+	tempConfig := clientcmd.NewNonInteractiveClientConfig(
+		*k.config,
+		contextName,
+		&clientcmd.ConfigOverrides{},
+		nil,
+	)
+
+	restConfig, err := tempConfig.ClientConfig()
+	if err != nil {
+		return "", fmt.Errorf("failed to get client config: %w", err)
+	}
+
+	// Set a reasonable timeout
+	restConfig.Timeout = 10 * time.Second
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to create client: %w", err)
+	}
+
+	// Create a context with timeout for the API call
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var selector *metav1.LabelSelector
+	switch strings.ToLower(kind) {
+	case "deployment", "deployments", "deploy":
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to get deployment: %w", err)
+		}
+		selector = deployment.Spec.Selector
+	case "statefulset", "statefulsets", "sts":
+		statefulSet, err := clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to get statefulset: %w", err)
+		}
+		selector = statefulSet.Spec.Selector
+	case "daemonset", "daemonsets", "ds":
+		daemonSet, err := clientset.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to get daemonset: %w", err)
+		}
+		selector = daemonSet.Spec.Selector
+	case "replicaset", "replicasets", "rs":
+		replicaSet, err := clientset.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to get replicaset: %w", err)
+		}
+		selector = replicaSet.Spec.Selector
+		// Follow the whole rollout rather than a single revision
+		for _, owner := range replicaSet.OwnerReferences {
+			if owner.Kind == "Deployment" {
+				return k.ResolveLogSelector(contextName, namespace, "deployment/"+owner.Name)
+			}
+		}
+	case "job", "jobs":
+		job, err := clientset.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to get job: %w", err)
+		}
+		selector = job.Spec.Selector
+	default:
+		return "", fmt.Errorf("unsupported workload kind %q (use deployment, statefulset, daemonset, replicaset or job)", kind)
+	}
+
+	if selector == nil {
+		return "", fmt.Errorf("%s has no pod selector", target)
+	}
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return "", fmt.Errorf("invalid selector on %s: %w", target, err)
+	}
+	return labelSelector.String(), nil
+}
+
+// WriteSelectorLogs writes the logs of every container of the pods matching a selector to w, each
+// line prefixed with "pod/container", one container after the other. When pods is set, only those
+// pods, given as "namespace/pod" keys, are written.
+func (k *KubeConfig) WriteSelectorLogs(contextName, namespace, selector string, pods []string, opts LogOptions, w io.Writer) error {
+	// Create a temporary client config for the specified context
+	tempConfig := clientcmd.NewNonInteractiveClientConfig(
+		*k.config,
+		contextName,
+		&clientcmd.ConfigOverrides{},
+		nil,
+	)
+
+	restConfig, err := tempConfig.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get client config: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	wanted := podKeySet(pods)
+	var items []corev1.Pod
+	for _, listNamespace := range podNamespaces(namespace, wanted) {
+		listCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		list, err := clientset.CoreV1().Pods(listNamespace).List(listCtx, metav1.ListOptions{LabelSelector: selector})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to list pods: %w", err)
+		}
+		items = append(items, list.Items...)
+	}
+
+	for _, pod := range items {
+		if wanted != nil && !wanted[pod.Namespace+"/"+pod.Name] {
+			continue
+		}
+		for _, container := range slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers) {
+			opts.Container = container.Name
+			opts.Follow = false
+			podLogOpts := podLogOptions(opts)
+			logs, err := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &podLogOpts).Stream(context.Background())
+			if err != nil {
+				// Containers that never started have no logs
+				if apierrors.IsBadRequest(err) {
+					continue
+				}
+				return fmt.Errorf("failed to get logs of %s/%s: %w", pod.Name, container.Name, err)
+			}
+
+			scanner := bufio.NewScanner(logs)
+			scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+			for scanner.Scan() {
+				if _, err := fmt.Fprintf(w, "%s/%s %s\n", pod.Name, container.Name, scanner.Text()); err != nil {
+					logs.Close()
+					return err
+				}
+			}
+			logs.Close()
+			if err := scanner.Err(); err != nil {
+				return fmt.Errorf("failed to read logs of %s/%s: %w", pod.Name, container.Name, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package k8s
+
+import (
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLogTailerStopsOnlyItsNamespacesPod(t *testing.T) {
+	tailer := (&KubeConfig{}).NewLogTailer("test", "", "")
+	tailer.SetPods([]string{"team-a/web-0", "team-b/web-0"})
+
+	cancelled := make(map[string]bool)
+	for _, key := range []string{"team-a/web-0/app", "team-b/web-0/app"} {
+		tailer.streams[key] = func() { cancelled[key] = true }
+		tailer.started[key] = metav1.Now()
+	}
+
+	// Deleting a same-named pod that is not tailed leaves both streams alone
+	unrelated := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "team-c", Name: "web-0"}}
+	if tailer.wants(unrelated) {
+		t.Errorf("tailer wants %s, which was not chosen", podKey(unrelated))
+	}
+
+	tailer.stopPod("team-a/web-0")
+	if !cancelled["team-a/web-0/app"] || cancelled["team-b/web-0/app"] {
+		t.Errorf("stopPod(team-a/web-0) cancelled %v, want only team-a/web-0/app", cancelled)
+	}
+
+	// A relist of team-b that still has web-0 keeps its stream
+	tailer.stopMissing("team-b", map[string]bool{"team-b/web-0": true})
+	if cancelled["team-b/web-0/app"] {
+		t.Error("stopMissing cancelled team-b/web-0/app, which is still present")
+	}
+	tailer.stopMissing("team-b", map[string]bool{})
+	if !cancelled["team-b/web-0/app"] {
+		t.Error("stopMissing kept team-b/web-0/app, which is gone")
+	}
+}
+
+func TestPodNamespaces(t *testing.T) {
+	tests := []struct {
+		namespace string
+		pods      []string
+		want      []string
+	}{
+		{"default", nil, []string{"default"}},
+		{"", nil, []string{""}},
+		{"default", []string{"default/a", "default/b"}, []string{"default"}},
+		{"", []string{"team-b/a", "team-a/b", "team-b/c"}, []string{"team-a", "team-b"}},
+	}
+
+	for _, tt := range tests {
+		if got := podNamespaces(tt.namespace, podKeySet(tt.pods)); !slices.Equal(got, tt.want) {
+			t.Errorf("podNamespaces(%q, %v) = %v, want %v", tt.namespace, tt.pods, got, tt.want)
+		}
+	}
+}
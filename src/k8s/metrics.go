@@ -10,7 +10,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
-// GetClusterMetrics retrieves comprehensive cluster metrics including nodes, pods, and events
+// GetClusterMetrics retrieves comprehensive cluster metrics including nodes, pods, and resource usage
 func (k *KubeConfig) GetClusterMetrics(ctx context.Context) (*ClusterMetrics, error) {
 	// Get client config
 	restConfig, err := k.clientConfig.ClientConfig()
@@ -38,6 +38,18 @@ func (k *KubeConfig) GetClusterMetrics(ctx context.Context) (*ClusterMetrics, er
 
 	metrics.Nodes = calculateNodeMetrics(nodes.Items)
 
+	// Get live node usage (optional, requires metrics-server)
+	usage, err := listNodeUsage(ctx, restConfig)
+	if err != nil {
+		metrics.Nodes.UsageError = err
+	} else {
+		for _, nodeUsage := range usage {
+			metrics.Nodes.CPUUsage += nodeUsage.CPU
+			metrics.Nodes.MemUsage += nodeUsage.Memory
+		}
+		metrics.Nodes.UsageAvailable = true
+	}
+
 	// Get pod metrics
 	pods, err := clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
 	if err != nil {
@@ -46,13 +58,16 @@ func (k *KubeConfig) GetClusterMetrics(ctx context.Context) (*ClusterMetrics, er
 
 	metrics.Pods = calculatePodMetrics(pods.Items)
 
-	// Get events (warnings and errors for overview)
-	events, err := k.getRecentEvents(ctx, clientset)
-	if err != nil {
-		// Don't fail if we can't get events, just return empty slice
-		metrics.Events = []EventInfo{}
-	} else {
-		metrics.Events = events
+	// Account requests and limits per node and namespace
+	metrics.NodeAllocations, metrics.NamespaceAllocations = calculateAllocations(nodes.Items, pods.Items)
+	for _, allocation := range metrics.NodeAllocations {
+		metrics.Nodes.CPURequests += allocation.CPURequests
+		metrics.Nodes.CPULimits += allocation.CPULimits
+		metrics.Nodes.MemRequests += allocation.MemRequests
+		metrics.Nodes.MemLimits += allocation.MemLimits
+		if allocation.Overcommitted() {
+			metrics.Nodes.Overcommitted++
+		}
 	}
 
 	return metrics, nil
@@ -46,6 +46,22 @@ func (k *KubeConfig) GetNodes(contextName string) ([]NodeInfo, error) {
 		return nil, fmt.Errorf("failed to list nodes: %w", err)
 	}
 
+	// Get non-terminal pods to account requests and limits per node; listing pods across the
+	// cluster may be forbidden, so the nodes are still shown without their allocation
+	allocationsByNode := make(map[string]ResourceAllocation)
+	podList, allocationErr := clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{FieldSelector: nonTerminalPodsSelector})
+	if allocationErr != nil {
+		allocationErr = fmt.Errorf("failed to list pods: %w", allocationErr)
+	} else {
+		allocations, _ := calculateAllocations(nodeList.Items, podList.Items)
+		for _, allocation := range allocations {
+			allocationsByNode[allocation.Name] = allocation
+		}
+	}
+
+	// Get live usage; metrics-server is optional so failures just leave Usage empty
+	usage, usageErr := listNodeUsage(ctx, restConfig)
+
 	var nodes []NodeInfo
 	for _, node := range nodeList.Items {
 		nodeInfo := NodeInfo{
@@ -84,6 +100,7 @@ func (k *KubeConfig) GetNodes(contextName string) ([]NodeInfo, error) {
 		nodeInfo.Roles = roles
 
 		// Extract age
+		nodeInfo.CreationTime = node.CreationTimestamp.Time
 		age := time.Since(node.CreationTimestamp.Time)
 		nodeInfo.Age = formatDuration(age)
 
@@ -100,6 +117,25 @@ func (k *KubeConfig) GetNodes(contextName string) ([]NodeInfo, error) {
 		}
 		if mem, ok := node.Status.Capacity[corev1.ResourceMemory]; ok {
 			nodeInfo.MemCapacity = formatBytes(mem.Value())
+			nodeInfo.MemCapacityBytes = mem.Value()
+		}
+
+		// Extract allocatable
+		if cpu, ok := node.Status.Allocatable[corev1.ResourceCPU]; ok {
+			nodeInfo.CPUAllocatable = cpu.MilliValue()
+		}
+		if mem, ok := node.Status.Allocatable[corev1.ResourceMemory]; ok {
+			nodeInfo.MemAllocatable = mem.Value()
+		}
+
+		nodeInfo.Allocation = allocationsByNode[node.Name]
+		nodeInfo.AllocationError = allocationErr
+
+		// Attach live usage if available
+		if usageErr == nil {
+			if nodeUsage, ok := usage[node.Name]; ok {
+				nodeInfo.Usage = &nodeUsage
+			}
 		}
 
 		nodes = append(nodes, nodeInfo)
@@ -145,10 +181,10 @@ func calculateNodeMetrics(nodes []corev1.Node) NodeMetrics {
 
 		// Aggregate allocatable (available resources)
 		if cpu, ok := node.Status.Allocatable[corev1.ResourceCPU]; ok {
-			metrics.CPUAllocated += cpu.MilliValue()
+			metrics.CPUAllocatable += cpu.MilliValue()
 		}
 		if mem, ok := node.Status.Allocatable[corev1.ResourceMemory]; ok {
-			metrics.MemAllocated += mem.Value()
+			metrics.MemAllocatable += mem.Value()
 		}
 	}
 
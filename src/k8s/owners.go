@@ -0,0 +1,448 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// maxOwnerDepth bounds the owner chain, so a reference cycle cannot loop forever
+const maxOwnerDepth = 10
+
+// OwnerNode is an object in an ownership tree with its status
+type OwnerNode struct {
+	Ref     ObjectReference
+	Status  string // as the pods and applications views show it, e.g. Running, Complete
+	Ready   string // e.g. "2/3", empty for kinds without replicas
+	Detail  string // what else matters for the kind, e.g. "revision 4"
+	Created time.Time
+	// Missing is set for an owner that is referenced but no longer exists
+	Missing bool
+}
+
+// ownerClient reads the objects of an ownership tree. Kinds peek knows are read typed, so their
+// status matches the other views; any other kind, such as a custom resource, is read dynamically.
+type ownerClient struct {
+	clientset     *kubernetes.Clientset
+	dynamicClient dynamic.Interface
+	mapper        meta.RESTMapper
+}
+
+func (k *KubeConfig) newOwnerClient(contextName string) (*ownerClient, error) {
+	// Create a temporary client config for the specified context
+	tempConfig := clientcmd.NewNonInteractiveClientConfig(
+		*k.config,
+		contextName,
+		&clientcmd.ConfigOverrides{},
+		nil,
+	)
+
+	restConfig, err := tempConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client config: %w", err)
+	}
+
+	// Set a reasonable timeout
+	restConfig.Timeout = 10 * time.Second
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery client: %w", err)
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	return &ownerClient{
+		clientset:     clientset,
+		dynamicClient: dynamicClient,
+		mapper:        restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)),
+	}, nil
+}
+
+// GetOwnerChain resolves the controllers of an object up to the top, e.g. Pod, ReplicaSet,
+// Deployment or Pod, Job, CronJob. The object comes first. An owner that no longer exists ends
+// the chain as a missing node.
+func (k *KubeConfig) GetOwnerChain(contextName string, ref ObjectReference) ([]OwnerNode, error) {
+	client, err := k.newOwnerClient(contextName)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var chain []OwnerNode
+	seen := make(map[string]bool)
+	for len(chain) < maxOwnerDepth {
+		node, owners, err := client.get(ctx, ref)
+		if apierrors.IsNotFound(err) && len(chain) > 0 {
+			chain = append(chain, OwnerNode{Ref: ref, Status: "NotFound", Missing: true})
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, node)
+		seen[node.Ref.UID] = true
+
+		owner := controllerOf(owners)
+		if owner == nil || seen[string(owner.UID)] {
+			break
+		}
+		ref = ObjectReference{
+			APIVersion: owner.APIVersion,
+			Kind:       owner.Kind,
+			Namespace:  ref.Namespace,
+			Name:       owner.Name,
+			UID:        string(owner.UID),
+		}
+	}
+	return chain, nil
+}
+
+// GetOwnedObjects lists the objects an object owns directly, e.g. the ReplicaSets of a Deployment
+// or the Pods of a Job, newest first
+func (k *KubeConfig) GetOwnedObjects(contextName string, ref ObjectReference) ([]OwnerNode, error) {
+	client, err := k.newOwnerClient(contextName)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	owner := types.UID(ref.UID)
+	if owner == "" {
+		node, _, err := client.get(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		owner = types.UID(node.Ref.UID)
+	}
+
+	var children []OwnerNode
+	for _, kind := range ownedKinds(ref.Kind) {
+		nodes, err := client.listOwned(ctx, kind, ref.Namespace, owner)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, nodes...)
+	}
+	sort.SliceStable(children, func(i, j int) bool {
+		return children[i].Created.After(children[j].Created)
+	})
+	return children, nil
+}
+
+// ownedKinds are the kinds an object of a kind owns. Kinds peek does not know, such as Argo
+// Rollouts, commonly own ReplicaSets or Pods, so both are searched.
+func ownedKinds(kind string) []string {
+	switch kind {
+	case "Deployment":
+		return []string{"ReplicaSet"}
+	case "CronJob":
+		return []string{"Job"}
+	case "ReplicaSet", "StatefulSet", "DaemonSet", "Job":
+		return []string{"Pod"}
+	case "Pod":
+		return nil
+	default:
+		return []string{"ReplicaSet", "Pod"}
+	}
+}
+
+// controllerOf returns the owner that controls an object, or its first owner when none does
+func controllerOf(owners []metav1.OwnerReference) *metav1.OwnerReference {
+	for i := range owners {
+		if owners[i].Controller != nil && *owners[i].Controller {
+			return &owners[i]
+		}
+	}
+	if len(owners) > 0 {
+		return &owners[0]
+	}
+	return nil
+}
+
+func ownedBy(object metav1.Object, owner types.UID) bool {
+	for _, reference := range object.GetOwnerReferences() {
+		if reference.UID == owner {
+			return true
+		}
+	}
+	return false
+}
+
+// get reads an object and returns it as a node with its owners
+func (c *ownerClient) get(ctx context.Context, ref ObjectReference) (OwnerNode, []metav1.OwnerReference, error) {
+	group := schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind).Group
+	var object metav1.Object
+	var node OwnerNode
+	var err error
+
+	switch {
+	case ref.Kind == "Pod" && group == "":
+		var pod *corev1.Pod
+		if pod, err = c.clientset.CoreV1().Pods(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{}); err == nil {
+			object, node = pod, podNode(pod)
+		}
+	case ref.Kind == "ReplicaSet" && group == "apps":
+		var replicaSet *appsv1.ReplicaSet
+		if replicaSet, err = c.clientset.AppsV1().ReplicaSets(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{}); err == nil {
+			object, node = replicaSet, replicaSetNode(replicaSet)
+		}
+	case ref.Kind == "Deployment" && group == "apps":
+		var deployment *appsv1.Deployment
+		if deployment, err = c.clientset.AppsV1().Deployments(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{}); err == nil {
+			object, node = deployment, deploymentNode(deployment)
+		}
+	case ref.Kind == "StatefulSet" && group == "apps":
+		var statefulSet *appsv1.StatefulSet
+		if statefulSet, err = c.clientset.AppsV1().StatefulSets(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{}); err == nil {
+			object, node = statefulSet, statefulSetNode(statefulSet)
+		}
+	case ref.Kind == "DaemonSet" && group == "apps":
+		var daemonSet *appsv1.DaemonSet
+		if daemonSet, err = c.clientset.AppsV1().DaemonSets(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{}); err == nil {
+			object, node = daemonSet, daemonSetNode(daemonSet)
+		}
+	case ref.Kind == "Job" && group == "batch":
+		var job *batchv1.Job
+		if job, err = c.clientset.BatchV1().Jobs(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{}); err == nil {
+			object, node = job, jobNode(job)
+		}
+	case ref.Kind == "CronJob" && group == "batch":
+		var cronJob *batchv1.CronJob
+		if cronJob, err = c.clientset.BatchV1().CronJobs(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{}); err == nil {
+			object, node = cronJob, cronJobNode(cronJob)
+		}
+	default:
+		var unstructuredObject *unstructured.Unstructured
+		if unstructuredObject, err = c.getDynamic(ctx, ref); err == nil {
+			object, node = unstructuredObject, unstructuredNode(unstructuredObject)
+		}
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return OwnerNode{}, nil, err
+		}
+		return OwnerNode{}, nil, fmt.Errorf("failed to get %s %s: %w", ref.Kind, ref.Name, err)
+	}
+
+	node.Ref = ObjectReference{
+		APIVersion: ref.APIVersion,
+		Kind:       ref.Kind,
+		Namespace:  object.GetNamespace(),
+		Name:       object.GetName(),
+		UID:        string(object.GetUID()),
+	}
+	node.Created = object.GetCreationTimestamp().Time
+	return node, object.GetOwnerReferences(), nil
+}
+
+// getDynamic reads an object of any kind, resolving the kind to its resource first
+func (c *ownerClient) getDynamic(ctx context.Context, ref ObjectReference) (*unstructured.Unstructured, error) {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid apiVersion %q: %w", ref.APIVersion, err)
+	}
+	mapping, err := c.mapper.RESTMapping(gv.WithKind(ref.Kind).GroupKind(), gv.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s %s: %w", ref.APIVersion, ref.Kind, err)
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return c.dynamicClient.Resource(mapping.Resource).Namespace(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	}
+	return c.dynamicClient.Resource(mapping.Resource).Get(ctx, ref.Name, metav1.GetOptions{})
+}
+
+// listOwned lists the objects of a kind in a namespace that an owner owns
+func (c *ownerClient) listOwned(ctx context.Context, kind, namespace string, owner types.UID) ([]OwnerNode, error) {
+	var nodes []OwnerNode
+	add := func(object metav1.Object, apiVersion string, node OwnerNode) {
+		if !ownedBy(object, owner) {
+			return
+		}
+		node.Ref = ObjectReference{
+			APIVersion: apiVersion,
+			Kind:       kind,
+			Namespace:  object.GetNamespace(),
+			Name:       object.GetName(),
+			UID:        string(object.GetUID()),
+		}
+		node.Created = object.GetCreationTimestamp().Time
+		nodes = append(nodes, node)
+	}
+
+	switch kind {
+	case "Pod":
+		pods, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods: %w", err)
+		}
+		for i := range pods.Items {
+			add(&pods.Items[i], "v1", podNode(&pods.Items[i]))
+		}
+	case "ReplicaSet":
+		replicaSets, err := c.clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list replicasets: %w", err)
+		}
+		for i := range replicaSets.Items {
+			add(&replicaSets.Items[i], "apps/v1", replicaSetNode(&replicaSets.Items[i]))
+		}
+	case "Job":
+		jobs, err := c.clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list jobs: %w", err)
+		}
+		for i := range jobs.Items {
+			add(&jobs.Items[i], "batch/v1", jobNode(&jobs.Items[i]))
+		}
+	}
+	return nodes, nil
+}
+
+func podNode(pod *corev1.Pod) OwnerNode {
+	summary := summarizePod(pod)
+	node := OwnerNode{
+		Status: summary.Status,
+		Ready:  fmt.Sprintf("%d/%d", summary.Ready, summary.Total),
+	}
+	if summary.Restarts > 0 {
+		node.Detail = fmt.Sprintf("%d restarts", summary.Restarts)
+	}
+	if pod.Spec.NodeName != "" {
+		node.Detail = joinDetail(node.Detail, "on "+pod.Spec.NodeName)
+	}
+	return node
+}
+
+func replicaSetNode(replicaSet *appsv1.ReplicaSet) OwnerNode {
+	node := OwnerNode{
+		Status: getReplicaSetStatus(replicaSet),
+		Ready:  fmt.Sprintf("%d/%d", replicaSet.Status.ReadyReplicas, *replicaSet.Spec.Replicas),
+	}
+	if revision := replicaSet.Annotations["deployment.kubernetes.io/revision"]; revision != "" {
+		node.Detail = "revision " + revision
+	}
+	// Old ReplicaSets of a Deployment are scaled to zero and kept for rollbacks
+	if *replicaSet.Spec.Replicas == 0 {
+		node.Status = "ScaledDown"
+	}
+	return node
+}
+
+func deploymentNode(deployment *appsv1.Deployment) OwnerNode {
+	return OwnerNode{
+		Status: getDeploymentStatus(deployment),
+		Ready:  fmt.Sprintf("%d/%d", deployment.Status.ReadyReplicas, *deployment.Spec.Replicas),
+		Detail: fmt.Sprintf("%d up-to-date, %d available", deployment.Status.UpdatedReplicas, deployment.Status.AvailableReplicas),
+	}
+}
+
+func statefulSetNode(statefulSet *appsv1.StatefulSet) OwnerNode {
+	return OwnerNode{
+		Status: getStatefulSetStatus(statefulSet),
+		Ready:  fmt.Sprintf("%d/%d", statefulSet.Status.ReadyReplicas, *statefulSet.Spec.Replicas),
+		Detail: fmt.Sprintf("%d up-to-date", statefulSet.Status.UpdatedReplicas),
+	}
+}
+
+func daemonSetNode(daemonSet *appsv1.DaemonSet) OwnerNode {
+	return OwnerNode{
+		Status: getDaemonSetStatus(daemonSet),
+		Ready:  fmt.Sprintf("%d/%d", daemonSet.Status.NumberReady, daemonSet.Status.DesiredNumberScheduled),
+		Detail: fmt.Sprintf("%d up-to-date, %d available", daemonSet.Status.UpdatedNumberScheduled, daemonSet.Status.NumberAvailable),
+	}
+}
+
+func jobNode(job *batchv1.Job) OwnerNode {
+	completions := int32(1)
+	if job.Spec.Completions != nil {
+		completions = *job.Spec.Completions
+	}
+	node := OwnerNode{
+		Status: getJobStatus(job),
+		Ready:  fmt.Sprintf("%d/%d", job.Status.Succeeded, completions),
+	}
+	if job.Status.Active > 0 {
+		node.Detail = fmt.Sprintf("%d active", job.Status.Active)
+	}
+	if job.Status.Failed > 0 {
+		node.Detail = joinDetail(node.Detail, fmt.Sprintf("%d failed", job.Status.Failed))
+	}
+	return node
+}
+
+func cronJobNode(cronJob *batchv1.CronJob) OwnerNode {
+	node := OwnerNode{
+		Status: getCronJobStatus(cronJob),
+		Detail: "schedule " + cronJob.Spec.Schedule,
+	}
+	if cronJob.Status.LastScheduleTime != nil {
+		node.Detail = joinDetail(node.Detail,
+			"last run "+formatDuration(time.Since(cronJob.Status.LastScheduleTime.Time))+" ago")
+	}
+	return node
+}
+
+// unstructuredNode reads the status of a kind peek does not know from the conventions most
+// resources follow: a phase, or a Ready or Available condition
+func unstructuredNode(object *unstructured.Unstructured) OwnerNode {
+	node := OwnerNode{Status: "Unknown"}
+	if phase, found, _ := unstructured.NestedString(object.Object, "status", "phase"); found && phase != "" {
+		node.Status = phase
+	}
+	conditions, _, _ := unstructured.NestedSlice(object.Object, "status", "conditions")
+	for _, condition := range conditions {
+		fields, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditionType, _ := fields["type"].(string)
+		status, _ := fields["status"].(string)
+		if conditionType != "Ready" && conditionType != "Available" {
+			continue
+		}
+		if status == "True" {
+			node.Status = "Ready"
+		} else if reason, _ := fields["reason"].(string); reason != "" {
+			node.Status = reason
+		} else {
+			node.Status = "NotReady"
+		}
+		break
+	}
+	return node
+}
+
+func joinDetail(detail, more string) string {
+	if detail == "" {
+		return more
+	}
+	return detail + ", " + more
+}
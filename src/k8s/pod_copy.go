@@ -0,0 +1,374 @@
+package k8s
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// ErrNoTar is returned when a copy fails because the container has no tar binary, as in
+// distroless images
+var ErrNoTar = errors.New("the container has no tar binary, which copying needs")
+
+// errArchiveRead marks failures to read the archive stream, which the exec failing causes too
+var errArchiveRead = errors.New("failed to read archive")
+
+// CopyProgress is how far a copy is
+type CopyProgress struct {
+	File  string // the file being copied
+	Files int64  // files copied so far
+	Bytes int64  // bytes copied so far
+	Total int64  // bytes to copy, 0 when unknown
+	// Skipped counts the links and special files that were not copied
+	Skipped int64
+}
+
+// copyTracker records copy progress from the copying goroutine for the UI to read
+type copyTracker struct {
+	file    atomic.Value
+	files   atomic.Int64
+	bytes   atomic.Int64
+	total   atomic.Int64
+	skipped atomic.Int64
+}
+
+func (t *copyTracker) progress() CopyProgress {
+	file, _ := t.file.Load().(string)
+	return CopyProgress{
+		File:    file,
+		Files:   t.files.Load(),
+		Bytes:   t.bytes.Load(),
+		Total:   t.total.Load(),
+		Skipped: t.skipped.Load(),
+	}
+}
+
+// progressWriter counts the bytes written through it
+type progressWriter struct {
+	w       io.Writer
+	tracker *copyTracker
+}
+
+func (pw progressWriter) Write(p []byte) (int, error) {
+	n, err := pw.w.Write(p)
+	pw.tracker.bytes.Add(int64(n))
+	return n, err
+}
+
+// PodCopy copies files between the local machine and a container, like `kubectl cp`. The files
+// travel as a tar stream over exec, so the container needs tar.
+type PodCopy struct {
+	kubeConfig    *KubeConfig
+	contextName   string
+	namespace     string
+	podName       string
+	containerName string
+	tracker       copyTracker
+}
+
+// NewPodCopy prepares a copy to or from a container of a pod
+func (k *KubeConfig) NewPodCopy(contextName, namespace, podName, containerName string) *PodCopy {
+	return &PodCopy{
+		kubeConfig:    k,
+		contextName:   contextName,
+		namespace:     namespace,
+		podName:       podName,
+		containerName: containerName,
+	}
+}
+
+// Progress returns how far the copy is; it is safe to call while the copy runs
+func (c *PodCopy) Progress() CopyProgress {
+	return c.tracker.progress()
+}
+
+// ToContainer copies a local file or directory to containerPath. A container path ending in "/"
+// is a directory the local file or directory is copied into.
+func (c *PodCopy) ToContainer(ctx context.Context, localPath, containerPath string) error {
+	localPath = filepath.Clean(localPath)
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", localPath, err)
+	}
+	if strings.HasSuffix(containerPath, "/") {
+		containerPath += filepath.Base(localPath)
+	}
+	containerPath = path.Clean(containerPath)
+	if !path.IsAbs(containerPath) {
+		return fmt.Errorf("container path %s must be absolute", containerPath)
+	}
+
+	var total int64
+	if info.IsDir() {
+		err = filepath.Walk(localPath, func(_ string, info os.FileInfo, err error) error {
+			if err == nil && info.Mode().IsRegular() {
+				total += info.Size()
+			}
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", localPath, err)
+		}
+	} else {
+		total = info.Size()
+	}
+	c.tracker.total.Store(total)
+
+	restConfig, clientset, err := c.clients()
+	if err != nil {
+		return err
+	}
+
+	// Entries are named after the destination, so extracting them in its directory puts the copy
+	// at containerPath
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(c.writeTar(writer, localPath, path.Base(containerPath)))
+	}()
+	defer reader.Close()
+
+	command := []string{"tar", "-xmf", "-", "-C", path.Dir(containerPath)}
+	err = execInContainer(ctx, restConfig, clientset, c.namespace, c.podName, c.containerName, command, reader, nil)
+	return c.wrapError(err, "to")
+}
+
+// FromContainer copies a file or directory of the container to localPath. When localPath is an
+// existing directory the file or directory is copied into it.
+func (c *PodCopy) FromContainer(ctx context.Context, containerPath, localPath string) error {
+	containerPath = path.Clean(containerPath)
+	if !path.IsAbs(containerPath) {
+		return fmt.Errorf("container path %s must be absolute", containerPath)
+	}
+	if containerPath == "/" {
+		return errors.New("refusing to copy the whole container filesystem")
+	}
+	localPath = filepath.Clean(localPath)
+	if info, err := os.Stat(localPath); err == nil && info.IsDir() {
+		localPath = filepath.Join(localPath, path.Base(containerPath))
+	}
+
+	restConfig, clientset, err := c.clients()
+	if err != nil {
+		return err
+	}
+
+	reader, writer := io.Pipe()
+	extracted := make(chan error, 1)
+	go func() {
+		err := extractTar(reader, path.Base(containerPath), localPath, &c.tracker)
+		if err == nil {
+			// tar pads the archive after its end marker; read the padding so the stream finishes
+			_, err = io.Copy(io.Discard, reader)
+		}
+		// A refused archive stops the exec stream instead of leaving it blocked
+		reader.CloseWithError(err)
+		extracted <- err
+	}()
+
+	command := []string{"tar", "-cf", "-", "-C", path.Dir(containerPath), path.Base(containerPath)}
+	execErr := execInContainer(ctx, restConfig, clientset, c.namespace, c.podName, c.containerName, command, nil, writer)
+	writer.CloseWithError(execErr)
+	extractErr := <-extracted
+
+	switch {
+	case extractErr != nil && !errors.Is(extractErr, errArchiveRead):
+		// Refused entries and local write failures explain the copy stopping better than the
+		// stream closing does
+		return extractErr
+	case execErr != nil:
+		return c.wrapError(execErr, "from")
+	default:
+		return extractErr
+	}
+}
+
+func (c *PodCopy) clients() (*rest.Config, *kubernetes.Clientset, error) {
+	// Create a temporary client config for the specified context
+	tempConfig := clientcmd.NewNonInteractiveClientConfig(
+		*c.kubeConfig.config,
+		c.contextName,
+		&clientcmd.ConfigOverrides{},
+		nil,
+	)
+
+	restConfig, err := tempConfig.ClientConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get client config: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create client: %w", err)
+	}
+	return restConfig, clientset, nil
+}
+
+// wrapError explains a failed tar command, telling a missing tar apart from other failures
+func (c *PodCopy) wrapError(err error, direction string) error {
+	if err == nil {
+		return nil
+	}
+	if isMissingTar(err) {
+		return fmt.Errorf("cannot copy %s container %s: %w", direction, c.containerName, ErrNoTar)
+	}
+	return fmt.Errorf("failed to copy %s container %s: %w", direction, c.containerName, err)
+}
+
+// isMissingTar reports whether exec failed because tar could not be found. Shells exit with 127
+// for unknown commands; container runtimes fail the exec with "executable file not found".
+func isMissingTar(err error) bool {
+	var exitErr utilexec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitStatus() == 127 {
+		return true
+	}
+	message := err.Error()
+	return strings.Contains(message, `"tar": executable file not found`) ||
+		strings.Contains(message, "tar: not found") ||
+		strings.Contains(message, "tar: command not found") ||
+		strings.Contains(message, "tar: no such file or directory")
+}
+
+// writeTar writes a local file or directory as a tar stream with its entries under name
+func (c *PodCopy) writeTar(w io.Writer, localPath, name string) error {
+	tw := tar.NewWriter(w)
+	err := filepath.Walk(localPath, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(localPath, file)
+		if err != nil {
+			return err
+		}
+		entry := path.Join(name, filepath.ToSlash(rel))
+
+		link := ""
+		switch {
+		case info.Mode()&os.ModeSymlink != 0:
+			if link, err = os.Readlink(file); err != nil {
+				return err
+			}
+		case !info.Mode().IsRegular() && !info.IsDir():
+			c.tracker.skipped.Add(1)
+			return nil
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = entry
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		c.tracker.file.Store(entry)
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if _, err := io.Copy(progressWriter{w: tw, tracker: &c.tracker}, f); err != nil {
+			return err
+		}
+		c.tracker.files.Add(1)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to archive %s: %w", localPath, err)
+	}
+	return tw.Close()
+}
+
+// extractTar writes the entries of a tar stream named prefix or prefix/... to localPath. Entries
+// that would land outside localPath are refused, and links are skipped, so a compromised
+// container cannot write anywhere else on the local machine.
+func extractTar(r io.Reader, prefix, localPath string, tracker *copyTracker) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %w", errArchiveRead, err)
+		}
+
+		target, err := archiveEntryPath(header.Name, prefix, localPath)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return fmt.Errorf("failed to create %s: %w", target, err)
+			}
+		case tar.TypeReg:
+			tracker.file.Store(header.Name)
+			if err := writeArchiveFile(tr, target, header.FileInfo().Mode().Perm(), tracker); err != nil {
+				return err
+			}
+			tracker.files.Add(1)
+		default:
+			// Links could point anywhere; special files have no business on the local machine
+			tracker.skipped.Add(1)
+		}
+	}
+}
+
+// archiveEntryPath maps an archive entry to the local file it is extracted to, refusing absolute
+// entries and entries that climb out of localPath with ".."
+func archiveEntryPath(name, prefix, localPath string) (string, error) {
+	if path.IsAbs(name) || filepath.IsAbs(name) {
+		return "", fmt.Errorf("refusing archive entry %q: absolute path", name)
+	}
+	cleaned := path.Clean(strings.TrimPrefix(name, "./"))
+	var rel string
+	switch {
+	case cleaned == prefix:
+	case strings.HasPrefix(cleaned, prefix+"/"):
+		rel = strings.TrimPrefix(cleaned, prefix+"/")
+	default:
+		return "", fmt.Errorf("refusing archive entry %q: not under %s", name, prefix)
+	}
+
+	target := filepath.Join(localPath, filepath.FromSlash(rel))
+	within, err := filepath.Rel(localPath, target)
+	if err != nil || within == ".." || strings.HasPrefix(within, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("refusing archive entry %q: it escapes %s", name, localPath)
+	}
+	return target, nil
+}
+
+func writeArchiveFile(r io.Reader, target string, mode os.FileMode, tracker *copyTracker) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err)
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode|0o200)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", target, err)
+	}
+	if _, err := io.Copy(progressWriter{w: f, tracker: tracker}, r); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", target, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", target, err)
+	}
+	return nil
+}
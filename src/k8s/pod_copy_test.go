@@ -0,0 +1,115 @@
+package k8s
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestArchiveEntryPath(t *testing.T) {
+	localPath := t.TempDir()
+	tests := []struct {
+		name   string
+		want   string // relative to localPath
+		refuse bool
+	}{
+		{name: "app", want: "."},
+		{name: "app/config.yaml", want: "config.yaml"},
+		{name: "app/conf/nested/settings.json", want: "conf/nested/settings.json"},
+		{name: "./app/a", want: "a"},
+		{name: "app/./conf/../a", want: "a"},
+		{name: "/etc/passwd", refuse: true},
+		{name: "/app/a", refuse: true},
+		{name: "app/../../x", refuse: true},
+		{name: "app/../x", refuse: true},
+		{name: "../app/a", refuse: true},
+		{name: "appevil/x", refuse: true},
+		{name: "other/a", refuse: true},
+	}
+
+	for _, tt := range tests {
+		target, err := archiveEntryPath(tt.name, "app", localPath)
+		if tt.refuse {
+			if err == nil {
+				t.Errorf("archiveEntryPath(%q) = %q, want it refused", tt.name, target)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("archiveEntryPath(%q) failed: %v", tt.name, err)
+			continue
+		}
+		if want := filepath.Join(localPath, filepath.FromSlash(tt.want)); target != want {
+			t.Errorf("archiveEntryPath(%q) = %q, want %q", tt.name, target, want)
+		}
+	}
+}
+
+// buildTar writes the headers, with content for regular files, into a tar stream
+func buildTar(t *testing.T, entries []tar.Header, contents map[string]string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, header := range entries {
+		header.Size = int64(len(contents[header.Name]))
+		if err := tw.WriteHeader(&header); err != nil {
+			t.Fatalf("failed to write header %s: %v", header.Name, err)
+		}
+		if _, err := tw.Write([]byte(contents[header.Name])); err != nil {
+			t.Fatalf("failed to write %s: %v", header.Name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("failed to close tar: %v", err)
+	}
+	return &buf
+}
+
+func TestExtractTarSkipsLinks(t *testing.T) {
+	localPath := filepath.Join(t.TempDir(), "out")
+	archive := buildTar(t, []tar.Header{
+		{Name: "app/", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "app/conf/", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "app/conf/settings.json", Typeflag: tar.TypeReg, Mode: 0o644},
+		{Name: "app/passwd", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"},
+		{Name: "app/hard", Typeflag: tar.TypeLink, Linkname: "app/conf/settings.json"},
+	}, map[string]string{"app/conf/settings.json": `{"debug":true}`})
+
+	tracker := &copyTracker{}
+	if err := extractTar(archive, "app", localPath, tracker); err != nil {
+		t.Fatalf("extractTar failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(localPath, "conf", "settings.json"))
+	if err != nil {
+		t.Fatalf("nested file was not extracted: %v", err)
+	}
+	if string(data) != `{"debug":true}` {
+		t.Errorf("nested file holds %q", data)
+	}
+	for _, link := range []string{"passwd", "hard"} {
+		if _, err := os.Lstat(filepath.Join(localPath, link)); !os.IsNotExist(err) {
+			t.Errorf("link %s was extracted", link)
+		}
+	}
+	if files, skipped := tracker.files.Load(), tracker.skipped.Load(); files != 1 || skipped != 2 {
+		t.Errorf("extracted %d files and skipped %d entries, want 1 and 2", files, skipped)
+	}
+}
+
+func TestExtractTarRefusesTraversal(t *testing.T) {
+	root := t.TempDir()
+	localPath := filepath.Join(root, "out")
+	archive := buildTar(t, []tar.Header{
+		{Name: "app/../../escaped", Typeflag: tar.TypeReg, Mode: 0o644},
+	}, map[string]string{"app/../../escaped": "gotcha"})
+
+	if err := extractTar(archive, "app", localPath, &copyTracker{}); err == nil {
+		t.Fatal("extractTar accepted an entry climbing out of the destination")
+	}
+	if _, err := os.Stat(filepath.Join(root, "escaped")); !os.IsNotExist(err) {
+		t.Error("the escaping entry was written")
+	}
+}
@@ -0,0 +1,270 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// DescribePod gathers what `kubectl describe pod` shows: the pod's conditions, QoS class,
+// containers with their state, probes and mounts, its node and tolerations, and its events
+func (k *KubeConfig) DescribePod(contextName, namespace, podName string) (*PodDescription, error) {
+	// Create a temporary client config for the specified context
+	tempConfig := clientcmd.NewNonInteractiveClientConfig(
+		*k.config,
+		contextName,
+		&clientcmd.ConfigOverrides{},
+		nil,
+	)
+
+	restConfig, err := tempConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client config: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod: %w", err)
+	}
+
+	description := describePod(pod)
+	description.Events, description.EventsError = podEvents(ctx, clientset, pod)
+	return description, nil
+}
+
+// describePod converts the status and spec of a pod; events are listed separately
+func describePod(pod *corev1.Pod) *PodDescription {
+	summary := summarizePod(pod)
+	description := &PodDescription{
+		Name:           pod.Name,
+		Namespace:      pod.Namespace,
+		Status:         summary.Status,
+		QoSClass:       string(pod.Status.QOSClass),
+		ReadinessGates: summary.ReadinessGates,
+		Node:           pod.Spec.NodeName,
+		NodeIP:         pod.Status.HostIP,
+		PodIP:          pod.Status.PodIP,
+		ServiceAccount: pod.Spec.ServiceAccountName,
+		Priority:       pod.Spec.PriorityClassName,
+		CreationTime:   pod.CreationTimestamp.Time,
+		Labels:         pod.Labels,
+	}
+	if pod.Status.StartTime != nil {
+		description.StartTime = pod.Status.StartTime.Time
+	}
+
+	for _, owner := range pod.OwnerReferences {
+		description.Owners = append(description.Owners, owner.Kind+"/"+owner.Name)
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		description.Conditions = append(description.Conditions, PodConditionInfo{
+			Type:               string(condition.Type),
+			Status:             string(condition.Status),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: condition.LastTransitionTime.Time,
+		})
+	}
+
+	statuses := make(map[string]corev1.ContainerStatus)
+	for _, list := range [][]corev1.ContainerStatus{
+		pod.Status.InitContainerStatuses,
+		pod.Status.ContainerStatuses,
+		pod.Status.EphemeralContainerStatuses,
+	} {
+		for _, status := range list {
+			statuses[status.Name] = status
+		}
+	}
+	for _, container := range pod.Spec.InitContainers {
+		description.Containers = append(description.Containers, describeContainer(container, ContainerTypeInit, statuses))
+	}
+	for _, container := range pod.Spec.Containers {
+		description.Containers = append(description.Containers, describeContainer(container, ContainerTypeApp, statuses))
+	}
+	for _, ephemeral := range pod.Spec.EphemeralContainers {
+		container := corev1.Container(ephemeral.EphemeralContainerCommon)
+		described := describeContainer(container, ContainerTypeEphemeral, statuses)
+		described.TargetContainer = ephemeral.TargetContainerName
+		description.Containers = append(description.Containers, described)
+	}
+
+	for _, toleration := range pod.Spec.Tolerations {
+		description.Tolerations = append(description.Tolerations, formatToleration(toleration))
+	}
+
+	return description
+}
+
+func describeContainer(container corev1.Container, containerType string, statuses map[string]corev1.ContainerStatus) ContainerDescription {
+	description := ContainerDescription{
+		Name:  container.Name,
+		Type:  containerType,
+		Image: container.Image,
+		State: "Waiting",
+	}
+
+	if status, ok := statuses[container.Name]; ok {
+		description.Ready = status.Ready
+		description.RestartCount = status.RestartCount
+		description.State = "Unknown"
+		switch {
+		case status.State.Running != nil:
+			description.State = "Running"
+			description.StartedAt = status.State.Running.StartedAt.Time
+		case status.State.Waiting != nil:
+			description.State = "Waiting"
+			description.Reason = status.State.Waiting.Reason
+			description.Message = status.State.Waiting.Message
+		case status.State.Terminated != nil:
+			description.State = "Terminated"
+			description.Reason = status.State.Terminated.Reason
+			description.Message = status.State.Terminated.Message
+			description.Termination = convertTermination(status.State.Terminated)
+		}
+		if status.LastTerminationState.Terminated != nil {
+			description.LastTermination = convertTermination(status.LastTerminationState.Terminated)
+		}
+	}
+
+	for _, probe := range []struct {
+		name  string
+		probe *corev1.Probe
+	}{
+		{"Startup", container.StartupProbe},
+		{"Liveness", container.LivenessProbe},
+		{"Readiness", container.ReadinessProbe},
+	} {
+		if probe.probe != nil {
+			description.Probes = append(description.Probes, probe.name+": "+formatProbe(probe.probe))
+		}
+	}
+
+	for _, mount := range container.VolumeMounts {
+		mode := "rw"
+		if mount.ReadOnly {
+			mode = "ro"
+		}
+		line := fmt.Sprintf("%s from %s (%s)", mount.MountPath, mount.Name, mode)
+		if mount.SubPath != "" {
+			line = fmt.Sprintf("%s from %s (%s, path %q)", mount.MountPath, mount.Name, mode, mount.SubPath)
+		}
+		description.Mounts = append(description.Mounts, line)
+	}
+
+	return description
+}
+
+func convertTermination(state *corev1.ContainerStateTerminated) *ContainerTermination {
+	return &ContainerTermination{
+		ExitCode:   state.ExitCode,
+		Signal:     state.Signal,
+		Reason:     state.Reason,
+		Message:    strings.TrimSpace(state.Message),
+		StartedAt:  state.StartedAt.Time,
+		FinishedAt: state.FinishedAt.Time,
+	}
+}
+
+// formatProbe describes a probe the way kubectl describe does, e.g.
+// "http-get http://:8080/healthz delay=0s timeout=1s period=10s #success=1 #failure=3"
+func formatProbe(probe *corev1.Probe) string {
+	var action string
+	switch handler := probe.ProbeHandler; {
+	case handler.Exec != nil:
+		action = fmt.Sprintf("exec %v", handler.Exec.Command)
+	case handler.HTTPGet != nil:
+		scheme := strings.ToLower(string(handler.HTTPGet.Scheme))
+		if scheme == "" {
+			scheme = "http"
+		}
+		action = fmt.Sprintf("http-get %s://%s:%s%s", scheme, handler.HTTPGet.Host, handler.HTTPGet.Port.String(), handler.HTTPGet.Path)
+	case handler.TCPSocket != nil:
+		action = fmt.Sprintf("tcp-socket %s:%s", handler.TCPSocket.Host, handler.TCPSocket.Port.String())
+	case handler.GRPC != nil:
+		action = fmt.Sprintf("grpc <pod>:%d", handler.GRPC.Port)
+		if handler.GRPC.Service != nil && *handler.GRPC.Service != "" {
+			action += " " + *handler.GRPC.Service
+		}
+	default:
+		action = "unknown"
+	}
+
+	return fmt.Sprintf("%s delay=%ds timeout=%ds period=%ds #success=%d #failure=%d",
+		action, probe.InitialDelaySeconds, probe.TimeoutSeconds, probe.PeriodSeconds,
+		probe.SuccessThreshold, probe.FailureThreshold)
+}
+
+// formatToleration describes a toleration the way kubectl describe does, e.g.
+// "node.kubernetes.io/not-ready:NoExecute op=Exists for 300s"
+func formatToleration(toleration corev1.Toleration) string {
+	var b strings.Builder
+	b.WriteString(toleration.Key)
+	if toleration.Value != "" {
+		b.WriteString("=" + toleration.Value)
+	}
+	if toleration.Effect != "" {
+		b.WriteString(":" + string(toleration.Effect))
+	}
+	if toleration.Operator == corev1.TolerationOpExists && toleration.Value == "" {
+		if toleration.Key != "" || toleration.Effect != "" {
+			b.WriteString(" ")
+		}
+		b.WriteString("op=Exists")
+	}
+	if toleration.TolerationSeconds != nil {
+		fmt.Fprintf(&b, " for %ds", *toleration.TolerationSeconds)
+	}
+	return b.String()
+}
+
+// podEvents lists the events about a pod, oldest first. Events of an earlier pod with the same
+// name are left out.
+func podEvents(ctx context.Context, clientset *kubernetes.Clientset, pod *corev1.Pod) ([]EventInfo, error) {
+	return objectEvents(ctx, clientset, "Pod", pod.Namespace, pod.Name, pod.UID)
+}
+
+// objectEvents lists the events about an object, oldest first, leaving out the events of an
+// earlier object with the same kind and name
+func objectEvents(ctx context.Context, clientset *kubernetes.Clientset, kind, namespace, name string, uid types.UID) ([]EventInfo, error) {
+	list, err := clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.Set{
+			"involvedObject.kind": kind,
+			"involvedObject.name": name,
+		}.AsSelector().String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	var events []EventInfo
+	for i := range list.Items {
+		event := &list.Items[i]
+		if event.InvolvedObject.UID != "" && event.InvolvedObject.UID != uid {
+			continue
+		}
+		events = append(events, convertCoreV1Event(event))
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].LastTimestamp.Before(events[j].LastTimestamp)
+	})
+	return events, nil
+}
@@ -0,0 +1,117 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// LabelChanges maps a label key to its new value, or to nil when the label is removed
+type LabelChanges map[string]*string
+
+// ParseLabelChanges parses label changes in kubectl label syntax: `key=value` sets a label and
+// `key-` removes it, e.g. `tier=cache,owner=team-a,stale-`
+func ParseLabelChanges(input string) (LabelChanges, error) {
+	changes := make(LabelChanges)
+	for _, term := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if key, value, ok := strings.Cut(term, "="); ok {
+			if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+				return nil, fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, "; "))
+			}
+			if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+				return nil, fmt.Errorf("invalid label value %q: %s", value, strings.Join(errs, "; "))
+			}
+			changes[key] = &value
+			continue
+		}
+
+		key, ok := strings.CutSuffix(term, "-")
+		if !ok {
+			return nil, fmt.Errorf("invalid label change %q: use key=value or key-", term)
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, "; "))
+		}
+		changes[key] = nil
+	}
+
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("no label changes given")
+	}
+	return changes, nil
+}
+
+// String describes the changes, e.g. "set tier=cache, remove stale"
+func (c LabelChanges) String() string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var set, remove []string
+	for _, key := range keys {
+		if value := c[key]; value != nil {
+			set = append(set, key+"="+*value)
+		} else {
+			remove = append(remove, key)
+		}
+	}
+
+	var parts []string
+	if len(set) > 0 {
+		parts = append(parts, "set "+strings.Join(set, ","))
+	}
+	if len(remove) > 0 {
+		parts = append(parts, "remove "+strings.Join(remove, ","))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// LabelPod sets and removes labels on a pod with a merge patch, leaving its other labels alone
+func (k *KubeConfig) LabelPod(contextName, namespace, podName string, changes LabelChanges) error {
+	// Create a temporary client config for the specified context
+	tempConfig := clientcmd.NewNonInteractiveClientConfig(
+		*k.config,
+		contextName,
+		&clientcmd.ConfigOverrides{},
+		nil,
+	)
+
+	restConfig, err := tempConfig.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get client config: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	// A null value in a merge patch removes the label
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{"labels": changes},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build label patch: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err = clientset.CoreV1().Pods(namespace).Patch(ctx, podName, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to label pod: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package k8s
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// nodeUnreachablePodReason is the status reason the node lifecycle controller sets on pods of a
+// node that stopped reporting
+const nodeUnreachablePodReason = "NodeLost"
+
+// podSummary is what `kubectl get pods` prints for a pod
+type podSummary struct {
+	Status   string
+	Ready    int
+	Total    int
+	Restarts int32
+	// ReadinessGates counts the readiness gate conditions that are true, e.g. "1/2"; empty when
+	// the pod has no readiness gates
+	ReadinessGates string
+}
+
+// getPodStatus returns the pod status kubectl prints, e.g. Running, Init:1/3, CrashLoopBackOff
+func getPodStatus(pod *corev1.Pod) string {
+	return summarizePod(pod).Status
+}
+
+// summarizePod follows kubectl's pod printer: the status starts from the phase or the pod's reason,
+// is overridden by the first init container that has not finished, and otherwise by the app
+// containers, last container first. Sidecars, init containers that keep running, count as
+// containers once they have started.
+func summarizePod(pod *corev1.Pod) podSummary {
+	summary := podSummary{Total: len(pod.Spec.Containers)}
+
+	reason := string(pod.Status.Phase)
+	if pod.Status.Reason != "" {
+		reason = pod.Status.Reason
+	}
+
+	// Pods held back by scheduling gates are not pending for the scheduler yet
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Reason == corev1.PodReasonSchedulingGated {
+			reason = corev1.PodReasonSchedulingGated
+		}
+	}
+
+	initContainers := make(map[string]*corev1.Container)
+	for i := range pod.Spec.InitContainers {
+		initContainers[pod.Spec.InitContainers[i].Name] = &pod.Spec.InitContainers[i]
+		if isRestartableInitContainer(&pod.Spec.InitContainers[i]) {
+			summary.Total++
+		}
+	}
+
+	var restarts, sidecarRestarts int32
+	initializing := false
+	for i, container := range pod.Status.InitContainerStatuses {
+		restarts += container.RestartCount
+		sidecar := isRestartableInitContainer(initContainers[container.Name])
+		if sidecar {
+			sidecarRestarts += container.RestartCount
+		}
+
+		switch {
+		case container.State.Terminated != nil && container.State.Terminated.ExitCode == 0:
+			continue
+		case sidecar && container.Started != nil && *container.Started:
+			if container.Ready {
+				summary.Ready++
+			}
+			continue
+		case container.State.Terminated != nil:
+			// Initialization failed
+			switch terminated := container.State.Terminated; {
+			case terminated.Reason != "":
+				reason = "Init:" + terminated.Reason
+			case terminated.Signal != 0:
+				reason = fmt.Sprintf("Init:Signal:%d", terminated.Signal)
+			default:
+				reason = fmt.Sprintf("Init:ExitCode:%d", terminated.ExitCode)
+			}
+		case container.State.Waiting != nil && container.State.Waiting.Reason != "" &&
+			container.State.Waiting.Reason != "PodInitializing":
+			reason = "Init:" + container.State.Waiting.Reason
+		default:
+			reason = fmt.Sprintf("Init:%d/%d", i, len(pod.Spec.InitContainers))
+		}
+		initializing = true
+		break
+	}
+	summary.Restarts = restarts
+
+	if !initializing || isPodConditionTrue(pod, corev1.PodInitialized) {
+		summary.Restarts = sidecarRestarts
+		hasRunning := false
+		for i := len(pod.Status.ContainerStatuses) - 1; i >= 0; i-- {
+			container := pod.Status.ContainerStatuses[i]
+			summary.Restarts += container.RestartCount
+
+			switch {
+			case container.State.Waiting != nil && container.State.Waiting.Reason != "":
+				reason = container.State.Waiting.Reason
+			case container.State.Terminated != nil && container.State.Terminated.Reason != "":
+				reason = container.State.Terminated.Reason
+			case container.State.Terminated != nil && container.State.Terminated.Signal != 0:
+				reason = fmt.Sprintf("Signal:%d", container.State.Terminated.Signal)
+			case container.State.Terminated != nil:
+				reason = fmt.Sprintf("ExitCode:%d", container.State.Terminated.ExitCode)
+			case container.Ready && container.State.Running != nil:
+				hasRunning = true
+				summary.Ready++
+			}
+		}
+
+		// A completed container does not make the pod completed while others still run
+		if reason == "Completed" && hasRunning {
+			if isPodConditionTrue(pod, corev1.PodReady) {
+				reason = "Running"
+			} else {
+				reason = "NotReady"
+			}
+		}
+	}
+
+	if pod.DeletionTimestamp != nil && pod.Status.Reason == nodeUnreachablePodReason {
+		reason = "Unknown"
+	} else if pod.DeletionTimestamp != nil && !isTerminalPod(pod) {
+		reason = "Terminating"
+	}
+	summary.Status = reason
+
+	if len(pod.Spec.ReadinessGates) > 0 {
+		trueConditions := 0
+		for _, gate := range pod.Spec.ReadinessGates {
+			if isPodConditionTrue(pod, gate.ConditionType) {
+				trueConditions++
+			}
+		}
+		summary.ReadinessGates = fmt.Sprintf("%d/%d", trueConditions, len(pod.Spec.ReadinessGates))
+	}
+
+	return summary
+}
+
+// isRestartableInitContainer reports whether an init container is a sidecar that keeps running
+func isRestartableInitContainer(container *corev1.Container) bool {
+	return container != nil && container.RestartPolicy != nil &&
+		*container.RestartPolicy == corev1.ContainerRestartPolicyAlways
+}
+
+// isPodConditionTrue reports whether the first condition of a type is true
+func isPodConditionTrue(pod *corev1.Pod, conditionType corev1.PodConditionType) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
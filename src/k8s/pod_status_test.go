@@ -0,0 +1,85 @@
+package k8s
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// loadPodFixture reads a pod manifest from testdata/pods
+func loadPodFixture(t *testing.T, name string) *corev1.Pod {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "pods", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	var pod corev1.Pod
+	if err := yaml.UnmarshalStrict(data, &pod); err != nil {
+		t.Fatalf("failed to parse fixture %s: %v", name, err)
+	}
+	return &pod
+}
+
+func TestSummarizePod(t *testing.T) {
+	tests := []struct {
+		fixture        string
+		status         string
+		ready          int
+		total          int
+		restarts       int32
+		readinessGates string
+	}{
+		{fixture: "running.yaml", status: "Running", ready: 1, total: 1, restarts: 2},
+		{fixture: "completed.yaml", status: "Completed", total: 1},
+		{fixture: "error.yaml", status: "Error", total: 1},
+		{fixture: "exit-code-without-reason.yaml", status: "ExitCode:3", total: 1},
+		{fixture: "signal-without-reason.yaml", status: "Signal:9", total: 1},
+		{fixture: "crashloop.yaml", status: "CrashLoopBackOff", ready: 1, total: 2, restarts: 14},
+		{fixture: "image-pull-backoff.yaml", status: "ImagePullBackOff", total: 1},
+		{fixture: "container-creating.yaml", status: "ContainerCreating", total: 1},
+		{fixture: "init-running.yaml", status: "Init:1/3", total: 1},
+		{fixture: "init-waiting-pod-initializing.yaml", status: "Init:0/1", total: 1},
+		{fixture: "init-crashloop.yaml", status: "Init:CrashLoopBackOff", total: 1, restarts: 6},
+		{fixture: "init-error.yaml", status: "Init:Error", total: 1},
+		{fixture: "init-signal-without-reason.yaml", status: "Init:Signal:15", total: 1},
+		{fixture: "sidecar-running.yaml", status: "Running", ready: 2, total: 2, restarts: 3},
+		{fixture: "sidecar-starting.yaml", status: "Init:0/1", total: 2},
+		{fixture: "completed-container-still-running.yaml", status: "Running", ready: 1, total: 2},
+		{fixture: "completed-container-not-ready.yaml", status: "NotReady", ready: 1, total: 2},
+		{fixture: "scheduling-gated.yaml", status: "SchedulingGated", total: 1},
+		{fixture: "unschedulable.yaml", status: "Pending", total: 1},
+		{fixture: "evicted.yaml", status: "Evicted", total: 1},
+		{fixture: "evicted-container-status-unknown.yaml", status: "ContainerStatusUnknown", total: 1},
+		{fixture: "node-lost.yaml", status: "NodeLost", total: 1},
+		{fixture: "unreachable-node-deleted.yaml", status: "Unknown", ready: 1, total: 1},
+		{fixture: "terminating.yaml", status: "Terminating", ready: 1, total: 1},
+		{fixture: "deleted-after-completion.yaml", status: "Completed", total: 1},
+		{fixture: "readiness-gates.yaml", status: "Running", ready: 1, total: 1, readinessGates: "1/2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			pod := loadPodFixture(t, tt.fixture)
+			got := summarizePod(pod)
+
+			if got.Status != tt.status {
+				t.Errorf("status = %q, want %q", got.Status, tt.status)
+			}
+			if got.Ready != tt.ready || got.Total != tt.total {
+				t.Errorf("ready = %d/%d, want %d/%d", got.Ready, got.Total, tt.ready, tt.total)
+			}
+			if got.Restarts != tt.restarts {
+				t.Errorf("restarts = %d, want %d", got.Restarts, tt.restarts)
+			}
+			if got.ReadinessGates != tt.readinessGates {
+				t.Errorf("readiness gates = %q, want %q", got.ReadinessGates, tt.readinessGates)
+			}
+			if status := getPodStatus(pod); status != tt.status {
+				t.Errorf("getPodStatus = %q, want %q", status, tt.status)
+			}
+		})
+	}
+}
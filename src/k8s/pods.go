@@ -13,8 +13,9 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 )
 
-// GetPods retrieves pods from the specified Kubernetes context and namespace
-func (k *KubeConfig) GetPods(contextName, namespace string) ([]PodInfo, error) {
+// GetPods retrieves pods from the specified Kubernetes context and namespace, or all namespaces
+// when it is empty, that match the selector; a nil selector matches every pod
+func (k *KubeConfig) GetPods(contextName, namespace string, selector *Selector) ([]PodInfo, error) {
 	// Create a temporary client config for the specified context
 	tempConfig := clientcmd.NewNonInteractiveClientConfig(
 		*k.config,
@@ -41,14 +42,27 @@ func (k *KubeConfig) GetPods(contextName, namespace string) ([]PodInfo, error) {
 	defer cancel()
 
 	// Get pods
-	podList, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	podList, err := clientset.CoreV1().Pods(namespace).List(ctx, selector.ListOptions())
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pods: %w", err)
 	}
 
+	// Get live usage; metrics-server is optional so failures just leave Usage empty
+	usage, usageErr := listPodUsage(ctx, restConfig, namespace)
+
 	var pods []PodInfo
 	for _, pod := range podList.Items {
+		// Field selectors the API server does not support are applied here
+		if !selector.Matches(&pod) {
+			continue
+		}
+
 		podInfo := convertPodToPodInfo(&pod)
+		if usageErr == nil {
+			if podUsage, ok := usage[pod.Namespace+"/"+pod.Name]; ok {
+				podInfo.Usage = &podUsage
+			}
+		}
 		pods = append(pods, podInfo)
 	}
 
@@ -56,7 +70,7 @@ func (k *KubeConfig) GetPods(contextName, namespace string) ([]PodInfo, error) {
 }
 
 // GetPodLogs retrieves logs from a specific pod
-func (k *KubeConfig) GetPodLogs(contextName, namespace, podName, containerName string, lines int64, follow bool) (io.ReadCloser, error) {
+func (k *KubeConfig) GetPodLogs(contextName, namespace, podName string, opts LogOptions) (io.ReadCloser, error) {
 	// Create a temporary client config for the specified context
 	tempConfig := clientcmd.NewNonInteractiveClientConfig(
 		*k.config,
@@ -75,18 +89,8 @@ func (k *KubeConfig) GetPodLogs(contextName, namespace, podName, containerName s
 		return nil, fmt.Errorf("failed to create client: %w", err)
 	}
 
-	// Prepare log options
-	podLogOpts := corev1.PodLogOptions{
-		Container:  containerName,
-		Follow:     follow,
-		Timestamps: true,
-	}
-	
-	if lines > 0 {
-		podLogOpts.TailLines = &lines
-	}
-
 	// Get logs
+	podLogOpts := podLogOptions(opts)
 	req := clientset.CoreV1().Pods(namespace).GetLogs(podName, &podLogOpts)
 	logs, err := req.Stream(context.Background())
 	if err != nil {
@@ -96,6 +100,31 @@ func (k *KubeConfig) GetPodLogs(contextName, namespace, podName, containerName s
 	return logs, nil
 }
 
+// podLogOptions converts LogOptions to the options of the log subresource
+func podLogOptions(opts LogOptions) corev1.PodLogOptions {
+	podLogOpts := corev1.PodLogOptions{
+		Container:  opts.Container,
+		Follow:     opts.Follow,
+		Previous:   opts.Previous,
+		Timestamps: true,
+	}
+
+	if opts.TailLines > 0 {
+		podLogOpts.TailLines = &opts.TailLines
+	}
+	if !opts.SinceTime.IsZero() {
+		sinceTime := metav1.NewTime(opts.SinceTime)
+		podLogOpts.SinceTime = &sinceTime
+	} else if opts.SinceSeconds > 0 {
+		podLogOpts.SinceSeconds = &opts.SinceSeconds
+	}
+	if opts.LimitBytes > 0 {
+		podLogOpts.LimitBytes = &opts.LimitBytes
+	}
+
+	return podLogOpts
+}
+
 // DeletePod deletes a pod
 func (k *KubeConfig) DeletePod(contextName, namespace, podName string) error {
 	// Create a temporary client config for the specified context
@@ -199,57 +228,11 @@ status:
 
 // Helper functions
 func convertPodToPodInfo(pod *corev1.Pod) PodInfo {
-	// Calculate ready containers
-	readyCount := 0
-	totalCount := len(pod.Status.ContainerStatuses)
-	
-	for _, status := range pod.Status.ContainerStatuses {
-		if status.Ready {
-			readyCount++
-		}
-	}
-	
-	// Calculate total restarts
-	var totalRestarts int32
-	for _, status := range pod.Status.ContainerStatuses {
-		totalRestarts += status.RestartCount
-	}
+	// Status, readiness and restarts as kubectl get pods prints them
+	summary := summarizePod(pod)
 
 	// Convert containers
-	var containers []ContainerInfo
-	for _, containerStatus := range pod.Status.ContainerStatuses {
-		state := "Unknown"
-		reason := ""
-		
-		if containerStatus.State.Running != nil {
-			state = "Running"
-		} else if containerStatus.State.Waiting != nil {
-			state = "Waiting"
-			reason = containerStatus.State.Waiting.Reason
-		} else if containerStatus.State.Terminated != nil {
-			state = "Terminated"
-			reason = containerStatus.State.Terminated.Reason
-		}
-		
-		// Find the corresponding container spec
-		containerName := containerStatus.Name
-		image := ""
-		for _, container := range pod.Spec.Containers {
-			if container.Name == containerName {
-				image = container.Image
-				break
-			}
-		}
-		
-		containers = append(containers, ContainerInfo{
-			Name:         containerName,
-			Image:        image,
-			Ready:        containerStatus.Ready,
-			RestartCount: containerStatus.RestartCount,
-			State:        state,
-			Reason:       reason,
-		})
-	}
+	containers := convertContainers(pod)
 
 	// Get owner references
 	var owners []string
@@ -262,10 +245,10 @@ func convertPodToPodInfo(pod *corev1.Pod) PodInfo {
 	return PodInfo{
 		Name:            pod.Name,
 		Namespace:       pod.Namespace,
-		Status:          getPodStatus(pod),
+		Status:          summary.Status,
 		Phase:           string(pod.Status.Phase),
-		Ready:           fmt.Sprintf("%d/%d", readyCount, totalCount),
-		Restarts:        totalRestarts,
+		Ready:           fmt.Sprintf("%d/%d", summary.Ready, summary.Total),
+		Restarts:        summary.Restarts,
 		Age:             age,
 		CreationTime:    pod.CreationTimestamp.Time,
 		Node:            pod.Spec.NodeName,
@@ -276,28 +259,55 @@ func convertPodToPodInfo(pod *corev1.Pod) PodInfo {
 	}
 }
 
-func getPodStatus(pod *corev1.Pod) string {
-	if pod.DeletionTimestamp != nil {
-		return "Terminating"
+// convertContainers lists the init, app and ephemeral containers of a pod with their current state
+func convertContainers(pod *corev1.Pod) []ContainerInfo {
+	statuses := make(map[string]corev1.ContainerStatus)
+	for _, list := range [][]corev1.ContainerStatus{
+		pod.Status.InitContainerStatuses,
+		pod.Status.ContainerStatuses,
+		pod.Status.EphemeralContainerStatuses,
+	} {
+		for _, status := range list {
+			statuses[status.Name] = status
+		}
 	}
 
-	// Check container states
-	for _, status := range pod.Status.ContainerStatuses {
-		if status.State.Waiting != nil && status.State.Waiting.Reason == "CrashLoopBackOff" {
-			return "CrashLoopBackOff"
-		}
-		if status.State.Waiting != nil && status.State.Waiting.Reason == "ImagePullBackOff" {
-			return "ImagePullBackOff"
-		}
-		if status.State.Waiting != nil {
-			return status.State.Waiting.Reason
+	var containers []ContainerInfo
+	add := func(name, image, containerType string) {
+		info := ContainerInfo{
+			Name:  name,
+			Image: image,
+			Type:  containerType,
+			State: "Waiting",
 		}
-		if status.State.Terminated != nil {
-			return status.State.Terminated.Reason
+		if status, ok := statuses[name]; ok {
+			info.Ready = status.Ready
+			info.RestartCount = status.RestartCount
+			info.State = "Unknown"
+			if status.State.Running != nil {
+				info.State = "Running"
+			} else if status.State.Waiting != nil {
+				info.State = "Waiting"
+				info.Reason = status.State.Waiting.Reason
+			} else if status.State.Terminated != nil {
+				info.State = "Terminated"
+				info.Reason = status.State.Terminated.Reason
+			}
 		}
+		containers = append(containers, info)
+	}
+
+	for _, container := range pod.Spec.InitContainers {
+		add(container.Name, container.Image, ContainerTypeInit)
+	}
+	for _, container := range pod.Spec.Containers {
+		add(container.Name, container.Image, ContainerTypeApp)
+	}
+	for _, container := range pod.Spec.EphemeralContainers {
+		add(container.Name, container.Image, ContainerTypeEphemeral)
 	}
 
-	return string(pod.Status.Phase)
+	return containers
 }
 
 func formatLabelsYAML(labels map[string]string) string {
@@ -0,0 +1,182 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/muesli/cancelreader"
+	"golang.org/x/term"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// newStreamExecutor connects to an exec or attach URL over websockets, falling back to SPDY for
+// API servers that do not upgrade to websockets yet, the way kubectl does
+func newStreamExecutor(restConfig *rest.Config, method string, streamURL *url.URL) (remotecommand.Executor, error) {
+	spdyExecutor, err := remotecommand.NewSPDYExecutor(restConfig, method, streamURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stream executor: %w", err)
+	}
+	websocketExecutor, err := remotecommand.NewWebSocketExecutor(restConfig, "GET", streamURL.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stream executor: %w", err)
+	}
+	return remotecommand.NewFallbackExecutor(websocketExecutor, spdyExecutor, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+}
+
+// execInContainer runs a command in a container without a terminal, streaming stdin to it and
+// its output to stdout. What the command writes to stderr is added to the error.
+func execInContainer(ctx context.Context, restConfig *rest.Config, clientset kubernetes.Interface, namespace, podName, containerName string, command []string, stdin io.Reader, stdout io.Writer) error {
+	request := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podName).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: containerName,
+			Command:   command,
+			Stdin:     stdin != nil,
+			Stdout:    stdout != nil,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := newStreamExecutor(restConfig, "POST", request.URL())
+	if err != nil {
+		return err
+	}
+
+	var stderr bytes.Buffer
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: &stderr,
+	})
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("%w: %s", err, message)
+		}
+		return err
+	}
+	return nil
+}
+
+// TerminalSession hands the terminal to a process in a container until the process exits. It
+// has the methods of bubbletea's ExecCommand, so the UI can suspend itself while it runs.
+type TerminalSession struct {
+	restConfig *rest.Config
+	url        *url.URL
+	banner     string
+
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (s *TerminalSession) SetStdin(r io.Reader) {
+	s.stdin = r
+}
+
+func (s *TerminalSession) SetStdout(w io.Writer) {
+	s.stdout = w
+}
+
+func (s *TerminalSession) SetStderr(w io.Writer) {
+	s.stderr = w
+}
+
+// Run streams the terminal until the container's process exits or the connection drops
+func (s *TerminalSession) Run() error {
+	executor, err := newStreamExecutor(s.restConfig, "POST", s.url)
+	if err != nil {
+		return err
+	}
+
+	if s.banner != "" {
+		fmt.Fprintln(s.stdout, s.banner)
+	}
+
+	stdin := s.stdin
+	var sizes *terminalSizeQueue
+	if file, ok := s.stdin.(*os.File); ok && term.IsTerminal(int(file.Fd())) {
+		// Raw mode sends every key, ctrl+c included, to the container instead of acting on it here
+		state, err := term.MakeRaw(int(file.Fd()))
+		if err != nil {
+			return fmt.Errorf("failed to set up terminal: %w", err)
+		}
+		defer term.Restore(int(file.Fd()), state)
+
+		// The stream keeps reading stdin after the process exits; a cancelable reader stops it
+		// from swallowing the first key meant for peek
+		reader, err := cancelreader.NewReader(file)
+		if err == nil {
+			defer reader.Cancel()
+			stdin = reader
+		}
+
+		if out, ok := s.stdout.(*os.File); ok && term.IsTerminal(int(out.Fd())) {
+			sizes = newTerminalSizeQueue(int(out.Fd()))
+			defer sizes.stop()
+		}
+	}
+
+	options := remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: s.stdout,
+		Tty:    true,
+	}
+	if sizes != nil {
+		options.TerminalSizeQueue = sizes
+	}
+	if err := executor.StreamWithContext(context.Background(), options); err != nil {
+		return fmt.Errorf("stream to container ended: %w", err)
+	}
+	return nil
+}
+
+// terminalSizeQueue reports the size of the local terminal to the container whenever it changes
+type terminalSizeQueue struct {
+	fd   int
+	last remotecommand.TerminalSize
+	done chan struct{}
+}
+
+func newTerminalSizeQueue(fd int) *terminalSizeQueue {
+	return &terminalSizeQueue{fd: fd, done: make(chan struct{})}
+}
+
+// Next blocks until the terminal has a new size; nil ends the queue
+func (q *terminalSizeQueue) Next() *remotecommand.TerminalSize {
+	// Polling avoids SIGWINCH, which does not exist on every platform
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		if width, height, err := term.GetSize(q.fd); err == nil {
+			size := remotecommand.TerminalSize{Width: uint16(width), Height: uint16(height)}
+			if size != q.last {
+				q.last = size
+				return &size
+			}
+		}
+		select {
+		case <-q.done:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (q *terminalSizeQueue) stop() {
+	close(q.done)
+}
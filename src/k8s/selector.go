@@ -0,0 +1,229 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// serverPodFields are the pod field selectors the API server supports
+var serverPodFields = map[string]bool{
+	"metadata.name":            true,
+	"metadata.namespace":       true,
+	"spec.nodeName":            true,
+	"spec.restartPolicy":       true,
+	"spec.schedulerName":       true,
+	"spec.serviceAccountName":  true,
+	"spec.hostNetwork":         true,
+	"status.phase":             true,
+	"status.podIP":             true,
+	"status.nominatedNodeName": true,
+}
+
+// serverWorkloadFields are the field selectors the API server supports for every workload kind
+var serverWorkloadFields = map[string]bool{
+	"metadata.name":      true,
+	"metadata.namespace": true,
+}
+
+// Selector selects objects by label and field selectors in Kubernetes syntax, e.g.
+// `app=web,tier!=cache,env in (prod),status.phase=Running`. Terms whose key starts with
+// metadata., spec. or status. are field selectors, all others are label selectors. Label
+// selectors and the field selectors the API server supports for the kind are sent with the list
+// request; other field selectors, like status.reason or spec.priorityClassName for pods, are
+// applied to the listed objects.
+type Selector struct {
+	query        string
+	labels       labels.Selector
+	serverFields fields.Selector
+	clientFields []fieldRequirement
+}
+
+type fieldRequirement struct {
+	path   string
+	value  string
+	negate bool
+}
+
+// ParsePodSelector parses a selector for pods; an empty selector selects every pod
+func ParsePodSelector(query string) (*Selector, error) {
+	return parseSelector(query, serverPodFields)
+}
+
+// ParseWorkloadSelector parses a selector for workloads such as Deployments and Jobs; an empty
+// selector selects every workload
+func ParseWorkloadSelector(query string) (*Selector, error) {
+	return parseSelector(query, serverWorkloadFields)
+}
+
+// parseSelector parses a selector, sending the fields in serverFields to the API server
+func parseSelector(query string, serverFields map[string]bool) (*Selector, error) {
+	selector := &Selector{
+		query:        strings.TrimSpace(query),
+		labels:       labels.Everything(),
+		serverFields: fields.Everything(),
+	}
+
+	var labelTerms, serverTerms []string
+	for _, term := range splitSelectorTerms(selector.query) {
+		if !isFieldPath(term) {
+			labelTerms = append(labelTerms, term)
+			continue
+		}
+
+		requirement, err := parseFieldRequirement(term)
+		if err != nil {
+			return nil, err
+		}
+		if serverFields[requirement.path] {
+			serverTerms = append(serverTerms, term)
+		} else {
+			selector.clientFields = append(selector.clientFields, requirement)
+		}
+	}
+
+	if len(labelTerms) > 0 {
+		parsed, err := labels.Parse(strings.Join(labelTerms, ","))
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector: %w", err)
+		}
+		selector.labels = parsed
+	}
+	if len(serverTerms) > 0 {
+		parsed, err := fields.ParseSelector(strings.Join(serverTerms, ","))
+		if err != nil {
+			return nil, fmt.Errorf("invalid field selector: %w", err)
+		}
+		selector.serverFields = parsed
+	}
+
+	return selector, nil
+}
+
+// String returns the query the selector was parsed from
+func (s *Selector) String() string {
+	if s == nil {
+		return ""
+	}
+	return s.query
+}
+
+// IsEmpty reports whether the selector selects every object
+func (s *Selector) IsEmpty() bool {
+	return s == nil || s.query == ""
+}
+
+// ListOptions returns the list options carrying the selectors the API server applies
+func (s *Selector) ListOptions() metav1.ListOptions {
+	if s.IsEmpty() {
+		return metav1.ListOptions{}
+	}
+	return metav1.ListOptions{
+		LabelSelector: s.labels.String(),
+		FieldSelector: s.serverFields.String(),
+	}
+}
+
+// ClientFields returns the field selectors applied to the listed objects rather than by the API server
+func (s *Selector) ClientFields() []string {
+	if s == nil {
+		return nil
+	}
+	var terms []string
+	for _, requirement := range s.clientFields {
+		op := "="
+		if requirement.negate {
+			op = "!="
+		}
+		terms = append(terms, requirement.path+op+requirement.value)
+	}
+	return terms
+}
+
+// Matches reports whether a listed object, such as a *corev1.Pod, satisfies the field selectors
+// the API server did not apply. An object whose fields cannot be read does not match.
+func (s *Selector) Matches(object runtime.Object) bool {
+	if s == nil || len(s.clientFields) == 0 {
+		return true
+	}
+
+	values, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return false
+	}
+	for _, requirement := range s.clientFields {
+		value, found, err := unstructured.NestedFieldNoCopy(values, strings.Split(requirement.path, ".")...)
+		actual := ""
+		if err == nil && found && value != nil {
+			actual = fmt.Sprint(value)
+		}
+		if (actual == requirement.value) == requirement.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// splitSelectorTerms splits a selector at the commas that are not inside a set like `env in (a,b)`
+func splitSelectorTerms(query string) []string {
+	var terms []string
+	depth, start := 0, 0
+	for i, r := range query {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				terms = appendTerm(terms, query[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return appendTerm(terms, query[start:])
+}
+
+func appendTerm(terms []string, term string) []string {
+	if term = strings.TrimSpace(term); term != "" {
+		terms = append(terms, term)
+	}
+	return terms
+}
+
+// isFieldPath reports whether a selector term is about a field rather than a label
+func isFieldPath(term string) bool {
+	for _, prefix := range []string{"metadata.", "spec.", "status."} {
+		if strings.HasPrefix(term, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseFieldRequirement parses path=value, path==value or path!=value
+func parseFieldRequirement(term string) (fieldRequirement, error) {
+	if path, value, ok := strings.Cut(term, "!="); ok {
+		return newFieldRequirement(path, value, true)
+	}
+	if path, value, ok := strings.Cut(term, "=="); ok {
+		return newFieldRequirement(path, value, false)
+	}
+	if path, value, ok := strings.Cut(term, "="); ok {
+		return newFieldRequirement(path, value, false)
+	}
+	return fieldRequirement{}, fmt.Errorf("invalid field selector %q: use field=value or field!=value", term)
+}
+
+func newFieldRequirement(path, value string, negate bool) (fieldRequirement, error) {
+	path, value = strings.TrimSpace(path), strings.TrimSpace(value)
+	if path == "" || strings.ContainsAny(path, " ()<>") {
+		return fieldRequirement{}, fmt.Errorf("invalid field %q", path)
+	}
+	return fieldRequirement{path: path, value: value, negate: negate}, nil
+}
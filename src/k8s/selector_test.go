@@ -0,0 +1,167 @@
+package k8s
+
+import (
+	"slices"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestSelectorListOptions(t *testing.T) {
+	tests := []struct {
+		query        string
+		workload     bool
+		labels       string
+		fields       string
+		clientFields []string
+	}{
+		{query: "", labels: "", fields: ""},
+		{query: "app=web,tier!=cache", labels: "app=web,tier!=cache", fields: ""},
+		{query: "env in (prod,staging),app=web", labels: "app=web,env in (prod,staging)", fields: ""},
+		{query: "app=web,status.phase=Running", labels: "app=web", fields: "status.phase=Running"},
+		{query: "spec.nodeName==node-1,status.reason!=Evicted", labels: "", fields: "spec.nodeName=node-1",
+			clientFields: []string{"status.reason!=Evicted"}},
+		// Workloads only have their name and namespace selected by the API server
+		{query: "metadata.name=api,spec.paused=true", workload: true, labels: "", fields: "metadata.name=api",
+			clientFields: []string{"spec.paused=true"}},
+		{query: "status.phase=Running", workload: true, labels: "", fields: "",
+			clientFields: []string{"status.phase=Running"}},
+	}
+
+	for _, tt := range tests {
+		parse := ParsePodSelector
+		if tt.workload {
+			parse = ParseWorkloadSelector
+		}
+		selector, err := parse(tt.query)
+		if err != nil {
+			t.Errorf("parse(%q) failed: %v", tt.query, err)
+			continue
+		}
+		opts := selector.ListOptions()
+		if opts.LabelSelector != tt.labels || opts.FieldSelector != tt.fields {
+			t.Errorf("parse(%q) lists with labels %q and fields %q, want %q and %q",
+				tt.query, opts.LabelSelector, opts.FieldSelector, tt.labels, tt.fields)
+		}
+		if clientFields := selector.ClientFields(); !slices.Equal(clientFields, tt.clientFields) {
+			t.Errorf("parse(%q) matches %v locally, want %v", tt.query, clientFields, tt.clientFields)
+		}
+	}
+}
+
+func TestParseSelectorErrors(t *testing.T) {
+	for _, query := range []string{
+		"status.phase",      // no operator
+		"spec.node name=x",  // space in the field
+		"app in (web",       // unclosed set
+		"=web",              // no label key
+		"status.phase>=Run", // unsupported field operator
+	} {
+		if _, err := ParsePodSelector(query); err == nil {
+			t.Errorf("ParsePodSelector(%q) succeeded, want an error", query)
+		}
+	}
+}
+
+func TestSplitSelectorTerms(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", nil},
+		{"app=web", []string{"app=web"}},
+		{"env in (a,b),tier!=cache", []string{"env in (a,b)", "tier!=cache"}},
+		{" app=web , , status.phase=Running ", []string{"app=web", "status.phase=Running"}},
+		{"env notin (a, b, c)", []string{"env notin (a, b, c)"}},
+	}
+
+	for _, tt := range tests {
+		if got := splitSelectorTerms(tt.query); !slices.Equal(got, tt.want) {
+			t.Errorf("splitSelectorTerms(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestParseFieldRequirement(t *testing.T) {
+	tests := []struct {
+		term string
+		want fieldRequirement
+	}{
+		{"status.reason=Evicted", fieldRequirement{path: "status.reason", value: "Evicted"}},
+		{"status.reason==Evicted", fieldRequirement{path: "status.reason", value: "Evicted"}},
+		{"status.reason!=Evicted", fieldRequirement{path: "status.reason", value: "Evicted", negate: true}},
+		{" spec.paused = true ", fieldRequirement{path: "spec.paused", value: "true"}},
+		{"status.message=", fieldRequirement{path: "status.message", value: ""}},
+	}
+
+	for _, tt := range tests {
+		got, err := parseFieldRequirement(tt.term)
+		if err != nil {
+			t.Errorf("parseFieldRequirement(%q) failed: %v", tt.term, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseFieldRequirement(%q) = %+v, want %+v", tt.term, got, tt.want)
+		}
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	replicas := int32(3)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Paused:   true,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{ServiceAccountName: "api-runner"},
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "default"},
+		Spec:       corev1.PodSpec{PriorityClassName: "critical"},
+		Status:     corev1.PodStatus{Phase: corev1.PodFailed, Reason: "Evicted"},
+	}
+
+	tests := []struct {
+		query   string
+		object  runtime.Object
+		matches bool
+	}{
+		{"", deployment, true},
+		// Booleans and numbers compare by their text
+		{"spec.paused=true", deployment, true},
+		{"spec.paused=false", deployment, false},
+		{"spec.paused!=true", deployment, false},
+		{"spec.replicas=3", deployment, true},
+		// Nested fields
+		{"spec.template.spec.serviceAccountName=api-runner", deployment, true},
+		{"spec.template.spec.serviceAccountName!=api-runner", deployment, false},
+		// Missing fields are empty
+		{"spec.template.spec.nodeName=", deployment, true},
+		{"spec.minReadySeconds!=", deployment, false},
+		// Every term must match
+		{"spec.paused=true,spec.template.spec.serviceAccountName=other", deployment, false},
+		{"status.reason=Evicted,spec.priorityClassName=critical", pod, true},
+		{"status.reason!=Evicted", pod, false},
+	}
+
+	for _, tt := range tests {
+		parse := ParsePodSelector
+		if _, ok := tt.object.(*appsv1.Deployment); ok {
+			parse = ParseWorkloadSelector
+		}
+		selector, err := parse(tt.query)
+		if err != nil {
+			t.Errorf("parse(%q) failed: %v", tt.query, err)
+			continue
+		}
+		if matches := selector.Matches(tt.object); matches != tt.matches {
+			t.Errorf("%q matches = %v, want %v", tt.query, matches, tt.matches)
+		}
+	}
+}
@@ -21,6 +21,7 @@ type NodeInfo struct {
 	Status       string
 	Roles        []string
 	Age          string
+	CreationTime time.Time
 	Version      string
 	OS           string
 	Architecture string
@@ -28,19 +29,64 @@ type NodeInfo struct {
 	MemCapacity  string
 	Ready        bool
 	LastUpdated  time.Time
+
+	// Allocatable resources in millicores and bytes
+	CPUAllocatable int64
+	MemAllocatable int64
+
+	// MemCapacity in bytes, for sorting
+	MemCapacityBytes int64
+
+	// Summed requests and limits of the non-terminal pods scheduled on the node
+	Allocation ResourceAllocation
+	// AllocationError is why Allocation is empty when the pods could not be listed
+	AllocationError error
+
+	// Live usage from metrics.k8s.io, nil when the metrics API is unavailable
+	Usage *ResourceUsage
 }
 
 // ApplicationInfo represents information about Kubernetes application workloads
 type ApplicationInfo struct {
-	Name           string
-	Type           string // Deployment, DaemonSet, StatefulSet, ReplicaSet, Job, CronJob
-	Namespace      string
-	Status         string
-	Replicas       int32
-	ReadyReplicas  int32
-	CreationTime   time.Time
-	Labels         map[string]string
-	Conditions     []string
+	Name          string
+	Type          string // Deployment, DaemonSet, StatefulSet, ReplicaSet, Job, CronJob
+	Namespace     string
+	Status        string
+	Replicas      int32
+	ReadyReplicas int32
+	CreationTime  time.Time
+	Labels        map[string]string
+	Conditions    []string
+	Health        string // one of the Health constants, derived from the status and replicas
+}
+
+// Health of an application, summarising its status and how many of its replicas are ready
+const (
+	HealthHealthy     = "Healthy"
+	HealthProgressing = "Progressing" // a job waiting for its pods to start or finish
+	HealthDegraded    = "Degraded"    // some replicas are ready, not all
+	HealthUnavailable = "Unavailable" // replicas are wanted but none is ready
+	HealthFailed      = "Failed"
+	HealthSuspended   = "Suspended"
+)
+
+// ApplicationDescription is the detail of a workload: its spec and status, the pods its selector
+// matches, its conditions and its events
+type ApplicationDescription struct {
+	Application       ApplicationInfo
+	UID               string
+	Selector          string // the label selector of its pods, empty for CronJobs which select through their jobs
+	Images            []string
+	Strategy          string // how it rolls out or runs, e.g. "RollingUpdate (max surge 25%, max unavailable 25%)"
+	UpdatedReplicas   int32
+	AvailableReplicas int32
+	Schedule          string    // CronJobs only
+	LastSchedule      time.Time // CronJobs only
+	Conditions        []PodConditionInfo
+	Pods              []PodInfo   // the pods its selector matches, by name
+	PodsError         error       // pods could not be listed; the rest of the description is valid
+	Events            []EventInfo // the workload's own events, oldest first
+	EventsError       error       // events could not be listed; the rest of the description is valid
 }
 
 // PodInfo represents information about a Kubernetes pod
@@ -58,18 +104,101 @@ type PodInfo struct {
 	Labels          map[string]string
 	Containers      []ContainerInfo
 	OwnerReferences []string
+	Usage           *ResourceUsage // nil when the metrics API is unavailable
+}
+
+// PodDescription is the describe-style detail of a pod: its status, spec and events
+type PodDescription struct {
+	Name           string
+	Namespace      string
+	Status         string
+	QoSClass       string
+	ReadinessGates string // readiness gate conditions that are true, e.g. "1/2"; empty without gates
+	Node           string
+	NodeIP         string
+	PodIP          string
+	ServiceAccount string
+	Priority       string // priority class name, empty when not set
+	StartTime      time.Time
+	CreationTime   time.Time
+	Labels         map[string]string
+	Owners         []string // "Kind/name"
+	Conditions     []PodConditionInfo
+	Containers     []ContainerDescription
+	Tolerations    []string    // e.g. "node.kubernetes.io/not-ready:NoExecute op=Exists for 300s"
+	Events         []EventInfo // the pod's own events, oldest first
+	EventsError    error       // events could not be listed; the rest of the description is valid
+}
+
+// PodConditionInfo is one of a pod's status conditions
+type PodConditionInfo struct {
+	Type               string
+	Status             string
+	Reason             string
+	Message            string
+	LastTransitionTime time.Time
+}
+
+// ContainerDescription is the spec and state of one container of a pod
+type ContainerDescription struct {
+	Name         string
+	Type         string // ContainerTypeInit, ContainerTypeApp or ContainerTypeEphemeral
+	Image        string
+	Ready        bool
+	RestartCount int32
+	State        string // "Running", "Waiting", "Terminated" or "Unknown"
+	Reason       string
+	Message      string
+	StartedAt    time.Time
+	// Termination is set while the container is terminated, LastTermination when an earlier
+	// instance terminated
+	Termination     *ContainerTermination
+	LastTermination *ContainerTermination
+	Probes          []string // e.g. "Liveness: http-get :8080/healthz delay=0s timeout=1s period=10s #success=1 #failure=3"
+	Mounts          []string // e.g. "/data from data (rw)"
+	TargetContainer string   // the container an ephemeral container shares the process namespace of
+}
+
+// ContainerTermination describes how a container instance ended
+type ContainerTermination struct {
+	ExitCode   int32
+	Signal     int32
+	Reason     string
+	Message    string
+	StartedAt  time.Time
+	FinishedAt time.Time
 }
 
+// Container types
+const (
+	ContainerTypeInit      = "init"
+	ContainerTypeApp       = "app"
+	ContainerTypeEphemeral = "ephemeral"
+)
+
 // ContainerInfo represents information about a container in a pod
 type ContainerInfo struct {
 	Name         string
 	Image        string
+	Type         string // ContainerTypeInit, ContainerTypeApp or ContainerTypeEphemeral
 	Ready        bool
 	RestartCount int32
 	State        string
 	Reason       string
 }
 
+// LogOptions selects which logs GetPodLogs streams
+type LogOptions struct {
+	Container string
+	TailLines int64 // 0 means all lines
+	Follow    bool
+	Previous  bool // logs of the previous, terminated instance of the container
+	// SinceTime and SinceSeconds start the logs at a point in time instead of the tail; set at most one
+	SinceTime    time.Time
+	SinceSeconds int64
+	LimitBytes   int64 // 0 means no limit
+}
+
 // EventInfo represents information about a Kubernetes event
 type EventInfo struct {
 	Type           string
@@ -81,25 +210,84 @@ type EventInfo struct {
 	LastTimestamp  time.Time
 	Namespace      string
 	Source         string
+	InvolvedObject ObjectReference
+}
+
+// ObjectReference identifies the object an event is about
+type ObjectReference struct {
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+	UID        string
+}
+
+// EventGroup aggregates the events reported for one involved object with the same reason
+type EventGroup struct {
+	Key            string
+	Type           string
+	Reason         string
+	Object         string
+	Namespace      string
+	Message        string // latest message
+	Count          int32
+	FirstTimestamp time.Time
+	LastTimestamp  time.Time
+	Events         []EventInfo // individual occurrences, most recent first
 }
 
 // ClusterMetrics holds various cluster-wide metrics
 type ClusterMetrics struct {
 	Nodes      NodeMetrics
 	Pods       PodMetrics
-	Events     []EventInfo
 	LastUpdate time.Time
+
+	// Requests and limits accounting, per node and per namespace
+	NodeAllocations      []ResourceAllocation
+	NamespaceAllocations []ResourceAllocation
 }
 
 // NodeMetrics represents aggregated node statistics
 type NodeMetrics struct {
-	Total        int
-	Ready        int
-	NotReady     int
-	CPUCapacity  int64
-	CPUAllocated int64
-	MemCapacity  int64
-	MemAllocated int64
+	Total          int
+	Ready          int
+	NotReady       int
+	CPUCapacity    int64
+	CPUAllocatable int64
+	MemCapacity    int64
+	MemAllocatable int64
+
+	// Summed requests and limits of all non-terminal pods
+	CPURequests   int64
+	CPULimits     int64
+	MemRequests   int64
+	MemLimits     int64
+	Overcommitted int // nodes whose limits exceed allocatable
+
+	// Live usage from metrics.k8s.io, only meaningful when UsageAvailable is true
+	CPUUsage       int64
+	MemUsage       int64
+	UsageAvailable bool
+	UsageError     error
+}
+
+// ResourceAllocation represents summed container requests and limits for a node or namespace.
+// CPU values are in millicores and memory values in bytes; allocatable is zero for namespaces.
+type ResourceAllocation struct {
+	Name           string
+	Pods           int
+	CPURequests    int64
+	CPULimits      int64
+	MemRequests    int64
+	MemLimits      int64
+	CPUAllocatable int64
+	MemAllocatable int64
+}
+
+// ResourceUsage represents live CPU (millicores) and memory (bytes) consumption
+type ResourceUsage struct {
+	CPU    int64
+	Memory int64
 }
 
 // PodMetrics represents aggregated pod statistics
@@ -0,0 +1,86 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	metricsclient "k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+// ErrMetricsUnavailable is returned when the cluster does not serve the metrics.k8s.io API
+var ErrMetricsUnavailable = errors.New("metrics.k8s.io API not available (is metrics-server installed?)")
+
+// listNodeUsage lists NodeMetrics using an existing rest config
+func listNodeUsage(ctx context.Context, restConfig *rest.Config) (map[string]ResourceUsage, error) {
+	client, err := metricsclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metrics client: %w", err)
+	}
+
+	nodeMetrics, err := client.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if isMetricsUnavailable(err) {
+			return nil, ErrMetricsUnavailable
+		}
+		return nil, fmt.Errorf("failed to list node metrics: %w", err)
+	}
+
+	usage := make(map[string]ResourceUsage, len(nodeMetrics.Items))
+	for _, item := range nodeMetrics.Items {
+		usage[item.Name] = resourceUsageFromList(item.Usage)
+	}
+
+	return usage, nil
+}
+
+// listPodUsage lists PodMetrics using an existing rest config, summing usage across containers
+func listPodUsage(ctx context.Context, restConfig *rest.Config, namespace string) (map[string]ResourceUsage, error) {
+	client, err := metricsclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metrics client: %w", err)
+	}
+
+	podMetrics, err := client.MetricsV1beta1().PodMetricses(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if isMetricsUnavailable(err) {
+			return nil, ErrMetricsUnavailable
+		}
+		return nil, fmt.Errorf("failed to list pod metrics: %w", err)
+	}
+
+	usage := make(map[string]ResourceUsage, len(podMetrics.Items))
+	for _, item := range podMetrics.Items {
+		var total ResourceUsage
+		for _, container := range item.Containers {
+			containerUsage := resourceUsageFromList(container.Usage)
+			total.CPU += containerUsage.CPU
+			total.Memory += containerUsage.Memory
+		}
+		usage[item.Namespace+"/"+item.Name] = total
+	}
+
+	return usage, nil
+}
+
+// resourceUsageFromList extracts CPU (millicores) and memory (bytes) from a resource list
+func resourceUsageFromList(list corev1.ResourceList) ResourceUsage {
+	var usage ResourceUsage
+	if cpu, ok := list[corev1.ResourceCPU]; ok {
+		usage.CPU = cpu.MilliValue()
+	}
+	if mem, ok := list[corev1.ResourceMemory]; ok {
+		usage.Memory = mem.Value()
+	}
+	return usage
+}
+
+// isMetricsUnavailable reports whether an error means the metrics API is not served
+func isMetricsUnavailable(err error) bool {
+	// 404 means the APIService is not registered, 503 means it is registered but metrics-server is down
+	return apierrors.IsNotFound(err) || apierrors.IsServiceUnavailable(err)
+}
@@ -0,0 +1,165 @@
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// DefaultEventArchiveRetentionDays is how long archived events are kept when no retention is configured
+const DefaultEventArchiveRetentionDays = 7
+
+// DefaultLogBufferLines is how many log lines the logs viewer keeps when no size is configured
+const DefaultLogBufferLines = 1000
+
+// DefaultDebugImages are the images offered for ephemeral debug containers when none are configured
+var DefaultDebugImages = []string{"busybox:1.36", "nicolaka/netshoot:latest"}
+
+// Settings holds user preferences that survive restarts
+type Settings struct {
+	Debug        DebugSettings        `json:"debug"`
+	EventArchive EventArchiveSettings `json:"eventArchive"`
+	// EventFilters holds the events filter query per context
+	EventFilters map[string]string `json:"eventFilters,omitempty"`
+	Logs         LogsSettings      `json:"logs"`
+	// TableSorts holds the sort order of each view's table
+	TableSorts map[string]TableSort `json:"tableSorts,omitempty"`
+
+	mu   sync.Mutex
+	path string
+}
+
+// EventArchiveSettings controls the local on-disk event archive
+type EventArchiveSettings struct {
+	Enabled       bool `json:"enabled"`
+	RetentionDays int  `json:"retentionDays"`
+}
+
+// DebugSettings controls the ephemeral debug containers added to pods
+type DebugSettings struct {
+	// Images are the images offered for a debug container, the first is preselected
+	Images []string `json:"images,omitempty"`
+}
+
+// LogsSettings controls how the logs viewer renders lines
+type LogsSettings struct {
+	// Fields are the extra fields of JSON and logfmt lines shown after the message
+	Fields []string `json:"fields,omitempty"`
+	// BufferLines is how many lines the logs viewer keeps, older lines are dropped
+	BufferLines int `json:"bufferLines"`
+	// Wrap wraps long lines instead of scrolling them horizontally
+	Wrap bool `json:"wrap"`
+	// Timestamps is how line timestamps are shown: raw, local, relative or hidden
+	Timestamps string `json:"timestamps"`
+	// LevelColors colors lines by their log level
+	LevelColors bool `json:"levelColors"`
+}
+
+// TableSort is the column a table is sorted by, by its title
+type TableSort struct {
+	Column     string `json:"column"`
+	Descending bool   `json:"descending,omitempty"`
+}
+
+// Dir returns the directory peek keeps its settings and local data in
+func Dir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user config directory: %w", err)
+	}
+	return filepath.Join(configDir, "peek"), nil
+}
+
+// Load reads the settings file, falling back to defaults when it does not exist yet
+func Load() (*Settings, error) {
+	s := defaults()
+
+	dir, err := Dir()
+	if err != nil {
+		return s, err
+	}
+	path := filepath.Join(dir, "settings.json")
+	s.path = path
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return s, nil
+		}
+		return s, fmt.Errorf("failed to read settings: %w", err)
+	}
+
+	if err := json.Unmarshal(data, s); err != nil {
+		s = defaults()
+		s.path = path
+		return s, fmt.Errorf("failed to parse settings %s: %w", path, err)
+	}
+	s.normalize()
+	return s, nil
+}
+
+// Save writes the settings file atomically
+func (s *Settings) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.path == "" {
+		return errors.New("settings location unknown")
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode settings: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create settings directory: %w", err)
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated settings file
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write settings: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write settings: %w", err)
+	}
+	return nil
+}
+
+func defaults() *Settings {
+	return &Settings{
+		Debug: DebugSettings{
+			Images: append([]string(nil), DefaultDebugImages...),
+		},
+		EventArchive: EventArchiveSettings{
+			RetentionDays: DefaultEventArchiveRetentionDays,
+		},
+		Logs: LogsSettings{
+			BufferLines: DefaultLogBufferLines,
+			Timestamps:  "raw",
+			LevelColors: true,
+		},
+	}
+}
+
+// normalize replaces invalid values with their defaults
+func (s *Settings) normalize() {
+	if len(s.Debug.Images) == 0 {
+		s.Debug.Images = append([]string(nil), DefaultDebugImages...)
+	}
+	if s.EventArchive.RetentionDays <= 0 {
+		s.EventArchive.RetentionDays = DefaultEventArchiveRetentionDays
+	}
+	if s.Logs.BufferLines <= 0 {
+		s.Logs.BufferLines = DefaultLogBufferLines
+	}
+	switch s.Logs.Timestamps {
+	case "raw", "local", "relative", "hidden":
+	default:
+		s.Logs.Timestamps = "raw"
+	}
+}
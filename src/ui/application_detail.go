@@ -0,0 +1,419 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"peek/src/k8s"
+	"peek/src/styles"
+)
+
+// ApplicationDescribedMsg delivers a fetched application description to the detail view
+type ApplicationDescribedMsg struct {
+	run         int
+	description *k8s.ApplicationDescription
+	err         error
+}
+
+// ApplicationDetailView shows the detail of an application: its rollout strategy and images, the
+// pods its selector matches, its conditions and its events. The cursor moves over the pods.
+type ApplicationDetailView struct {
+	isOpen       bool
+	app          k8s.ApplicationInfo
+	kubeConfig   *k8s.KubeConfig
+	contextName  string
+	description  *k8s.ApplicationDescription
+	error        error
+	isLoading    bool
+	runs         int // fetches started, so results of an earlier fetch are dropped
+	pods         *Table
+	followCursor bool // scroll the pod under the cursor into view on the next render
+	scrollOffset int
+	lineCount    int // lines of the last rendered body, to bound scrolling
+	pageSize     int
+}
+
+func NewApplicationDetailView() *ApplicationDetailView {
+	return &ApplicationDetailView{
+		pageSize: 20,
+		pods: NewTable("pods", []Column{
+			{Title: "NAME", Width: 20, Flex: 3},
+			{Title: "STATUS", Width: 6, MaxWidth: 20},
+			{Title: "READY", Width: 5},
+			{Title: "RESTARTS", Width: 8},
+			{Title: "AGE", Width: 3},
+			{Title: "NODE", Width: 10, MaxWidth: 30, Flex: 1},
+		}),
+	}
+}
+
+// Open shows the detail of an application; the returned command fetches it
+func (ad *ApplicationDetailView) Open(kubeConfig *k8s.KubeConfig, contextName string, app k8s.ApplicationInfo) tea.Cmd {
+	ad.isOpen = true
+	ad.app = app
+	ad.kubeConfig = kubeConfig
+	ad.contextName = contextName
+	ad.description = nil
+	ad.scrollOffset = 0
+	ad.pods.SetRows(nil)
+	ad.pods.SetCursor(0)
+	return ad.Refresh()
+}
+
+// Refresh fetches the application, its pods and its events again, keeping the pod under the cursor
+func (ad *ApplicationDetailView) Refresh() tea.Cmd {
+	ad.error = nil
+	ad.isLoading = true
+	ad.runs++
+	run, kubeConfig, contextName, app := ad.runs, ad.kubeConfig, ad.contextName, ad.app
+	return func() tea.Msg {
+		description, err := kubeConfig.DescribeApplication(contextName, app.Namespace, app.Type, app.Name)
+		return ApplicationDescribedMsg{run: run, description: description, err: err}
+	}
+}
+
+// Finish shows a fetched description, unless the view was closed or fetched again since
+func (ad *ApplicationDetailView) Finish(msg ApplicationDescribedMsg) {
+	if !ad.isOpen || msg.run != ad.runs {
+		return
+	}
+	ad.isLoading = false
+	if msg.err != nil {
+		ad.error = msg.err
+		return
+	}
+	ad.description = msg.description
+	ad.app = msg.description.Application
+}
+
+func (ad *ApplicationDetailView) Close() {
+	ad.isOpen = false
+	ad.runs++
+	ad.description = nil
+	ad.scrollOffset = 0
+	ad.isLoading = false
+}
+
+func (ad *ApplicationDetailView) IsOpen() bool {
+	return ad.isOpen
+}
+
+// GetApplication returns the application the view describes, as of its last refresh
+func (ad *ApplicationDetailView) GetApplication() k8s.ApplicationInfo {
+	return ad.app
+}
+
+// GetSelectedPod returns the pod under the cursor
+func (ad *ApplicationDetailView) GetSelectedPod() *k8s.PodInfo {
+	if ad.description == nil {
+		return nil
+	}
+	key := ad.pods.SelectedKey()
+	for i := range ad.description.Pods {
+		if ad.description.Pods[i].Namespace+"/"+ad.description.Pods[i].Name == key {
+			return &ad.description.Pods[i]
+		}
+	}
+	return nil
+}
+
+func (ad *ApplicationDetailView) MoveUp() {
+	ad.pods.MoveUp()
+	ad.followCursor = true
+}
+
+func (ad *ApplicationDetailView) MoveDown() {
+	ad.pods.MoveDown()
+	ad.followCursor = true
+}
+
+func (ad *ApplicationDetailView) PageUp() {
+	ad.scrollOffset = max(ad.scrollOffset-ad.pageSize, 0)
+}
+
+func (ad *ApplicationDetailView) PageDown() {
+	ad.scrollOffset = min(ad.scrollOffset+ad.pageSize, ad.maxScroll())
+}
+
+func (ad *ApplicationDetailView) maxScroll() int {
+	return max(ad.lineCount-ad.pageSize, 0)
+}
+
+func (ad *ApplicationDetailView) Render(screenWidth, screenHeight int) string {
+	if !ad.isOpen {
+		return ""
+	}
+
+	// Calculate dimensions
+	width := max(screenWidth-4, 60)
+	height := max(screenHeight-4, 15)
+	innerWidth := width - 2
+
+	var content strings.Builder
+
+	// Header
+	headerStyle := styles.NormalStyle.Bold(true).Foreground(lipgloss.Color("39"))
+	content.WriteString(headerStyle.Render(fmt.Sprintf("🚀 %s: %s", ad.app.Type, ad.app.Name)) + "\n")
+
+	// Status line
+	statusStyle := styles.NormalStyle.Foreground(lipgloss.Color("245"))
+	status := fmt.Sprintf("Namespace: %s • Status: %s", ad.app.Namespace, ad.app.Status)
+	if ad.app.Health != "" {
+		status += " • Health: " + ad.app.Health
+	}
+	if ad.isLoading && ad.description != nil {
+		status += " • Refreshing..."
+	}
+	content.WriteString(statusStyle.Render(status) + "\n")
+
+	// Controls; only the actions the workload type supports are offered
+	controlsStyle := styles.NormalStyle.Foreground(lipgloss.Color("240"))
+	controls := "↑↓=select pod enter=describe pod l=logs PgUp/PgDn=scroll r=refresh y=yaml"
+	if k8s.CanScale(ad.app.Type) {
+		controls += " s=scale"
+	}
+	if k8s.CanRestart(ad.app.Type) {
+		controls += " R=restart"
+	}
+	controls += " d=delete Esc=close"
+	content.WriteString(controlsStyle.Render(controls) + "\n\n")
+
+	// Content
+	switch {
+	case ad.error != nil:
+		errorStyle := styles.NormalStyle.Foreground(lipgloss.Color("196"))
+		content.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", ad.error)))
+	case ad.description == nil:
+		content.WriteString(styles.NormalStyle.Render("Loading application details..."))
+	default:
+		content.WriteString(ad.renderBody(innerWidth, height-6)) // Reserve space for header and controls
+	}
+
+	// Create the box style
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("39")).
+		Background(lipgloss.Color("235")).
+		Padding(1).
+		Width(width).
+		Height(height)
+
+	// Center the box on the screen
+	return lipgloss.Place(
+		screenWidth,
+		screenHeight,
+		lipgloss.Center,
+		lipgloss.Center,
+		boxStyle.Render(content.String()),
+	)
+}
+
+// renderBody lays out every section and shows the lines that fit from the scroll position on,
+// scrolling to the pod under the cursor when it moved
+func (ad *ApplicationDetailView) renderBody(width, maxLines int) string {
+	var lines []string
+	lines = append(lines, strings.Split(ad.renderOverview(width), "\n")...)
+	lines = append(lines, "")
+
+	// The pod under the cursor is below the section title and the table header
+	cursorLine := -1
+	pods := ad.renderPods(width)
+	if ad.pods.Len() > 0 {
+		cursorLine = len(lines) + 2 + ad.pods.Cursor()
+	}
+
+	for _, section := range []string{
+		pods,
+		ad.renderConditions(width),
+		ad.renderEvents(width),
+	} {
+		lines = append(lines, strings.Split(section, "\n")...)
+		lines = append(lines, "")
+	}
+
+	// One line goes to the scroll indicator
+	ad.pageSize = max(maxLines-1, 1)
+	ad.lineCount = len(lines)
+	if ad.followCursor && cursorLine >= 0 {
+		if cursorLine < ad.scrollOffset {
+			ad.scrollOffset = cursorLine
+		} else if cursorLine >= ad.scrollOffset+ad.pageSize {
+			ad.scrollOffset = cursorLine - ad.pageSize + 1
+		}
+	}
+	ad.followCursor = false
+	ad.scrollOffset = min(ad.scrollOffset, ad.maxScroll())
+	end := min(ad.scrollOffset+ad.pageSize, len(lines))
+
+	result := strings.Join(lines[ad.scrollOffset:end], "\n")
+	if len(lines) > ad.pageSize {
+		scrollStyle := styles.NormalStyle.Foreground(lipgloss.Color("240")).Italic(true)
+		result += "\n" + scrollStyle.Render(fmt.Sprintf("Showing lines %d-%d of %d", ad.scrollOffset+1, end, len(lines)))
+	}
+	return result
+}
+
+func (ad *ApplicationDetailView) renderOverview(width int) string {
+	d := ad.description
+	app := d.Application
+	var b strings.Builder
+	b.WriteString(styles.HeaderStyle.Render("🧭 Overview"))
+
+	healthStyle := styles.NormalStyle.Foreground(lipgloss.Color(getHealthColor(app.Health)))
+	replicas := fmt.Sprintf("%d desired • %d ready", app.Replicas, app.ReadyReplicas)
+	switch app.Type {
+	case "Deployment", "StatefulSet", "DaemonSet":
+		replicas += fmt.Sprintf(" • %d updated • %d available", d.UpdatedReplicas, d.AvailableReplicas)
+	case "ReplicaSet":
+		replicas += fmt.Sprintf(" • %d available", d.AvailableReplicas)
+	case "Job":
+		replicas = fmt.Sprintf("%d parallel • %d succeeded", app.Replicas, app.ReadyReplicas)
+	case "CronJob":
+		replicas = ""
+	}
+
+	fields := [][2]string{
+		{"Health", healthStyle.Render(getHealthIcon(app.Health) + " " + app.Health)},
+		{"Status", app.Status},
+	}
+	if replicas != "" {
+		fields = append(fields, [2]string{"Replicas", replicas})
+	}
+	if d.Schedule != "" {
+		fields = append(fields,
+			[2]string{"Schedule", d.Schedule},
+			[2]string{"Last Schedule", formatDetailTime(d.LastSchedule)},
+		)
+	}
+	if d.Strategy != "" {
+		fields = append(fields, [2]string{"Strategy", d.Strategy})
+	}
+	if app.Type != "CronJob" {
+		fields = append(fields, [2]string{"Selector", orNone(d.Selector)})
+	}
+	fields = append(fields,
+		[2]string{"Images", orNone(strings.Join(d.Images, ", "))},
+		[2]string{"Created", formatDetailTime(app.CreationTime)},
+		[2]string{"Labels", orNone(formatLabelList(app.Labels))},
+	)
+
+	labelStyle := styles.NormalStyle.Bold(true)
+	for _, field := range fields {
+		b.WriteString("\n" + fitCell(labelStyle.Render(fmt.Sprintf("%-16s", field[0]+":"))+" "+field[1], width, false))
+	}
+	return b.String()
+}
+
+func (ad *ApplicationDetailView) renderPods(width int) string {
+	d := ad.description
+	var b strings.Builder
+	title := "📦 Pods"
+	if d.Selector != "" {
+		title += " (" + d.Selector + ")"
+	} else if d.Application.Type == "CronJob" {
+		title += " (of its jobs)"
+	}
+	b.WriteString(styles.HeaderStyle.Render(title))
+	if d.PodsError != nil {
+		errorStyle := styles.NormalStyle.Foreground(lipgloss.Color("196"))
+		b.WriteString("\n" + errorStyle.Render(fmt.Sprintf("Error: %v", d.PodsError)))
+		return b.String()
+	}
+
+	rows := make([]TableRow, 0, len(d.Pods))
+	for _, pod := range d.Pods {
+		rows = append(rows, TableRow{
+			Key: pod.Namespace + "/" + pod.Name,
+			Cells: []string{pod.Name, pod.Status, pod.Ready, fmt.Sprintf("%d", pod.Restarts),
+				formatAppAge(pod.CreationTime), orNone(pod.Node)},
+			Style: styles.NormalStyle.Foreground(lipgloss.Color(getPodStatusColor(pod.Status))),
+		})
+	}
+	ad.pods.SetRows(rows)
+	if len(rows) == 0 {
+		b.WriteString("\n" + styles.NormalStyle.Render("No pods match the selector"))
+		return b.String()
+	}
+	b.WriteString("\n" + ad.pods.Render(width, 0))
+	return b.String()
+}
+
+func (ad *ApplicationDetailView) renderConditions(width int) string {
+	var b strings.Builder
+	b.WriteString(styles.HeaderStyle.Render("✅ Conditions"))
+	if len(ad.description.Conditions) == 0 {
+		b.WriteString("\n" + styles.NormalStyle.Render("No conditions reported"))
+		return b.String()
+	}
+
+	table := NewTable("conditions", []Column{
+		{Title: "TYPE", Width: 12, MaxWidth: 30},
+		{Title: "STATUS", Width: 6},
+		{Title: "LAST TRANSITION", Width: 15, MaxWidth: 30},
+		{Title: "REASON", Width: 6, MaxWidth: 30},
+		{Title: "MESSAGE", Width: 10, Flex: 1},
+	})
+	table.ShowCursor(false)
+	var rows []TableRow
+	for _, condition := range ad.description.Conditions {
+		rows = append(rows, TableRow{
+			Cells: []string{condition.Type, condition.Status, formatDetailTime(condition.LastTransitionTime),
+				condition.Reason, condition.Message},
+			Style: styles.NormalStyle.Foreground(lipgloss.Color(workloadConditionColor(condition))),
+		})
+	}
+	table.SetRows(rows)
+	b.WriteString("\n" + table.Render(width, 0))
+	return b.String()
+}
+
+func (ad *ApplicationDetailView) renderEvents(width int) string {
+	var b strings.Builder
+	b.WriteString(styles.HeaderStyle.Render("📰 Events (oldest first)"))
+	if ad.description.EventsError != nil {
+		errorStyle := styles.NormalStyle.Foreground(lipgloss.Color("196"))
+		b.WriteString("\n" + errorStyle.Render(fmt.Sprintf("Error: %v", ad.description.EventsError)))
+		return b.String()
+	}
+	if len(ad.description.Events) == 0 {
+		b.WriteString("\n" + styles.NormalStyle.Render("No events"))
+		return b.String()
+	}
+
+	table := NewTable("events", []Column{
+		{Title: "LAST SEEN", Width: 9},
+		{Title: "TYPE", Width: 7},
+		{Title: "REASON", Width: 8, MaxWidth: 25},
+		{Title: "FROM", Width: 6, MaxWidth: 25},
+		{Title: "COUNT", Width: 5},
+		{Title: "MESSAGE", Width: 10, Flex: 1},
+	})
+	table.ShowCursor(false)
+	var rows []TableRow
+	for _, event := range ad.description.Events {
+		rows = append(rows, TableRow{
+			Cells: []string{k8s.FormatTimeAgo(event.LastTimestamp) + " ago", event.Type, event.Reason,
+				event.Source, fmt.Sprintf("%d", event.Count), event.Message},
+			Style: styles.NormalStyle.Foreground(lipgloss.Color(k8s.GetEventColor(event.Type))),
+		})
+	}
+	table.SetRows(rows)
+	b.WriteString("\n" + table.Render(width, 0))
+	return b.String()
+}
+
+// workloadConditionColor colors a workload condition by whether it is good news. Most conditions
+// are good when true, but ReplicaFailure and a job's Failed are bad.
+func workloadConditionColor(condition k8s.PodConditionInfo) string {
+	bad := condition.Type == "ReplicaFailure" || condition.Type == "Failed" || condition.Type == "FailureTarget"
+	switch {
+	case condition.Status == "True" && bad:
+		return "196"
+	case condition.Status == "True" || bad:
+		return "46"
+	default:
+		return "226"
+	}
+}
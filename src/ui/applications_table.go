@@ -6,55 +6,89 @@ import (
 	"strings"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"peek/src/k8s"
 	"peek/src/styles"
 )
 
+// ApplicationsLoadedMsg delivers listed applications to the applications table
+type ApplicationsLoadedMsg struct {
+	generation   int
+	selector     *k8s.Selector
+	applications []k8s.ApplicationInfo
+	err          error
+}
+
 type ApplicationsTable struct {
+	tableData
 	applications []k8s.ApplicationInfo
-	lastUpdate   time.Time
 	kubeConfig   *k8s.KubeConfig
 	contextName  string
 	namespace    string
-	isLoading    bool
-	error        error
+	selector     *k8s.Selector
+	generation   int // bumped when the namespace or selector changes, so loads started before are dropped
+	table        *Table
 }
 
 func NewApplicationsTable(kubeConfig *k8s.KubeConfig, contextName, namespace string) *ApplicationsTable {
+	table := NewTable("applications", []Column{
+		{Title: "HEALTH", Width: 6, MaxWidth: 13},
+		{Title: "TYPE", Width: 4, MaxWidth: 12},
+		{Title: "NAME", Width: 20, Flex: 3},
+		{Title: "NAMESPACE", Width: 10, MaxWidth: 30, Flex: 1},
+		{Title: "STATUS", Width: 6, MaxWidth: 16},
+		{Title: "READY", Width: 5},
+		{Title: "REPLICAS", Width: 8},
+		{Title: "AGE", Width: 3},
+	})
+
 	return &ApplicationsTable{
+		tableData:   tableData{isLoading: true},
 		kubeConfig:  kubeConfig,
 		contextName: contextName,
 		namespace:   namespace,
-		isLoading:   true,
+		table:       table,
 	}
 }
 
 func (at *ApplicationsTable) SetNamespace(namespace string) {
 	at.namespace = namespace
+	at.generation++
 	// Force refresh on next update check
-	at.lastUpdate = time.Time{}
+	at.invalidate()
 	// Clear applications to trigger loading state
 	at.applications = []k8s.ApplicationInfo{}
 }
 
-func (at *ApplicationsTable) Update() error {
-	if at.kubeConfig == nil {
-		return fmt.Errorf("kubeconfig not available")
+// Load returns a command that lists the applications, or nil while a load is already running
+func (at *ApplicationsTable) Load() tea.Cmd {
+	if at.kubeConfig == nil || at.running {
+		return nil
 	}
 
 	// Only set loading to true if this is the first load (no existing applications)
-	if len(at.applications) == 0 {
-		at.isLoading = true
+	at.startLoad(len(at.applications) == 0)
+
+	kubeConfig, contextName, namespace, selector, generation := at.kubeConfig, at.contextName, at.namespace, at.selector, at.generation
+	return func() tea.Msg {
+		applications, err := kubeConfig.GetApplications(contextName, namespace, selector)
+		return ApplicationsLoadedMsg{generation: generation, selector: selector, applications: applications, err: err}
 	}
-	at.error = nil
+}
 
-	applications, err := at.kubeConfig.GetApplications(at.contextName, at.namespace)
-	if err != nil {
-		at.error = err
-		at.isLoading = false
-		return err
+// FinishLoad shows listed applications, unless the namespace or selector changed since the load
+// started
+func (at *ApplicationsTable) FinishLoad(msg ApplicationsLoadedMsg) {
+	if at.selector != msg.selector || at.generation != msg.generation {
+		// The load started for the applications listed now replaces this one
+		return
 	}
+	if msg.err != nil {
+		at.finishLoad(msg.err)
+		return
+	}
+	applications := msg.applications
 
 	// Sort applications by type first, then by name
 	sort.Slice(applications, func(i, j int) bool {
@@ -65,49 +99,90 @@ func (at *ApplicationsTable) Update() error {
 	})
 
 	at.applications = applications
-	at.lastUpdate = time.Now()
-	at.isLoading = false
+	at.finishLoad(nil)
+}
+
+// SetSelector lists only the applications matching a label and field selector; an empty query lists
+// all applications
+func (at *ApplicationsTable) SetSelector(query string) error {
+	selector, err := k8s.ParseWorkloadSelector(query)
+	if err != nil {
+		return err
+	}
+	at.selector = selector
+	at.generation++
+	// Force refresh on next update check
+	at.invalidate()
 	return nil
 }
 
-func (at *ApplicationsTable) ShouldUpdate() bool {
-	// Update every 30 seconds for applications
-	return time.Since(at.lastUpdate) > 30*time.Second
+// GetSelector returns the selector the applications are listed with
+func (at *ApplicationsTable) GetSelector() string {
+	return at.selector.String()
 }
 
-func (at *ApplicationsTable) Render() string {
-	var b strings.Builder
+func (at *ApplicationsTable) MoveUp() {
+	at.table.MoveUp()
+}
 
-	// Only show loading screen if we have no applications AND it's the initial load
-	if at.isLoading && len(at.applications) == 0 && at.lastUpdate.IsZero() {
-		b.WriteString(styles.NormalStyle.Render("Loading applications..."))
-		return b.String()
+func (at *ApplicationsTable) MoveDown() {
+	at.table.MoveDown()
+}
+
+// GetSelectedApplication returns the application under the cursor
+func (at *ApplicationsTable) GetSelectedApplication() *k8s.ApplicationInfo {
+	key := at.table.SelectedKey()
+	for i := range at.applications {
+		if applicationKey(at.applications[i]) == key {
+			return &at.applications[i]
+		}
 	}
+	return nil
+}
 
-	if at.error != nil {
-		errorStyle := styles.NormalStyle.Foreground(lipgloss.Color("196"))
-		b.WriteString(errorStyle.Render(fmt.Sprintf("Error loading applications: %v", at.error)))
-		return b.String()
+// SelectApplication puts the cursor on an application, waiting for the next refresh if it is not
+// loaded yet
+func (at *ApplicationsTable) SelectApplication(appType, namespace, name string) {
+	at.table.SelectKey(applicationKey(k8s.ApplicationInfo{Type: appType, Namespace: namespace, Name: name}))
+}
+
+// Render draws the applications view in width by height
+func (at *ApplicationsTable) Render(width, height int) string {
+	if state := at.renderState("applications", len(at.applications) == 0); state != "" {
+		return state
 	}
 
+	var b strings.Builder
+
 	// Namespace info - show updating status more subtly
 	namespaceStyle := styles.NormalStyle.Foreground(lipgloss.Color("245"))
 	namespaceText := fmt.Sprintf("Showing applications in namespace: %s", at.namespace)
 	if at.namespace == "" {
 		namespaceText = "Showing applications across all namespaces"
 	}
-	if at.isLoading && len(at.applications) > 0 {
-		// Show a subtle updating indicator only if we already have data
-		namespaceText += " ●"
-	}
+	namespaceText += at.refreshMarker(len(at.applications) == 0)
 	b.WriteString(namespaceStyle.Render(namespaceText) + "\n")
 
+	// Selector the applications are listed with
+	if !at.selector.IsEmpty() {
+		selectorStyle := styles.NormalStyle.Foreground(lipgloss.Color("39"))
+		selectorText := fmt.Sprintf("🏷 Selector: %s", at.selector)
+		if clientFields := at.selector.ClientFields(); len(clientFields) > 0 {
+			selectorText += fmt.Sprintf(" (%s matched locally)", strings.Join(clientFields, ","))
+		}
+		b.WriteString(selectorStyle.Render(selectorText) + "\n")
+	}
+
 	// Controls info
 	controlsStyle := styles.NormalStyle.Foreground(lipgloss.Color("240"))
-	b.WriteString(controlsStyle.Render("Auto-refresh every 30s • Use Ctrl+N to change namespace") + "\n\n")
+	b.WriteString(controlsStyle.Render("Auto-refresh every 30s • Ctrl+N=namespace • f=selector • enter=details s=scale r=restart y=yaml d=delete • o/O=sort") + "\n\n")
 
 	if len(at.applications) == 0 {
-		b.WriteString(styles.NormalStyle.Render("No applications found in the selected namespace(s)"))
+		if !at.selector.IsEmpty() {
+			b.WriteString(styles.NormalStyle.Render("No applications match the selector"))
+		} else {
+			b.WriteString(styles.NormalStyle.Render("No applications found in the selected namespace(s)"))
+		}
 		return b.String()
 	}
 
@@ -115,7 +190,8 @@ func (at *ApplicationsTable) Render() string {
 	b.WriteString(at.renderSummary() + "\n\n")
 
 	// Applications table
-	b.WriteString(at.renderApplicationsTable())
+	b.WriteString(styles.HeaderStyle.Render("🚀 Applications") + "\n")
+	b.WriteString(at.renderApplicationsTable(width, remainingHeight(height, b.String())))
 
 	return b.String()
 }
@@ -123,13 +199,15 @@ func (at *ApplicationsTable) Render() string {
 func (at *ApplicationsTable) renderSummary() string {
 	var b strings.Builder
 
-	// Count by type and status
+	// Count by type, status and health
 	typeCounts := make(map[string]int)
 	statusCounts := make(map[string]int)
+	healthCounts := make(map[string]int)
 
 	for _, app := range at.applications {
 		typeCounts[app.Type]++
 		statusCounts[app.Status]++
+		healthCounts[app.Health]++
 	}
 
 	b.WriteString(styles.HeaderStyle.Render("📊 Applications Summary") + "\n")
@@ -152,59 +230,95 @@ func (at *ApplicationsTable) renderSummary() string {
 		statusStyle := styles.NormalStyle.Foreground(lipgloss.Color(color))
 		statusParts = append(statusParts, statusStyle.Render(fmt.Sprintf("%s: %d", status, count)))
 	}
-	b.WriteString(strings.Join(statusParts, " | "))
+	b.WriteString(strings.Join(statusParts, " | ") + "\n")
+
+	// Health summary, worst first
+	b.WriteString(styles.NormalStyle.Bold(true).Render("By Health:") + " ")
+	var healthParts []string
+	for _, health := range applicationHealths {
+		if count := healthCounts[health]; count > 0 {
+			healthStyle := styles.NormalStyle.Foreground(lipgloss.Color(getHealthColor(health)))
+			healthParts = append(healthParts, healthStyle.Render(fmt.Sprintf("%s %s: %d", getHealthIcon(health), health, count)))
+		}
+	}
+	b.WriteString(strings.Join(healthParts, " | "))
 
 	return b.String()
 }
 
-func (at *ApplicationsTable) renderApplicationsTable() string {
-	var b strings.Builder
+func (at *ApplicationsTable) renderApplicationsTable(width, height int) string {
+	rows := make([]TableRow, 0, len(at.applications))
+	for _, app := range at.applications {
+		// Color based on health, which the status feeds
+		rows = append(rows, TableRow{
+			Key: applicationKey(app),
+			Cells: []string{getHealthIcon(app.Health) + " " + app.Health, app.Type, app.Name, app.Namespace, app.Status,
+				fmt.Sprintf("%d/%d", app.ReadyReplicas, app.Replicas), fmt.Sprintf("%d", app.Replicas),
+				formatAppAge(app.CreationTime)},
+			Values: []any{healthSeverity(app.Health), nil, nil, nil, appStatusSeverity(app.Status), readyRatio(fmt.Sprintf("%d/%d", app.ReadyReplicas, app.Replicas)),
+				app.Replicas, time.Since(app.CreationTime)},
+			Style: styles.NormalStyle.Foreground(lipgloss.Color(getHealthColor(app.Health))),
+		})
+	}
+	at.table.SetRows(rows)
 
-	b.WriteString(styles.HeaderStyle.Render("🚀 Applications") + "\n")
+	return at.table.Render(width, height)
+}
 
-	// Table header
-	headerStyle := styles.NormalStyle.Bold(true).Underline(true)
-	header := fmt.Sprintf("%-12s %-20s %-15s %-10s %-8s %-12s %s",
-		"TYPE", "NAME", "NAMESPACE", "STATUS", "READY", "REPLICAS", "AGE")
-	b.WriteString(headerStyle.Render(header) + "\n")
-
-	// Table rows
-	for i, app := range at.applications {
-		if i >= 50 { // Limit to 50 applications for performance
-			moreStyle := styles.NormalStyle.Foreground(lipgloss.Color("240"))
-			b.WriteString(moreStyle.Render(fmt.Sprintf("\n... and %d more applications", len(at.applications)-50)))
-			break
-		}
+func applicationKey(app k8s.ApplicationInfo) string {
+	return app.Type + "/" + app.Namespace + "/" + app.Name
+}
 
-		// Truncate and format fields
-		appType := truncateString(app.Type, 12)
-		name := truncateString(app.Name, 20)
-		namespace := truncateString(app.Namespace, 15)
-		status := truncateString(app.Status, 10)
-		ready := fmt.Sprintf("%d/%d", app.ReadyReplicas, app.Replicas)
-		if len(ready) > 8 {
-			ready = truncateString(ready, 8)
-		}
-		replicas := fmt.Sprintf("%d", app.Replicas)
-		if len(replicas) > 12 {
-			replicas = truncateString(replicas, 12)
-		}
-		age := formatAppAge(app.CreationTime)
+// applicationHealths lists the health levels from worst to best
+var applicationHealths = []string{
+	k8s.HealthFailed,
+	k8s.HealthUnavailable,
+	k8s.HealthDegraded,
+	k8s.HealthProgressing,
+	k8s.HealthSuspended,
+	k8s.HealthHealthy,
+}
 
-		row := fmt.Sprintf("%-12s %-20s %-15s %-10s %-8s %-12s %s",
-			appType, name, namespace, status, ready, replicas, age)
+// getHealthIcon returns the indicator shown in front of an application's health
+func getHealthIcon(health string) string {
+	switch health {
+	case k8s.HealthHealthy:
+		return "●"
+	case k8s.HealthProgressing:
+		return "◌"
+	case k8s.HealthDegraded:
+		return "◐"
+	case k8s.HealthUnavailable, k8s.HealthFailed:
+		return "✗"
+	case k8s.HealthSuspended:
+		return "○"
+	default:
+		return "?"
+	}
+}
 
-		// Color based on status
-		statusColor := getStatusColor(app.Status)
-		rowStyle := styles.NormalStyle.Foreground(lipgloss.Color(statusColor))
+// getHealthColor returns the color for an application's health
+func getHealthColor(health string) string {
+	switch health {
+	case k8s.HealthHealthy:
+		return "46" // Green
+	case k8s.HealthProgressing, k8s.HealthDegraded:
+		return "226" // Yellow
+	case k8s.HealthUnavailable, k8s.HealthFailed:
+		return "196" // Red
+	default:
+		return "240" // Gray
+	}
+}
 
-		b.WriteString(rowStyle.Render(row))
-		if i < len(at.applications)-1 && i < 49 {
-			b.WriteString("\n")
+// healthSeverity ranks an application's health for sorting, worst highest
+func healthSeverity(health string) int {
+	for i, level := range applicationHealths {
+		if level == health {
+			return len(applicationHealths) - i
 		}
 	}
-
-	return b.String()
+	return 0
 }
 
 // getTypeColor returns the color for different application types
@@ -243,6 +357,18 @@ func getStatusColor(status string) string {
 	}
 }
 
+// appStatusSeverity ranks an application status for sorting: 0 for healthy, 1 for in progress,
+// 2 for failing; unknown statuses rank with in progress
+func appStatusSeverity(status string) int {
+	switch getStatusColor(status) {
+	case "46":
+		return 0
+	case "196":
+		return 2
+	}
+	return 1
+}
+
 // formatAppAge formats the age of an application
 func formatAppAge(creationTime time.Time) string {
 	if creationTime.IsZero() {
@@ -264,4 +390,4 @@ func formatAppAge(creationTime time.Time) string {
 		days := int(duration.Hours()) / 24
 		return fmt.Sprintf("%dd", days)
 	}
-}
\ No newline at end of file
+}
@@ -1,21 +1,34 @@
 package ui
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"peek/src/k8s"
 	"peek/src/styles"
 )
 
+// bulkConfirmationNames is how many pod names a bulk confirmation lists before summarising the rest
+const bulkConfirmationNames = 5
+
 type ConfirmationDialog struct {
-	isOpen      bool
-	title       string
-	message     string
-	podName     string
-	namespace   string
-	action      string // "delete" or "restart"
-	confirmed   bool
-	cursor      int // 0 = Yes, 1 = No
+	isOpen    bool
+	title     string
+	message   string
+	podName   string
+	namespace string
+	action    string // "delete", "restart" or "label"
+	confirmed bool
+	cursor    int // 0 = Yes, 1 = No
+
+	// Bulk actions confirm a set of pods at once
+	pods   []k8s.PodInfo
+	detail string
+
+	// Application actions confirm a change to a workload instead of a pod
+	application *k8s.ApplicationInfo
 }
 
 func NewConfirmationDialog() *ConfirmationDialog {
@@ -33,6 +46,9 @@ func (cd *ConfirmationDialog) Open(action, podName, namespace string) {
 	cd.action = action
 	cd.confirmed = false
 	cd.cursor = 1 // Default to "No"
+	cd.pods = nil
+	cd.detail = ""
+	cd.application = nil
 
 	if action == "delete" {
 		cd.title = "⚠️  Delete Pod"
@@ -43,6 +59,72 @@ func (cd *ConfirmationDialog) Open(action, podName, namespace string) {
 	}
 }
 
+// OpenBulk asks once for an action on a set of pods, summarising them instead of naming each.
+// The detail describes the action further, e.g. the label changes.
+func (cd *ConfirmationDialog) OpenBulk(action string, pods []k8s.PodInfo, detail string) {
+	cd.isOpen = true
+	cd.podName = ""
+	cd.namespace = ""
+	cd.action = action
+	cd.confirmed = false
+	cd.cursor = 1 // Default to "No"
+	cd.pods = pods
+	cd.detail = detail
+	cd.application = nil
+
+	switch action {
+	case "delete":
+		cd.title = fmt.Sprintf("⚠️  Delete %d Pods", len(pods))
+		cd.message = "This will permanently delete the pods. Their controllers may recreate them."
+	case "restart":
+		cd.title = fmt.Sprintf("🔄 Restart %d Pods", len(pods))
+		cd.message = "This will delete the pods so their controllers recreate them. There may be brief downtime."
+	case "label":
+		cd.title = fmt.Sprintf("🏷  Label %d Pods", len(pods))
+		cd.message = "This will change the labels of the pods. Services and controllers selecting them may pick them up or drop them."
+	}
+}
+
+// OpenApplication asks for an action on a workload: "delete" or "restart"
+func (cd *ConfirmationDialog) OpenApplication(action string, app k8s.ApplicationInfo) {
+	cd.isOpen = true
+	cd.podName = ""
+	cd.namespace = ""
+	cd.action = action
+	cd.confirmed = false
+	cd.cursor = 1 // Default to "No"
+	cd.pods = nil
+	cd.detail = ""
+	cd.application = &app
+
+	switch action {
+	case "delete":
+		created := "its pods"
+		switch app.Type {
+		case "Deployment":
+			created = "its ReplicaSets and pods"
+		case "CronJob":
+			created = "its jobs and their pods"
+		}
+		cd.title = "⚠️  Delete " + app.Type
+		cd.message = fmt.Sprintf("This will permanently delete the %s and %s. Nothing will recreate them.",
+			strings.ToLower(app.Type), created)
+	case "restart":
+		cd.title = "🔄 Restart " + app.Type
+		cd.message = "This will replace every pod following the update strategy, like kubectl rollout restart."
+	}
+}
+
+// GetApplication returns the workload the dialog confirms an action on, nil for pod actions
+func (cd *ConfirmationDialog) GetApplication() *k8s.ApplicationInfo {
+	return cd.application
+}
+
+// IsBulk reports whether the dialog confirms an action on a set of pods
+func (cd *ConfirmationDialog) IsBulk() bool {
+	return len(cd.pods) > 0
+}
+
 func (cd *ConfirmationDialog) Close() {
 	cd.isOpen = false
 	cd.confirmed = false
@@ -61,10 +143,12 @@ func (cd *ConfirmationDialog) MoveRight() {
 	cd.cursor = 1 // No
 }
 
+// Confirm closes the dialog and reports whether "Yes" was chosen
 func (cd *ConfirmationDialog) Confirm() bool {
-	cd.confirmed = (cd.cursor == 0)
+	confirmed := cd.cursor == 0
 	cd.Close()
-	return cd.confirmed
+	cd.confirmed = confirmed
+	return confirmed
 }
 
 func (cd *ConfirmationDialog) GetAction() string {
@@ -84,8 +168,15 @@ func (cd *ConfirmationDialog) Render(screenWidth, screenHeight int) string {
 
 	// Pod information
 	podStyle := styles.NormalStyle.Bold(true)
-	content.WriteString(podStyle.Render("Pod: ") + cd.podName + "\n")
-	content.WriteString(podStyle.Render("Namespace: ") + cd.namespace + "\n\n")
+	if cd.IsBulk() {
+		content.WriteString(cd.renderBulkSummary(podStyle) + "\n")
+	} else if cd.application != nil {
+		content.WriteString(podStyle.Render(cd.application.Type+": ") + cd.application.Name + "\n")
+		content.WriteString(podStyle.Render("Namespace: ") + cd.application.Namespace + "\n\n")
+	} else {
+		content.WriteString(podStyle.Render("Pod: ") + cd.podName + "\n")
+		content.WriteString(podStyle.Render("Namespace: ") + cd.namespace + "\n\n")
+	}
 
 	// Message
 	messageStyle := styles.NormalStyle.Foreground(lipgloss.Color("252"))
@@ -138,4 +229,58 @@ func (cd *ConfirmationDialog) Render(screenWidth, screenHeight int) string {
 		lipgloss.Center,
 		dialog,
 	)
-}
\ No newline at end of file
+}
+
+// renderBulkSummary counts the pods by namespace and status and names the first few
+func (cd *ConfirmationDialog) renderBulkSummary(labelStyle lipgloss.Style) string {
+	var b strings.Builder
+
+	namespaces := make(map[string]int)
+	statuses := make(map[string]int)
+	for _, pod := range cd.pods {
+		namespaces[pod.Namespace]++
+		statuses[pod.Status]++
+	}
+
+	scope := fmt.Sprintf("%d namespaces", len(namespaces))
+	if len(namespaces) == 1 {
+		scope = "namespace " + cd.pods[0].Namespace
+	}
+	b.WriteString(labelStyle.Render("Pods: ") + fmt.Sprintf("%d in %s", len(cd.pods), scope) + "\n")
+	b.WriteString(labelStyle.Render("Status: ") + countSummary(statuses) + "\n")
+	if cd.detail != "" {
+		b.WriteString(labelStyle.Render("Labels: ") + cd.detail + "\n")
+	}
+	b.WriteString("\n")
+
+	nameStyle := styles.NormalStyle.Foreground(lipgloss.Color("245"))
+	for i, pod := range cd.pods {
+		if i == bulkConfirmationNames {
+			b.WriteString(nameStyle.Render(fmt.Sprintf("... and %d more", len(cd.pods)-bulkConfirmationNames)) + "\n")
+			break
+		}
+		b.WriteString(nameStyle.Render(pod.Namespace+"/"+pod.Name) + "\n")
+	}
+
+	return b.String()
+}
+
+// countSummary lists counts from most to least common, e.g. "Evicted: 30, Completed: 10"
+func countSummary(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = fmt.Sprintf("%s: %d", key, counts[key])
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"peek/src/k8s"
+)
+
+// ContainerPicker lets the user choose which container of a pod to open, including init and
+// ephemeral containers, and whether to read the logs of its previous instance
+type ContainerPicker struct {
+	isOpen     bool
+	pod        k8s.PodInfo
+	containers []k8s.ContainerInfo
+	cursor     int
+	previous   bool
+	width      int
+}
+
+func NewContainerPicker() *ContainerPicker {
+	return &ContainerPicker{
+		width: 70,
+	}
+}
+
+// Open shows the containers of a pod, with the cursor on the first app container
+func (cp *ContainerPicker) Open(pod k8s.PodInfo) {
+	cp.isOpen = true
+	cp.pod = pod
+	cp.containers = pod.Containers
+	cp.previous = false
+	cp.cursor = 0
+	for i, container := range cp.containers {
+		if container.Type == k8s.ContainerTypeApp {
+			cp.cursor = i
+			break
+		}
+	}
+}
+
+func (cp *ContainerPicker) Close() {
+	cp.isOpen = false
+}
+
+func (cp *ContainerPicker) IsOpen() bool {
+	return cp.isOpen
+}
+
+func (cp *ContainerPicker) MoveUp() {
+	if cp.cursor > 0 {
+		cp.cursor--
+	}
+}
+
+func (cp *ContainerPicker) MoveDown() {
+	if cp.cursor < len(cp.containers)-1 {
+		cp.cursor++
+	}
+}
+
+// TogglePrevious switches between the current and the previous container instance
+func (cp *ContainerPicker) TogglePrevious() {
+	cp.previous = !cp.previous
+}
+
+func (cp *ContainerPicker) IsPrevious() bool {
+	return cp.previous
+}
+
+func (cp *ContainerPicker) GetPod() k8s.PodInfo {
+	return cp.pod
+}
+
+func (cp *ContainerPicker) GetSelectedContainer() *k8s.ContainerInfo {
+	if cp.cursor < len(cp.containers) {
+		return &cp.containers[cp.cursor]
+	}
+	return nil
+}
+
+func (cp *ContainerPicker) Render(screenWidth, screenHeight int) string {
+	if !cp.isOpen {
+		return ""
+	}
+
+	// Create modal style
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("39")).
+		Width(cp.width).
+		Padding(1).
+		Background(lipgloss.Color("235"))
+
+	// Title
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("229")).
+		Bold(true).
+		MarginBottom(1)
+
+	title := titleStyle.Render(fmt.Sprintf("Select Container: %s", cp.pod.Name))
+
+	// Container list
+	var containerList strings.Builder
+
+	itemStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("252"))
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(true).
+		Width(cp.width - 4)
+
+	for i, container := range cp.containers {
+		state := container.State
+		if container.Reason != "" {
+			state += " (" + container.Reason + ")"
+		}
+		line := fmt.Sprintf("  %-24s %-10s %-24s %d restarts",
+			truncateString(container.Name, 24), container.Type, truncateString(state, 24), container.RestartCount)
+
+		if i == cp.cursor {
+			containerList.WriteString(selectedStyle.Render(line))
+		} else {
+			// Dim containers that have not started, there is nothing to read yet
+			style := itemStyle
+			if container.State == "Waiting" && container.RestartCount == 0 {
+				style = style.Foreground(lipgloss.Color("240"))
+			}
+			containerList.WriteString(style.Render(line))
+		}
+
+		if i < len(cp.containers)-1 {
+			containerList.WriteString("\n")
+		}
+	}
+
+	if len(cp.containers) == 0 {
+		containerList.WriteString(itemStyle.Render("  No containers found"))
+	}
+
+	// Instance toggle
+	instanceStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+	instance := "Instance: current"
+	if cp.previous {
+		instanceStyle = instanceStyle.Foreground(lipgloss.Color("214"))
+		instance = "Instance: previous (last terminated)"
+	}
+
+	controlsStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	controls := "↑↓=select p=current/previous Enter=open Esc=cancel"
+
+	// Combine all elements
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		containerList.String(),
+		"",
+		instanceStyle.Render(instance),
+		controlsStyle.Render(controls),
+	)
+
+	// Center the modal on the screen
+	return lipgloss.Place(
+		screenWidth,
+		screenHeight,
+		lipgloss.Center,
+		lipgloss.Center,
+		modalStyle.Render(content),
+	)
+}
@@ -0,0 +1,366 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"peek/src/k8s"
+	"peek/src/styles"
+)
+
+// CopyDoneMsg reports how a copy started from the copy dialog ended
+type CopyDoneMsg struct {
+	Upload    bool
+	From      string
+	To        string
+	Progress  k8s.CopyProgress
+	Cancelled bool
+	Err       error
+
+	copy *k8s.PodCopy
+}
+
+// Fields of the copy dialog, top to bottom
+const (
+	copyFieldDirection = iota
+	copyFieldContainer
+	copyFieldLocal
+	copyFieldContainerPath
+	copyFieldCount
+)
+
+// CopyDialog copies files between the local machine and a container of a pod, like `kubectl cp`,
+// and shows the progress of the copy
+type CopyDialog struct {
+	isOpen        bool
+	pod           k8s.PodInfo
+	containers    []string
+	container     int
+	upload        bool
+	localPath     string
+	containerPath string
+	field         int
+	copy          *k8s.PodCopy
+	cancel        context.CancelFunc
+	result        *CopyDoneMsg
+	err           string
+	width         int
+}
+
+func NewCopyDialog() *CopyDialog {
+	return &CopyDialog{
+		localPath: ".",
+		width:     80,
+	}
+}
+
+// Open asks what to copy to or from a pod. Paths typed earlier are kept.
+func (cd *CopyDialog) Open(pod k8s.PodInfo) {
+	cd.isOpen = true
+	cd.pod = pod
+	cd.field = copyFieldContainerPath
+	cd.copy = nil
+	cd.result = nil
+	cd.err = ""
+	cd.containers = nil
+	cd.container = 0
+	for _, container := range pod.Containers {
+		if container.Type != k8s.ContainerTypeInit {
+			cd.containers = append(cd.containers, container.Name)
+		}
+	}
+}
+
+// Close hides the dialog and stops a copy that is still running
+func (cd *CopyDialog) Close() {
+	cd.isOpen = false
+	if cd.cancel != nil {
+		cd.cancel()
+		cd.cancel = nil
+	}
+}
+
+func (cd *CopyDialog) IsOpen() bool {
+	return cd.isOpen
+}
+
+// IsCopying reports whether a copy is running; the choices are locked meanwhile
+func (cd *CopyDialog) IsCopying() bool {
+	return cd.copy != nil && cd.result == nil
+}
+
+// Cancel stops the running copy; what was copied so far stays
+func (cd *CopyDialog) Cancel() {
+	if cd.cancel != nil {
+		cd.cancel()
+	}
+}
+
+func (cd *CopyDialog) MoveUp() {
+	if !cd.IsCopying() && cd.field > 0 {
+		cd.field--
+	}
+}
+
+func (cd *CopyDialog) MoveDown() {
+	if !cd.IsCopying() && cd.field < copyFieldCount-1 {
+		cd.field++
+	}
+}
+
+// Toggle changes the direction or the container, whichever field is focused
+func (cd *CopyDialog) Toggle(step int) {
+	if cd.IsCopying() {
+		return
+	}
+	switch cd.field {
+	case copyFieldDirection:
+		cd.upload = !cd.upload
+	case copyFieldContainer:
+		if len(cd.containers) > 0 {
+			cd.container = (cd.container + step + len(cd.containers)) % len(cd.containers)
+		}
+	}
+}
+
+// AddChar types into the focused path
+func (cd *CopyDialog) AddChar(char string) {
+	if cd.IsCopying() || len(char) != 1 || char[0] < ' ' || char[0] >= 0x7f {
+		return
+	}
+	switch cd.field {
+	case copyFieldLocal:
+		cd.localPath += char
+	case copyFieldContainerPath:
+		cd.containerPath += char
+	}
+}
+
+func (cd *CopyDialog) Backspace() {
+	if cd.IsCopying() {
+		return
+	}
+	switch cd.field {
+	case copyFieldLocal:
+		if len(cd.localPath) > 0 {
+			cd.localPath = cd.localPath[:len(cd.localPath)-1]
+		}
+	case copyFieldContainerPath:
+		if len(cd.containerPath) > 0 {
+			cd.containerPath = cd.containerPath[:len(cd.containerPath)-1]
+		}
+	}
+}
+
+// Start copies with the chosen direction, container and paths. The returned command delivers a
+// CopyDoneMsg once the copy ends.
+func (cd *CopyDialog) Start(kubeConfig *k8s.KubeConfig, contextName string) tea.Cmd {
+	if cd.IsCopying() {
+		return nil
+	}
+	localPath := strings.TrimSpace(cd.localPath)
+	containerPath := strings.TrimSpace(cd.containerPath)
+	switch {
+	case len(cd.containers) == 0:
+		cd.err = "The pod has no container to copy with"
+		return nil
+	case localPath == "" || containerPath == "":
+		cd.err = "Enter both a local path and a container path"
+		return nil
+	case !strings.HasPrefix(containerPath, "/"):
+		cd.err = "The container path must be absolute, e.g. /tmp/dump"
+		return nil
+	}
+
+	cd.err = ""
+	cd.result = nil
+	copier := kubeConfig.NewPodCopy(contextName, cd.pod.Namespace, cd.pod.Name, cd.containers[cd.container])
+	cd.copy = copier
+	ctx, cancel := context.WithCancel(context.Background())
+	cd.cancel = cancel
+
+	upload := cd.upload
+	return func() tea.Msg {
+		defer cancel()
+		msg := CopyDoneMsg{Upload: upload, copy: copier}
+		var err error
+		if upload {
+			msg.From, msg.To = localPath, containerPath
+			err = copier.ToContainer(ctx, localPath, containerPath)
+		} else {
+			msg.From, msg.To = containerPath, localPath
+			err = copier.FromContainer(ctx, containerPath, localPath)
+		}
+		msg.Progress = copier.Progress()
+		msg.Cancelled = ctx.Err() != nil
+		if !msg.Cancelled {
+			msg.Err = err
+		}
+		return msg
+	}
+}
+
+// Finish shows how the copy ended, unless the dialog moved on to another copy
+func (cd *CopyDialog) Finish(msg CopyDoneMsg) {
+	if msg.copy != cd.copy {
+		return
+	}
+	cd.result = &msg
+	cd.cancel = nil
+}
+
+func (cd *CopyDialog) Render(screenWidth, screenHeight int) string {
+	if !cd.isOpen {
+		return ""
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("39")).
+		Background(lipgloss.Color("235")).
+		Padding(1, 2).
+		Width(cd.width - 4)
+	innerWidth := cd.width - 8
+
+	var content strings.Builder
+
+	titleStyle := styles.NormalStyle.Bold(true).Foreground(lipgloss.Color("39"))
+	content.WriteString(titleStyle.Render(fmt.Sprintf("📁 Copy Files: %s/%s", cd.pod.Namespace, cd.pod.Name)))
+	content.WriteString("\n\n")
+
+	direction := "Container → local"
+	if cd.upload {
+		direction = "Local → container"
+	}
+	container := "none"
+	if len(cd.containers) > 0 {
+		container = cd.containers[cd.container]
+	}
+	content.WriteString(cd.renderChoice("Copy", direction, cd.field == copyFieldDirection) + "\n")
+	content.WriteString(cd.renderChoice("Container", container, cd.field == copyFieldContainer) + "\n\n")
+
+	localHint := "an existing directory gets the copy inside it"
+	containerHint := "absolute path of the file or directory to copy"
+	if cd.upload {
+		localHint = "file or directory to copy"
+		containerHint = "absolute destination, ending in / to copy into a directory"
+	}
+	content.WriteString(cd.renderPath("Local path", cd.localPath, localHint, cd.field == copyFieldLocal, innerWidth))
+	content.WriteString(cd.renderPath("Container path", cd.containerPath, containerHint, cd.field == copyFieldContainerPath, innerWidth))
+
+	if cd.copy != nil {
+		content.WriteString("\n" + cd.renderProgress(innerWidth) + "\n")
+	}
+	if cd.err != "" {
+		content.WriteString("\n" + styles.NormalStyle.Foreground(lipgloss.Color("196")).Width(innerWidth).Render("✗ "+cd.err) + "\n")
+	}
+
+	controlsStyle := styles.NormalStyle.Foreground(lipgloss.Color("245")).Italic(true)
+	controls := "↑↓=field ←→=change Enter=copy Esc=close"
+	if cd.IsCopying() {
+		controls = "Esc=cancel the copy"
+	}
+	content.WriteString("\n" + controlsStyle.Render(controls))
+
+	return lipgloss.Place(
+		screenWidth,
+		screenHeight,
+		lipgloss.Center,
+		lipgloss.Center,
+		boxStyle.Render(content.String()),
+	)
+}
+
+func (cd *CopyDialog) renderChoice(label, value string, focused bool) string {
+	labelStyle := styles.NormalStyle.Foreground(lipgloss.Color("245")).Width(16)
+	valueStyle := styles.NormalStyle.Foreground(lipgloss.Color("252"))
+	if focused {
+		valueStyle = valueStyle.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57")).Bold(true)
+	}
+	return labelStyle.Render(label) + valueStyle.Render("◀ "+value+" ▶")
+}
+
+func (cd *CopyDialog) renderPath(label, value, hint string, focused bool, width int) string {
+	labelStyle := styles.NormalStyle.Foreground(lipgloss.Color("245")).Width(16)
+	valueStyle := styles.NormalStyle.Foreground(lipgloss.Color("252"))
+	if focused {
+		labelStyle = labelStyle.Foreground(lipgloss.Color("229")).Bold(true)
+		if !cd.IsCopying() {
+			value += "█"
+		}
+	}
+	// Keep the end of long paths visible, that is where the typing happens
+	if excess := len(value) - (width - 16); excess > 0 {
+		value = "…" + value[excess+1:]
+	}
+	hintStyle := styles.NormalStyle.Foreground(lipgloss.Color("240"))
+	return labelStyle.Render(label) + valueStyle.Render(value) + "\n" +
+		strings.Repeat(" ", 16) + hintStyle.Render(hint) + "\n"
+}
+
+// renderProgress shows how far the copy is, with a bar when the size is known up front
+func (cd *CopyDialog) renderProgress(width int) string {
+	progress := cd.copy.Progress()
+	if cd.result != nil {
+		progress = cd.result.Progress
+	}
+
+	countStyle := styles.NormalStyle.Foreground(lipgloss.Color("245"))
+	var lines []string
+	if progress.Total > 0 {
+		filled := int(int64(width) * min64(progress.Bytes, progress.Total) / progress.Total)
+		lines = append(lines, styles.NormalStyle.Foreground(lipgloss.Color("46")).Render(strings.Repeat("█", filled))+
+			styles.NormalStyle.Foreground(lipgloss.Color("240")).Render(strings.Repeat("░", width-filled)))
+		lines = append(lines, countStyle.Render(fmt.Sprintf("%s of %s • %d files",
+			formatTransferSize(progress.Bytes), formatTransferSize(progress.Total), progress.Files)))
+	} else {
+		lines = append(lines, countStyle.Render(fmt.Sprintf("%s • %d files",
+			formatTransferSize(progress.Bytes), progress.Files)))
+	}
+	if progress.Skipped > 0 {
+		lines = append(lines, styles.NormalStyle.Foreground(lipgloss.Color("214")).Render(
+			fmt.Sprintf("⚠ Skipped %d links and special files", progress.Skipped)))
+	}
+
+	switch result := cd.result; {
+	case result == nil:
+		lines = append(lines, styles.NormalStyle.Foreground(lipgloss.Color("226")).Render(
+			truncateString("⏳ "+progress.File, width)))
+	case result.Cancelled:
+		lines = append(lines, styles.NormalStyle.Foreground(lipgloss.Color("214")).Render("Cancelled, files copied so far were kept"))
+	case errors.Is(result.Err, k8s.ErrNoTar):
+		lines = append(lines, styles.NormalStyle.Foreground(lipgloss.Color("196")).Width(width).Render(
+			"✗ "+result.Err.Error()+". Distroless images usually lack tar: add a debug container (D) "+
+				"targeting this one and copy through it from /proc/1/root."))
+	case result.Err != nil:
+		lines = append(lines, styles.NormalStyle.Foreground(lipgloss.Color("196")).Width(width).Render("✗ "+result.Err.Error()))
+	default:
+		lines = append(lines, styles.NormalStyle.Foreground(lipgloss.Color("46")).Render(
+			truncateString(fmt.Sprintf("✓ Copied %s to %s", result.From, result.To), width)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// formatTransferSize renders a byte count with one decimal in the largest fitting unit
+func formatTransferSize(size int64) string {
+	switch {
+	case size >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(size)/(1<<30))
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", size)
+}
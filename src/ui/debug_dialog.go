@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"peek/src/k8s"
+	"peek/src/styles"
+)
+
+// customDebugImage is the image choice that lets the user type any image
+const customDebugImage = "custom"
+
+// DebugDialog asks which image an ephemeral debug container runs and which container it shares
+// the process namespace with, then shows progress while the container starts
+type DebugDialog struct {
+	isOpen      bool
+	pod         k8s.PodInfo
+	images      []string
+	imageIndex  int
+	customImage string
+	targets     []string // "" is no target
+	targetIndex int
+	field       int // 0 is the image, 1 the target
+	starting    string
+	err         string
+	width       int
+}
+
+func NewDebugDialog() *DebugDialog {
+	return &DebugDialog{
+		images: []string{customDebugImage},
+		width:  74,
+	}
+}
+
+// SetImages sets the images offered, the first is preselected; a custom image can always be typed
+func (dd *DebugDialog) SetImages(images []string) {
+	dd.images = append(append([]string(nil), images...), customDebugImage)
+}
+
+// Open asks how to debug a pod, targeting its first app container
+func (dd *DebugDialog) Open(pod k8s.PodInfo) {
+	dd.isOpen = true
+	dd.pod = pod
+	dd.imageIndex = 0
+	dd.field = 0
+	dd.starting = ""
+	dd.err = ""
+	dd.targets = []string{""}
+	dd.targetIndex = 0
+	for _, container := range pod.Containers {
+		if container.Type == k8s.ContainerTypeApp {
+			dd.targets = append(dd.targets, container.Name)
+		}
+	}
+	if len(dd.targets) > 1 {
+		dd.targetIndex = 1
+	}
+}
+
+func (dd *DebugDialog) Close() {
+	dd.isOpen = false
+}
+
+func (dd *DebugDialog) IsOpen() bool {
+	return dd.isOpen
+}
+
+func (dd *DebugDialog) GetPod() k8s.PodInfo {
+	return dd.pod
+}
+
+// GetImage returns the chosen image, empty when a custom image has not been typed yet
+func (dd *DebugDialog) GetImage() string {
+	if dd.images[dd.imageIndex] == customDebugImage {
+		return strings.TrimSpace(dd.customImage)
+	}
+	return dd.images[dd.imageIndex]
+}
+
+// GetTarget returns the container whose process namespace is shared, empty for none
+func (dd *DebugDialog) GetTarget() string {
+	return dd.targets[dd.targetIndex]
+}
+
+// SetStarting shows that the debug container was added and is starting
+func (dd *DebugDialog) SetStarting(containerName string) {
+	dd.starting = containerName
+	dd.err = ""
+}
+
+// IsStarting reports whether a debug container is on its way; the choices are locked meanwhile
+func (dd *DebugDialog) IsStarting() bool {
+	return dd.starting != ""
+}
+
+// SetError shows why the debug container could not be added or started
+func (dd *DebugDialog) SetError(err error) {
+	dd.starting = ""
+	dd.err = err.Error()
+}
+
+// SetErrorText shows a problem with the choices
+func (dd *DebugDialog) SetErrorText(text string) {
+	dd.err = text
+}
+
+func (dd *DebugDialog) MoveUp() {
+	if dd.field > 0 {
+		dd.field--
+	}
+}
+
+func (dd *DebugDialog) MoveDown() {
+	if dd.field < 1 {
+		dd.field++
+	}
+}
+
+// Left chooses the previous option of the focused field
+func (dd *DebugDialog) Left() {
+	if dd.IsStarting() {
+		return
+	}
+	if dd.field == 0 {
+		dd.imageIndex = (dd.imageIndex + len(dd.images) - 1) % len(dd.images)
+	} else {
+		dd.targetIndex = (dd.targetIndex + len(dd.targets) - 1) % len(dd.targets)
+	}
+}
+
+// Right chooses the next option of the focused field
+func (dd *DebugDialog) Right() {
+	if dd.IsStarting() {
+		return
+	}
+	if dd.field == 0 {
+		dd.imageIndex = (dd.imageIndex + 1) % len(dd.images)
+	} else {
+		dd.targetIndex = (dd.targetIndex + 1) % len(dd.targets)
+	}
+}
+
+// AddChar types into the custom image
+func (dd *DebugDialog) AddChar(char string) {
+	if dd.IsStarting() || dd.field != 0 || dd.images[dd.imageIndex] != customDebugImage {
+		return
+	}
+	if len(char) == 1 && char[0] > ' ' && char[0] < 0x7f {
+		dd.customImage += char
+	}
+}
+
+func (dd *DebugDialog) Backspace() {
+	if dd.IsStarting() || dd.field != 0 || dd.images[dd.imageIndex] != customDebugImage {
+		return
+	}
+	if len(dd.customImage) > 0 {
+		dd.customImage = dd.customImage[:len(dd.customImage)-1]
+	}
+}
+
+func (dd *DebugDialog) Render(screenWidth, screenHeight int) string {
+	if !dd.isOpen {
+		return ""
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("39")).
+		Background(lipgloss.Color("235")).
+		Padding(1, 2).
+		Width(dd.width - 4)
+
+	var content strings.Builder
+
+	titleStyle := styles.NormalStyle.Bold(true).Foreground(lipgloss.Color("39"))
+	content.WriteString(titleStyle.Render(fmt.Sprintf("🐞 Debug Pod: %s/%s", dd.pod.Namespace, dd.pod.Name)))
+	content.WriteString("\n\n")
+
+	noteStyle := styles.NormalStyle.Foreground(lipgloss.Color("245"))
+	content.WriteString(noteStyle.Render("Adds an ephemeral container to the pod and attaches to it.\n" +
+		"It cannot be removed and stays until the pod is deleted."))
+	content.WriteString("\n\n")
+
+	image := dd.images[dd.imageIndex]
+	if image == customDebugImage {
+		image = "custom: " + dd.customImage
+		if dd.field == 0 && !dd.IsStarting() {
+			image += "█"
+		}
+	}
+	target := dd.GetTarget()
+	targetHint := "shares the process namespace of the container"
+	if target == "" {
+		target = "none"
+		targetHint = "only sees its own processes"
+	}
+	content.WriteString(dd.renderField("Image", image, "", dd.field == 0) + "\n")
+	content.WriteString(dd.renderField("Target", target, targetHint, dd.field == 1) + "\n")
+
+	if dd.starting != "" {
+		content.WriteString("\n")
+		content.WriteString(styles.NormalStyle.Foreground(lipgloss.Color("226")).Render(
+			fmt.Sprintf("⏳ Starting %s (%s)…", dd.starting, dd.GetImage())))
+		content.WriteString("\n")
+	}
+	if dd.err != "" {
+		content.WriteString("\n")
+		content.WriteString(styles.NormalStyle.Foreground(lipgloss.Color("196")).Width(dd.width - 8).Render("✗ " + dd.err))
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	controlsStyle := styles.NormalStyle.Foreground(lipgloss.Color("245")).Italic(true)
+	controls := "↑↓=field ←→=change Enter=start and attach Esc=cancel"
+	if dd.IsStarting() {
+		controls = "Attaches once the container is running • Esc to stop waiting"
+	}
+	content.WriteString(controlsStyle.Render(controls))
+
+	return lipgloss.Place(
+		screenWidth,
+		screenHeight,
+		lipgloss.Center,
+		lipgloss.Center,
+		boxStyle.Render(content.String()),
+	)
+}
+
+// renderField draws one choice as "Label  ◀ value ▶  hint", highlighted when focused
+func (dd *DebugDialog) renderField(label, value, hint string, focused bool) string {
+	labelStyle := styles.NormalStyle.Foreground(lipgloss.Color("245")).Width(8)
+	valueStyle := styles.NormalStyle.Foreground(lipgloss.Color("252"))
+	if focused {
+		valueStyle = valueStyle.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57")).Bold(true)
+	}
+	line := labelStyle.Render(label) + valueStyle.Render("◀ "+truncateString(value, 40)+" ▶")
+	if hint != "" {
+		line += "  " + styles.NormalStyle.Foreground(lipgloss.Color("240")).Render(hint)
+	}
+	return line
+}
@@ -6,135 +6,477 @@ import (
 	"strings"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"peek/src/k8s"
 	"peek/src/styles"
 )
 
+// archiveQueryInterval is how often the on-disk archive is re-read while the view is open
+const archiveQueryInterval = 30 * time.Second
+
+// eventRangeLayouts are the accepted formats for absolute timeframe bounds, in local time
+var eventRangeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// EventsQueriedMsg delivers events read from an archive to the events table
+type EventsQueriedMsg struct {
+	archive *k8s.EventArchive
+	run     int
+	events  []k8s.EventInfo
+	err     error
+}
+
 type EventsTable struct {
-	events       []k8s.EventInfo
-	lastUpdate   time.Time
-	kubeConfig   *k8s.KubeConfig
-	contextName  string
-	timeframeMin int
-	isLoading    bool
-	error        error
+	tableData
+	events    []k8s.EventInfo
+	watcher   *k8s.EventWatcher
+	version   uint64
+	timeframe time.Duration
+	// rangeFrom and rangeTo select an absolute range instead of the relative timeframe;
+	// a zero rangeTo means "until now"
+	rangeFrom       time.Time
+	rangeTo         time.Time
+	archive         *k8s.EventArchive
+	archived        []k8s.EventInfo
+	archiveQuery    time.Time
+	archiveErr      error
+	archiveRuns     int // queries started, so results for an earlier range are dropped
+	archiveQuerying bool
+	archiveLoaded   bool
+	// filter is the applied filter; filterInput is the query being edited in the filter bar
+	filter        *k8s.EventFilter
+	filterEditing bool
+	filterInput   string
+	filterErr     error
+	totalEvents   int
+	// grouped collapses events by involved object and reason; expanded holds the open group keys
+	grouped  bool
+	groups   []k8s.EventGroup
+	expanded map[string]bool
+	rows     []eventRow
+	table    *Table
 }
 
-func NewEventsTable(kubeConfig *k8s.KubeConfig, contextName string) *EventsTable {
+// eventRow is one line of the table: an event, a group, or an occurrence inside an expanded group
+type eventRow struct {
+	event *k8s.EventInfo
+	group *k8s.EventGroup
+	child bool
+}
+
+// key identifies a row across refreshes so the cursor stays on it
+func (r eventRow) key() string {
+	if r.group != nil {
+		return "group|" + r.group.Key
+	}
+	key := fmt.Sprintf("%s|%s|%s|%s|%s", r.event.Namespace, r.event.Object, r.event.Type, r.event.Reason, r.event.Message)
+	if r.child {
+		return "child|" + key
+	}
+	return key
+}
+
+func NewEventsTable(watcher *k8s.EventWatcher) *EventsTable {
 	return &EventsTable{
-		kubeConfig:   kubeConfig,
-		contextName:  contextName,
-		timeframeMin: 10, // Default to 10 minutes
-		isLoading:    true,
+		watcher:   watcher,
+		timeframe: 10 * time.Minute, // Default to 10 minutes
+		expanded:  make(map[string]bool),
+		tableData: tableData{isLoading: true},
+		table:     NewTable("rows", eventColumns(false)),
 	}
 }
 
-func (et *EventsTable) SetTimeframe(minutes int) {
-	if minutes > 0 {
-		et.timeframeMin = minutes
-		// Force refresh on next update check
-		et.lastUpdate = time.Time{}
-		// Clear events to trigger loading state for timeframe change
-		et.events = []k8s.EventInfo{}
+// eventColumns lays out the table for single events, or for groups and their occurrences
+func eventColumns(grouped bool) []Column {
+	if grouped {
+		return []Column{
+			{Title: "", Width: 1},
+			{Title: "TYPE", Width: 4, MaxWidth: 8},
+			{Title: "REASON", Width: 12, MaxWidth: 30, Flex: 1},
+			{Title: "OBJECT", Width: 15, Flex: 2},
+			{Title: "LATEST MESSAGE", Width: 20, Flex: 4},
+			{Title: "COUNT", Width: 5},
+			{Title: "NAMESPACE", Width: 9, MaxWidth: 30, Flex: 1},
+			{Title: "FIRST", Width: 5},
+			{Title: "LAST", Width: 4},
+		}
+	}
+	return []Column{
+		{Title: "TYPE", Width: 4, MaxWidth: 8},
+		{Title: "REASON", Width: 12, MaxWidth: 30, Flex: 1},
+		{Title: "OBJECT", Width: 15, Flex: 2},
+		{Title: "MESSAGE", Width: 20, Flex: 4},
+		{Title: "COUNT", Width: 5},
+		{Title: "NAMESPACE", Width: 9, MaxWidth: 30, Flex: 1},
+		{Title: "AGE", Width: 3},
 	}
 }
 
-func (et *EventsTable) GetTimeframe() int {
-	return et.timeframeMin
+// SetTimeframe shows events from the given period up to now
+func (et *EventsTable) SetTimeframe(timeframe time.Duration) {
+	if timeframe > 0 {
+		et.timeframe = timeframe
+		et.rangeFrom = time.Time{}
+		et.rangeTo = time.Time{}
+		et.invalidate()
+	}
 }
 
-func (et *EventsTable) Update() error {
-	if et.kubeConfig == nil {
-		return fmt.Errorf("kubeconfig not available")
+// SetRange shows events last seen between from and to; a zero to means "until now"
+func (et *EventsTable) SetRange(from, to time.Time) {
+	et.rangeFrom = from
+	et.rangeTo = to
+	et.invalidate()
+}
+
+// SetArchive sets the on-disk archive used for ranges older than the watcher's buffer, or nil to disable it
+func (et *EventsTable) SetArchive(archive *k8s.EventArchive) {
+	et.archive = archive
+	et.archived = nil
+	et.archiveErr = nil
+	et.invalidate()
+}
+
+// SetFilter sets the applied filter, or nil to show every event
+func (et *EventsTable) SetFilter(filter *k8s.EventFilter) {
+	et.filter = filter
+	et.lastUpdate = time.Time{}
+}
+
+// GetFilter returns the applied filter
+func (et *EventsTable) GetFilter() *k8s.EventFilter {
+	return et.filter
+}
+
+// StartFilterEditing opens the filter bar with the applied query
+func (et *EventsTable) StartFilterEditing() {
+	et.filterEditing = true
+	et.filterInput = et.filter.String()
+	et.filterErr = nil
+}
+
+// StopFilterEditing closes the filter bar
+func (et *EventsTable) StopFilterEditing() {
+	et.filterEditing = false
+	et.filterErr = nil
+}
+
+func (et *EventsTable) IsFilterEditing() bool {
+	return et.filterEditing
+}
+
+func (et *EventsTable) GetFilterInput() string {
+	return et.filterInput
+}
+
+// GetFilterError returns why the query being edited is invalid, or nil
+func (et *EventsTable) GetFilterError() error {
+	return et.filterErr
+}
+
+// UpdateFilterInput changes the query being edited and validates it as the user types
+func (et *EventsTable) UpdateFilterInput(input string) {
+	et.filterInput = input
+	_, et.filterErr = k8s.ParseEventFilter(input)
+}
+
+func (et *EventsTable) invalidate() {
+	// Force refresh on next update check
+	et.lastUpdate = time.Time{}
+	et.archiveQuery = time.Time{}
+	et.archiveRuns++
+	et.archiveQuerying = false
+	et.archiveLoaded = false
+	et.archived = nil
+	// Clear events to trigger loading state for timeframe change
+	et.events = []k8s.EventInfo{}
+}
+
+// bounds returns the selected time range
+func (et *EventsTable) bounds() (from, to time.Time) {
+	now := time.Now()
+	if et.rangeFrom.IsZero() {
+		return now.Add(-et.timeframe), now
 	}
+	to = et.rangeTo
+	if to.IsZero() {
+		to = now
+	}
+	return et.rangeFrom, to
+}
 
-	// Only set loading to true if this is the first load (no existing events)
-	if len(et.events) == 0 {
-		et.isLoading = true
+// TimeframeDescription describes the selected time range
+func (et *EventsTable) TimeframeDescription() string {
+	if et.rangeFrom.IsZero() {
+		return "the past " + formatTimeframe(et.timeframe)
 	}
-	et.error = nil
+	if et.rangeTo.IsZero() {
+		return et.rangeFrom.Format("2006-01-02 15:04") + " until now"
+	}
+	return et.rangeFrom.Format("2006-01-02 15:04") + " to " + et.rangeTo.Format("2006-01-02 15:04")
+}
 
-	events, err := et.kubeConfig.GetEvents(et.contextName, et.timeframeMin)
-	if err != nil {
-		et.error = err
-		et.isLoading = false
-		return err
+// maxTimeframe is how far back events can be shown with the current sources
+func (et *EventsTable) maxTimeframe() time.Duration {
+	if et.archive != nil {
+		return et.archive.Retention()
+	}
+	return et.watcher.Retention()
+}
+
+func (et *EventsTable) Update() error {
+	if et.watcher == nil {
+		return fmt.Errorf("event watcher not available")
 	}
 
-	et.events = events
+	// Events come from the watcher's local buffer, so this never hits the API server
+	et.version = et.watcher.Version()
+	et.error = et.watcher.Err()
+	et.isLoading = !et.watcher.Synced() || (et.archive != nil && !et.archiveLoaded)
+
+	from, to := et.bounds()
+	var live []k8s.EventInfo
+	for _, event := range et.watcher.Events(from) {
+		if !event.LastSeen().After(to) {
+			live = append(live, event)
+		}
+	}
+
+	events := live
+	if et.archive != nil {
+		// The last query may have been for a slightly earlier window
+		var archived []k8s.EventInfo
+		for _, event := range et.archived {
+			if last := event.LastSeen(); !last.Before(from) && !last.After(to) {
+				archived = append(archived, event)
+			}
+		}
+		events = k8s.MergeEvents(live, archived)
+	}
+	et.totalEvents = len(events)
+	et.events = et.filter.Apply(events)
+	et.buildRows()
 	et.lastUpdate = time.Now()
-	et.isLoading = false
 	return nil
 }
 
-func (et *EventsTable) ShouldUpdate() bool {
-	// Update every 15 seconds for events (more frequent than other resources)
-	return time.Since(et.lastUpdate) > 15*time.Second
+// QueryArchive returns a command that reads the selected range from the on-disk archive, so the
+// disk is never read while rendering. The archive holds what the API server has already expired;
+// it is re-read periodically so events recorded while the view is open show up for absolute ranges
+// too. It is called on every refresh tick and returns nil when no query is due.
+func (et *EventsTable) QueryArchive() tea.Cmd {
+	if et.archive == nil || et.archiveQuerying || time.Since(et.archiveQuery) < archiveQueryInterval {
+		return nil
+	}
+
+	from, to := et.bounds()
+	et.archiveRuns++
+	et.archiveQuerying = true
+	et.archiveQuery = time.Now()
+	run, archive := et.archiveRuns, et.archive
+	return func() tea.Msg {
+		events, err := archive.Query(from, to)
+		return EventsQueriedMsg{archive: archive, run: run, events: events, err: err}
+	}
 }
 
-func (et *EventsTable) Render() string {
-	var b strings.Builder
+// FinishArchiveQuery shows the archived events of a query, unless the archive or range changed since
+func (et *EventsTable) FinishArchiveQuery(msg EventsQueriedMsg) {
+	if msg.archive != et.archive || msg.run != et.archiveRuns {
+		return
+	}
+	et.archiveQuerying = false
+	et.archiveLoaded = true
+	et.archived, et.archiveErr = msg.events, msg.err
+	// Merge them on the next render
+	et.lastUpdate = time.Time{}
+}
 
-	// Only show loading screen if we have no events AND it's the initial load
-	if et.isLoading && len(et.events) == 0 && et.lastUpdate.IsZero() {
-		b.WriteString(styles.NormalStyle.Render("Loading events..."))
-		return b.String()
+// buildRows lays out the events (or groups and their expanded occurrences) as table rows
+func (et *EventsTable) buildRows() {
+	var rows []eventRow
+	if et.grouped {
+		et.groups = k8s.GroupEvents(et.events)
+		for i := range et.groups {
+			group := &et.groups[i]
+			rows = append(rows, eventRow{group: group})
+			if et.expanded[group.Key] {
+				for j := range group.Events {
+					rows = append(rows, eventRow{event: &group.Events[j], child: true})
+				}
+			}
+		}
+	} else {
+		et.groups = nil
+		for i := range et.events {
+			rows = append(rows, eventRow{event: &et.events[i]})
+		}
 	}
+	et.rows = rows
+	et.table.SetColumns(eventColumns(et.grouped))
+	et.table.SetRows(et.tableRows())
+}
 
-	if et.error != nil {
-		errorStyle := styles.NormalStyle.Foreground(lipgloss.Color("196"))
-		b.WriteString(errorStyle.Render(fmt.Sprintf("Error loading events: %v", et.error)))
-		return b.String()
+// selectedRow returns the row under the cursor, nil when there are no rows. The table sorts its
+// rows, so the row is found by key rather than by the cursor position.
+func (et *EventsTable) selectedRow() *eventRow {
+	key := et.table.SelectedKey()
+	if key == "" {
+		return nil
 	}
+	for i := range et.rows {
+		if et.rows[i].key() == key {
+			return &et.rows[i]
+		}
+	}
+	return nil
+}
+
+func (et *EventsTable) MoveUp() {
+	et.table.MoveUp()
+}
+
+func (et *EventsTable) MoveDown() {
+	et.table.MoveDown()
+}
+
+// ToggleGrouped switches between individual events and groups by object and reason
+func (et *EventsTable) ToggleGrouped() bool {
+	et.grouped = !et.grouped
+	et.buildRows()
+	et.table.SetCursor(0)
+	return et.grouped
+}
+
+func (et *EventsTable) IsGrouped() bool {
+	return et.grouped
+}
+
+// ToggleExpand opens or closes the group under the cursor and reports whether the cursor was on a group
+func (et *EventsTable) ToggleExpand() bool {
+	row := et.selectedRow()
+	if !et.grouped || row == nil || row.group == nil {
+		return false
+	}
+
+	key := row.group.Key
+	et.expanded[key] = !et.expanded[key]
+	et.buildRows()
+	return true
+}
+
+// GetSelectedEvent returns the event under the cursor; for a group it is the latest occurrence
+func (et *EventsTable) GetSelectedEvent() *k8s.EventInfo {
+	row := et.selectedRow()
+	if row == nil {
+		return nil
+	}
+
+	if row.group != nil {
+		return &row.group.Events[0]
+	}
+	return row.event
+}
+
+func (et *EventsTable) ShouldUpdate() bool {
+	// Refresh whenever the watch delivered something new, and periodically so old events age out
+	return et.watcher != nil && (et.watcher.Version() != et.version || time.Since(et.lastUpdate) > 5*time.Second)
+}
+
+// Render draws the events view in width by height
+func (et *EventsTable) Render(width, height int) string {
+	// Buffered events stay on screen while the watch reconnects
+	if len(et.events) == 0 {
+		if state := et.renderState("events", true); state != "" {
+			return state
+		}
+	}
+
+	var b strings.Builder
 
 	// Timeframe info - show updating status more subtly
 	timeframeStyle := styles.NormalStyle.Foreground(lipgloss.Color("245"))
-	timeframeText := fmt.Sprintf("Showing events from the past %d minutes", et.timeframeMin)
-	if et.isLoading && len(et.events) > 0 {
-		// Show a subtle updating indicator only if we already have data
-		timeframeText += " ●"
-	}
+	timeframeText := "Showing events from " + et.TimeframeDescription() + et.refreshMarker(len(et.events) == 0)
 	b.WriteString(timeframeStyle.Render(timeframeText) + "\n")
 
+	b.WriteString(et.renderFilterBar())
+
+	// Keep showing buffered events while the watch reconnects
+	if et.error != nil {
+		warningStyle := styles.NormalStyle.Foreground(lipgloss.Color("214"))
+		b.WriteString(warningStyle.Render(fmt.Sprintf("⚠ Event watch interrupted, reconnecting: %v", et.error)) + "\n")
+	}
+	if et.archiveErr != nil {
+		warningStyle := styles.NormalStyle.Foreground(lipgloss.Color("214"))
+		b.WriteString(warningStyle.Render(fmt.Sprintf("⚠ Failed to read event archive: %v", et.archiveErr)) + "\n")
+	}
+
 	// Controls info
 	controlsStyle := styles.NormalStyle.Foreground(lipgloss.Color("240"))
-	b.WriteString(controlsStyle.Render("Use 't' to change timeframe • Events auto-refresh every 15s") + "\n\n")
+	archiveText := "'A' to archive events on disk"
+	if et.archive != nil {
+		archiveText = fmt.Sprintf("Archive on (%s retention), 'A' to disable", formatTimeframe(et.archive.Retention()))
+	}
+	b.WriteString(controlsStyle.Render(fmt.Sprintf("Use 't' to change timeframe • '/' to filter • 'g' to group • o/O to sort • Enter to open object • Live via %s watch • %s", et.watcher.API(), archiveText)) + "\n\n")
 
 	if len(et.events) == 0 {
-		b.WriteString(styles.NormalStyle.Render("No events found in the specified timeframe"))
+		if !et.filter.IsEmpty() && et.totalEvents > 0 {
+			b.WriteString(styles.NormalStyle.Render(fmt.Sprintf("No events match the filter (%d hidden)", et.totalEvents)))
+		} else {
+			b.WriteString(styles.NormalStyle.Render("No events found in the specified timeframe"))
+		}
 		return b.String()
 	}
 
-	// Table header
-	headerStyle := styles.NormalStyle.Bold(true).Underline(true)
-	header := fmt.Sprintf("%-8s %-12s %-15s %-20s %-8s %-15s %s",
-		"TYPE", "REASON", "OBJECT", "MESSAGE", "COUNT", "NAMESPACE", "AGE")
-	b.WriteString(headerStyle.Render(header) + "\n")
-
-	// Table rows
-	for i, event := range et.events {
-		if i >= 50 { // Limit to 50 events for performance
-			moreStyle := styles.NormalStyle.Foreground(lipgloss.Color("240"))
-			b.WriteString(moreStyle.Render(fmt.Sprintf("\n... and %d more events (adjust timeframe to see fewer)", len(et.events)-50)))
-			break
-		}
+	b.WriteString(et.table.Render(width, remainingHeight(height, b.String())))
 
-		// Truncate and format fields
-		eventType := truncateString(event.Type, 8)
-		reason := truncateString(event.Reason, 12)
-		object := truncateString(event.Object, 15)
-		message := truncateString(event.Message, 20)
-		count := truncateString(fmt.Sprintf("%d", event.Count), 8)
-		namespace := truncateString(event.Namespace, 15)
-		age := formatEventAge(event)
+	return b.String()
+}
 
-		row := fmt.Sprintf("%-8s %-12s %-15s %-20s %-8s %-15s %s",
-			eventType, reason, object, message, count, namespace, age)
+// tableRows renders the events, groups and occurrences as table rows colored by event type
+func (et *EventsTable) tableRows() []TableRow {
+	rows := make([]TableRow, 0, len(et.rows))
+	for _, row := range et.rows {
+		var cells []string
+		var values []any
+		var eventType string
+		switch {
+		case row.group != nil:
+			group := row.group
+			marker := "▸"
+			if et.expanded[group.Key] {
+				marker = "▾"
+			}
+			eventType = group.Type
+			cells = []string{marker, group.Type, group.Reason, group.Object, group.Message,
+				fmt.Sprintf("%d", group.Count), group.Namespace,
+				k8s.FormatTimeAgo(group.FirstTimestamp), k8s.FormatTimeAgo(group.LastTimestamp)}
+			values = []any{nil, k8s.EventTypeSeverity(group.Type), nil, nil, nil, group.Count, nil,
+				time.Since(group.FirstTimestamp), time.Since(group.LastTimestamp)}
+		case row.child:
+			event := row.event
+			eventType = event.Type
+			cells = []string{"", event.Type, "└", "", event.Message,
+				fmt.Sprintf("%d", event.Count), "",
+				k8s.FormatTimeAgo(event.FirstTimestamp), formatEventAge(*event)}
+		default:
+			event := row.event
+			eventType = event.Type
+			cells = []string{event.Type, event.Reason, event.Object, event.Message,
+				fmt.Sprintf("%d", event.Count), event.Namespace, formatEventAge(*event)}
+			values = []any{k8s.EventTypeSeverity(event.Type), nil, nil, nil, event.Count, nil,
+				time.Since(event.LastSeen())}
+		}
 
 		// Color based on event type
 		var rowStyle lipgloss.Style
-		switch strings.ToLower(event.Type) {
+		switch strings.ToLower(eventType) {
 		case "warning":
 			rowStyle = styles.NormalStyle.Foreground(lipgloss.Color("226")) // Yellow
 		case "error":
@@ -143,23 +485,35 @@ func (et *EventsTable) Render() string {
 			rowStyle = styles.NormalStyle.Foreground(lipgloss.Color("252")) // White/Default
 		}
 
-		b.WriteString(rowStyle.Render(row))
-		if i < len(et.events)-1 && i < 49 {
-			b.WriteString("\n")
+		rows = append(rows, TableRow{Key: row.key(), Cells: cells, Values: values, Child: row.child, Style: rowStyle})
+	}
+	return rows
+}
+
+// renderFilterBar renders the filter being edited, or the applied filter
+func (et *EventsTable) renderFilterBar() string {
+	filterStyle := styles.NormalStyle.Foreground(lipgloss.Color("39")).Bold(true)
+
+	if et.filterEditing {
+		line := filterStyle.Render(fmt.Sprintf("🔍 Filter: %s█", et.filterInput))
+		hintStyle := styles.NormalStyle.Foreground(lipgloss.Color("240"))
+		hint := "e.g. type=Warning ns=payments reason~Back.*Off kind=Pod source=kubelet text • Enter to apply • Esc to cancel"
+		if et.filterErr != nil {
+			hintStyle = styles.NormalStyle.Foreground(lipgloss.Color("196"))
+			hint = et.filterErr.Error()
 		}
+		return line + "\n" + hintStyle.Render(hint) + "\n"
 	}
 
-	return b.String()
+	if et.filter.IsEmpty() {
+		return ""
+	}
+	return filterStyle.Render(fmt.Sprintf("🔍 Filter: %s (%d of %d events)", et.filter, len(et.events), et.totalEvents)) + "\n"
 }
 
 // formatEventAge formats the age of an event
 func formatEventAge(event k8s.EventInfo) string {
-	// Use LastTimestamp if available, otherwise FirstTimestamp
-	eventTime := event.LastTimestamp
-	if eventTime.IsZero() {
-		eventTime = event.FirstTimestamp
-	}
-
+	eventTime := event.LastSeen()
 	if eventTime.IsZero() {
 		return "unknown"
 	}
@@ -181,21 +535,99 @@ func formatEventAge(event k8s.EventInfo) string {
 	}
 }
 
-// HandleTimeframeInput processes user input for timeframe changes
+// HandleTimeframeInput processes user input for timeframe changes. It accepts minutes ("30"),
+// a duration ("90m", "6h", "3d"), or an absolute range ("2026-10-17 14:00..2026-10-17 16:00" or
+// "2026-10-17 14:00..16:00", with the end optional to mean "until now")
 func (et *EventsTable) HandleTimeframeInput(input string) error {
-	minutes, err := strconv.Atoi(input)
+	input = strings.TrimSpace(input)
+
+	if fromText, toText, isRange := strings.Cut(input, ".."); isRange {
+		from, err := parseEventTime(fromText)
+		if err != nil {
+			return err
+		}
+		var to time.Time
+		if toText = strings.TrimSpace(toText); toText != "" {
+			// A bare time ends the range on the day it started
+			if clock, clockErr := time.ParseInLocation("15:04", toText, time.Local); clockErr == nil {
+				to = time.Date(from.Year(), from.Month(), from.Day(), clock.Hour(), clock.Minute(), 0, 0, time.Local)
+			} else if to, err = parseEventTime(toText); err != nil {
+				return err
+			}
+			if !to.After(from) {
+				return fmt.Errorf("range end must be after its start")
+			}
+		}
+		if time.Since(from) > et.maxTimeframe() {
+			return et.timeframeLimitError()
+		}
+		et.SetRange(from, to)
+		return nil
+	}
+
+	timeframe, err := parseTimeframe(input)
 	if err != nil {
-		return fmt.Errorf("invalid timeframe: must be a number in minutes")
+		return err
+	}
+	if timeframe > et.maxTimeframe() {
+		return et.timeframeLimitError()
 	}
 
-	if minutes <= 0 {
-		return fmt.Errorf("timeframe must be greater than 0 minutes")
+	et.SetTimeframe(timeframe)
+	return nil
+}
+
+func (et *EventsTable) timeframeLimitError() error {
+	if et.archive == nil {
+		return fmt.Errorf("events are only buffered for %s, press 'A' to archive them on disk", formatTimeframe(et.maxTimeframe()))
 	}
+	return fmt.Errorf("timeframe cannot exceed the archive retention of %s", formatTimeframe(et.maxTimeframe()))
+}
 
-	if minutes > 1440 { // 24 hours
-		return fmt.Errorf("timeframe cannot exceed 1440 minutes (24 hours)")
+// parseTimeframe parses minutes or a duration with an m, h or d suffix
+func parseTimeframe(input string) (time.Duration, error) {
+	if minutes, err := strconv.Atoi(input); err == nil {
+		if minutes <= 0 {
+			return 0, fmt.Errorf("timeframe must be greater than 0 minutes")
+		}
+		return time.Duration(minutes) * time.Minute, nil
 	}
 
-	et.SetTimeframe(minutes)
-	return nil
+	// time.ParseDuration has no day unit
+	if days, ok := strings.CutSuffix(input, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid timeframe: %q", input)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	timeframe, err := time.ParseDuration(input)
+	if err != nil || timeframe <= 0 {
+		return 0, fmt.Errorf("invalid timeframe: use minutes, a duration like 6h or 3d, or a range like 2026-10-17 14:00..16:00")
+	}
+	return timeframe, nil
+}
+
+// parseEventTime parses an absolute range bound in local time
+func parseEventTime(input string) (time.Time, error) {
+	input = strings.TrimSpace(input)
+	for _, layout := range eventRangeLayouts {
+		if t, err := time.ParseInLocation(layout, input, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q: use YYYY-MM-DD HH:MM", input)
+}
+
+// formatTimeframe formats a duration using the largest whole unit
+func formatTimeframe(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour && d%(24*time.Hour) == 0:
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	case d >= time.Hour && d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	default:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
 }
@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"peek/src/k8s"
+)
+
+func TestSelectedEventFollowsSortedRows(t *testing.T) {
+	now := time.Now()
+	et := NewEventsTable(nil)
+	et.events = []k8s.EventInfo{
+		{Type: "Normal", Reason: "Scheduled", Object: "Pod/web-0", Namespace: "default", LastTimestamp: now},
+		{Type: "Warning", Reason: "BackOff", Object: "Pod/web-1", Namespace: "default", LastTimestamp: now},
+		{Type: "Normal", Reason: "Pulled", Object: "Pod/web-2", Namespace: "default", LastTimestamp: now},
+	}
+	et.buildRows()
+	et.table.SetSortOrder("REASON", false)
+	et.table.SetCursor(0)
+
+	for _, want := range []string{"BackOff", "Pulled", "Scheduled"} {
+		event := et.GetSelectedEvent()
+		if event == nil {
+			t.Fatalf("no event selected, want %s", want)
+		}
+		if event.Reason != want {
+			t.Errorf("selected event %s, want the highlighted %s", event.Reason, want)
+		}
+		et.MoveDown()
+	}
+}
+
+func TestArchiveQueryForEarlierRangeIsDropped(t *testing.T) {
+	archive := &k8s.EventArchive{}
+	et := NewEventsTable(nil)
+	et.SetArchive(archive)
+	et.archiveQuerying = true
+	et.archiveRuns++
+	stale := EventsQueriedMsg{archive: archive, run: et.archiveRuns, events: []k8s.EventInfo{{Reason: "Old"}}}
+
+	et.SetTimeframe(time.Hour)
+	et.FinishArchiveQuery(stale)
+	if et.archiveLoaded || len(et.archived) != 0 {
+		t.Fatalf("kept %d events queried for the previous range", len(et.archived))
+	}
+
+	et.archiveRuns++
+	et.FinishArchiveQuery(EventsQueriedMsg{archive: archive, run: et.archiveRuns, events: []k8s.EventInfo{{Reason: "New"}}})
+	if !et.archiveLoaded || len(et.archived) != 1 || et.archived[0].Reason != "New" {
+		t.Errorf("archived %v, want the event of the current query", et.archived)
+	}
+}
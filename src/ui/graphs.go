@@ -65,6 +65,48 @@ func CreateUsageBar(used, total int64, width int, label string, color string) st
 		percentStyle.Render(fmt.Sprintf("%.1f%% (%d/%d)", percentage, used, total)))
 }
 
+// CreateResourceBar creates a usage bar like CreateUsageBar but with a caller-formatted detail
+// string, for values such as bytes or millicores that read poorly as raw numbers
+func CreateResourceBar(used, total int64, width int, label, detail string, color string) string {
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252")).Width(12)
+	percentStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+
+	if total == 0 {
+		return fmt.Sprintf("%s │%s│ %s", labelStyle.Render(label), strings.Repeat("─", width), percentStyle.Render("0% "+detail))
+	}
+
+	percentage := float64(used) / float64(total) * 100
+	filledWidth := int(float64(width) * percentage / 100)
+	if filledWidth > width {
+		filledWidth = width
+	}
+
+	// Create the bar
+	filled := strings.Repeat("█", filledWidth)
+	empty := strings.Repeat("─", width-filledWidth)
+	bar := filled + empty
+
+	// Style the bar based on usage level
+	var barColor string
+	switch {
+	case percentage >= 90:
+		barColor = "196" // Red
+	case percentage >= 75:
+		barColor = "214" // Orange
+	case percentage >= 50:
+		barColor = "226" // Yellow
+	default:
+		barColor = color // Default color (usually green)
+	}
+
+	barStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(barColor))
+
+	return fmt.Sprintf("%s │%s│ %s",
+		labelStyle.Render(label),
+		barStyle.Render(bar),
+		percentStyle.Render(fmt.Sprintf("%.1f%% (%s)", percentage, detail)))
+}
+
 // CreateSimpleChart creates a simple horizontal bar chart
 func CreateSimpleChart(data []ChartData, width int) string {
 	if len(data) == 0 {
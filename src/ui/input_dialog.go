@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"peek/src/styles"
+)
+
+// InputDialog asks for a single line of text. The action tells the caller what the input is for
+// when it is confirmed.
+type InputDialog struct {
+	isOpen      bool
+	action      string
+	title       string
+	placeholder string
+	hint        string
+	input       string
+	width       int
+}
+
+func NewInputDialog() *InputDialog {
+	return &InputDialog{
+		width: 70,
+	}
+}
+
+// Open shows the dialog for an action, prefilled with an initial value
+func (id *InputDialog) Open(action, title, placeholder, hint, initial string) {
+	id.isOpen = true
+	id.action = action
+	id.title = title
+	id.placeholder = placeholder
+	id.hint = hint
+	id.input = initial
+}
+
+func (id *InputDialog) Close() {
+	id.isOpen = false
+	id.input = ""
+}
+
+func (id *InputDialog) IsOpen() bool {
+	return id.isOpen
+}
+
+func (id *InputDialog) GetAction() string {
+	return id.action
+}
+
+func (id *InputDialog) GetInput() string {
+	return strings.TrimSpace(id.input)
+}
+
+func (id *InputDialog) AddChar(char string) {
+	if len(char) == 1 && char[0] >= ' ' && char[0] < 0x7f {
+		id.input += char
+	}
+}
+
+func (id *InputDialog) Backspace() {
+	if len(id.input) > 0 {
+		id.input = id.input[:len(id.input)-1]
+	}
+}
+
+func (id *InputDialog) Render(screenWidth, screenHeight int) string {
+	if !id.isOpen {
+		return ""
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("39")).
+		Background(lipgloss.Color("235")).
+		Padding(1, 2).
+		Width(id.width - 4)
+
+	var content strings.Builder
+
+	titleStyle := styles.NormalStyle.
+		Bold(true).
+		Foreground(lipgloss.Color("39"))
+	content.WriteString(titleStyle.Render(id.title))
+	content.WriteString("\n\n")
+
+	inputFieldStyle := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder(), false, false, true, false).
+		BorderForeground(lipgloss.Color("240")).
+		Width(id.width-8).
+		Padding(0, 1)
+
+	displayText := id.input
+	if displayText == "" {
+		placeholderStyle := styles.NormalStyle.Foreground(lipgloss.Color("240"))
+		displayText = placeholderStyle.Render(id.placeholder)
+	} else {
+		displayText = styles.NormalStyle.Render(displayText + "█")
+	}
+
+	content.WriteString(inputFieldStyle.Render(displayText))
+	content.WriteString("\n")
+
+	if id.hint != "" {
+		hintStyle := styles.NormalStyle.Foreground(lipgloss.Color("240"))
+		content.WriteString(hintStyle.Render(id.hint))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+
+	instructStyle := styles.NormalStyle.
+		Foreground(lipgloss.Color("245")).
+		Italic(true)
+	content.WriteString(instructStyle.Render("Press Enter to confirm • Esc to cancel"))
+
+	return lipgloss.Place(
+		screenWidth,
+		screenHeight,
+		lipgloss.Center,
+		lipgloss.Center,
+		boxStyle.Render(content.String()),
+	)
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"slices"
 	"strings"
 
 	"peek/src/models"
@@ -210,6 +211,35 @@ func (lp *LeftPane) Render() string {
 	return b.String()
 }
 
+// SelectItem selects a navigation item by name (e.g. "Nodes" or "Pods"), expanding its folder
+// and moving the cursor onto it. It reports whether the item exists.
+func (lp *LeftPane) SelectItem(name string) bool {
+	if lp.SearchMode {
+		lp.ToggleSearch()
+	}
+
+	for i := range lp.NavItems {
+		navItem := &lp.NavItems[i]
+		if navItem.Name == name && len(navItem.Items) == 0 {
+			lp.SelectedItem = navItem.Name
+		} else if slices.Contains(navItem.Items, name) {
+			navItem.Expanded = true
+			lp.SelectedItem = navItem.Name + " > " + name
+		} else {
+			continue
+		}
+
+		for j, item := range lp.GetVisibleItems() {
+			if item.Name == name {
+				lp.Cursor = j
+				break
+			}
+		}
+		return true
+	}
+	return false
+}
+
 func (lp *LeftPane) getNavItemIndex(name string) int {
 	for i, item := range lp.NavItems {
 		if item.Name == name {
@@ -1,183 +1,1140 @@
 package ui
 
 import (
-	"bufio"
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"peek/src/k8s"
+	"peek/src/settings"
 	"peek/src/styles"
 )
 
+// logSourceColors are the prefix colors of aggregated logs, picked per pod
+var logSourceColors = []string{"39", "46", "214", "170", "45", "208", "141", "118", "203", "81"}
+
+// logLine is a buffered log line; pod and container are only set for aggregated logs and seq
+// identifies the line while the buffer moves
+type logLine struct {
+	seq       uint64
+	pod       string
+	container string
+	text      string
+	notice    string
+	entry     *k8s.StructuredLog // parsed JSON or logfmt line, nil for plain text
+}
+
+// logRing keeps the most recent log lines up to a fixed capacity
+type logRing struct {
+	lines []logLine
+	start int
+	size  int
+}
+
+func newLogRing(capacity int) *logRing {
+	return &logRing{lines: make([]logLine, max(capacity, 1))}
+}
+
+// Push adds a line, returning the oldest line when it had to make room for it
+func (r *logRing) Push(line logLine) (logLine, bool) {
+	if r.size < len(r.lines) {
+		r.lines[(r.start+r.size)%len(r.lines)] = line
+		r.size++
+		return logLine{}, false
+	}
+	dropped := r.lines[r.start]
+	r.lines[r.start] = line
+	r.start = (r.start + 1) % len(r.lines)
+	return dropped, true
+}
+
+func (r *logRing) Len() int {
+	return r.size
+}
+
+// Lines returns a copy of the lines, oldest first
+func (r *logRing) Lines() []logLine {
+	lines := make([]logLine, 0, r.size)
+	for i := 0; i < r.size; i++ {
+		lines = append(lines, r.lines[(r.start+i)%len(r.lines)])
+	}
+	return lines
+}
+
+// logEvent is sent by the streaming goroutines; session tells apart the streams of successive opens
+type logEvent struct {
+	session uint64
+	line    logLine
+	loaded  bool // the stream is open, or failed to open
+	done    bool // the stream ended for good
+	err     error
+}
+
+// LogStreamMsg carries streamed log lines into the UI loop
+type LogStreamMsg struct {
+	events []logEvent
+}
+
+// logsBatchSize is the most streamed lines delivered in one message
+const logsBatchSize = 500
+
 type LogsViewer struct {
-	isOpen       bool
-	podName      string
-	namespace    string
+	isOpen        bool
+	podName       string
+	namespace     string
 	containerName string
-	kubeConfig   *k8s.KubeConfig
-	contextName  string
-	logs         []string
-	scrollOffset int
-	isFollowing  bool
-	lastUpdate   time.Time
-	error        error
-	cancel       context.CancelFunc
+	kubeConfig    *k8s.KubeConfig
+	contextName   string
+	logs          *logRing
+	scrollOffset  int
+	isFollowing   bool
+	previous      bool // showing the previous, terminated instance of the container
+	isLoading     bool
+	error         error
+	cancel        context.CancelFunc
+
+	// Streaming goroutines deliver lines on events, tagged with the session they were started for
+	events       chan logEvent
+	session      uint64
+	bufferSize   int
+	visible      []logLine
+	visibleValid bool
+
+	// Aggregated logs of every pod matching a selector, or of a chosen set of pods
+	tailer      *k8s.LogTailer
+	selector    string
+	pods        []string // "namespace/pod" keys
+	description string
+
+	// Incremental search, highlighted in the visible lines
+	search      *regexp.Regexp
+	searchQuery string
+	cursor      int // index in the visible lines of the selected line, -1 for the last line
+
+	// Regex filter; lines that do not match are hidden, or the lines that match when inverted
+	filter       *regexp.Regexp
+	filterInvert bool
+
+	// JSON and logfmt lines render as time, level, message and the extra fields
+	settings    *settings.Settings
+	structured  bool
+	fields      []string
+	fieldFilter *k8s.LogFilter
+	expanded    map[uint64]bool
+	nextSeq     uint64
+
+	// Where the logs start and how much is read, instead of the last lines
+	options k8s.LogOptions
+
+	// Display modes, remembered in the settings
+	wrap        bool
+	hOffset     int    // first column shown when lines are not wrapped
+	timestamps  string // one of the logTimestamps modes
+	levelColors bool
+
+	// Input line for the search or filter being typed
+	inputMode   string // "", "search" or "filter"
+	input       string
+	inputErr    error
+	savedSearch string
+	savedOffset int
+	savedCursor int
+	savedFollow bool
 }
 
+// logsPageLines is the number of log lines assumed to fit in the view when scrolling
+const logsPageLines = 20
+
+// Input modes of the logs viewer
+const (
+	logsInputSearch  = "search"
+	logsInputFilter  = "filter"
+	logsInputWhere   = "where"
+	logsInputFields  = "fields"
+	logsInputOptions = "options"
+	logsInputExport  = "export"
+)
+
+// Timestamp modes of the logs viewer
+const (
+	logTimestampsRaw      = "raw"      // RFC3339 as sent by the API server
+	logTimestampsLocal    = "local"    // local date and time
+	logTimestampsRelative = "relative" // age of the line
+	logTimestampsHidden   = "hidden"
+)
+
+// logTimestampModes is the order 't' cycles through the timestamp modes
+var logTimestampModes = []string{logTimestampsRaw, logTimestampsLocal, logTimestampsRelative, logTimestampsHidden}
+
+// logsPanColumns is how far left and right pan unwrapped lines
+const logsPanColumns = 10
+
+// What the logs viewer exports
+const (
+	LogExportVisible = "visible" // the buffered lines that pass the filters
+	LogExportBuffer  = "buffer"  // every buffered line
+	LogExportFull    = "full"    // all logs fetched again from the server
+)
+
+// logsInitialLines is how many lines are read before following when no start is set
+const logsInitialLines = 100
+
 func NewLogsViewer() *LogsViewer {
 	return &LogsViewer{
-		isOpen:      false,
-		logs:        []string{},
+		isOpen:       false,
+		logs:         newLogRing(settings.DefaultLogBufferLines),
+		events:       make(chan logEvent, logsBatchSize),
+		bufferSize:   settings.DefaultLogBufferLines,
+		structured:   true,
+		timestamps:   logTimestampsRaw,
+		levelColors:  true,
+		expanded:     make(map[uint64]bool),
 		scrollOffset: 0,
-		isFollowing: false,
+		isFollowing:  false,
 	}
 }
 
-func (lv *LogsViewer) Open(kubeConfig *k8s.KubeConfig, contextName, namespace, podName, containerName string) {
+func (lv *LogsViewer) Open(kubeConfig *k8s.KubeConfig, contextName, namespace, podName, containerName string, previous bool) {
 	lv.isOpen = true
 	lv.podName = podName
 	lv.namespace = namespace
 	lv.containerName = containerName
 	lv.kubeConfig = kubeConfig
 	lv.contextName = contextName
-	lv.logs = []string{}
+	lv.previous = previous
+	lv.tailer = nil
+	lv.selector = ""
+	lv.pods = nil
+	lv.description = ""
+	lv.restart()
+}
+
+// OpenAggregate tails every pod matching a label selector, interleaving their lines. The
+// description names what is tailed, e.g. the workload the selector was resolved from.
+func (lv *LogsViewer) OpenAggregate(kubeConfig *k8s.KubeConfig, contextName, namespace, selector, description string) {
+	lv.isOpen = true
+	lv.podName = ""
+	lv.containerName = ""
+	lv.namespace = namespace
+	lv.kubeConfig = kubeConfig
+	lv.contextName = contextName
+	lv.previous = false
+	lv.selector = selector
+	lv.pods = nil
+	lv.description = description
+	lv.restart()
+}
+
+// OpenPods tails a chosen set of pods, given as "namespace/pod" keys, interleaving their lines.
+// The namespace is empty when the pods are in several namespaces.
+func (lv *LogsViewer) OpenPods(kubeConfig *k8s.KubeConfig, contextName, namespace string, pods []string, description string) {
+	lv.isOpen = true
+	lv.podName = ""
+	lv.containerName = ""
+	lv.namespace = namespace
+	lv.kubeConfig = kubeConfig
+	lv.contextName = contextName
+	lv.previous = false
+	lv.selector = ""
+	lv.pods = pods
+	lv.description = description
+	lv.restart()
+}
+
+// IsAggregate reports whether the viewer tails several pods
+func (lv *LogsViewer) IsAggregate() bool {
+	return lv.selector != "" || len(lv.pods) > 0
+}
+
+// TogglePrevious switches between the logs of the current and the previous container instance
+func (lv *LogsViewer) TogglePrevious() {
+	// There is no previous instance of a whole workload
+	if lv.IsAggregate() {
+		return
+	}
+	lv.previous = !lv.previous
+	lv.restart()
+}
+
+// restart stops the current stream and fetches logs again with the current options
+func (lv *LogsViewer) restart() {
+	if lv.cancel != nil {
+		lv.cancel()
+		lv.cancel = nil
+	}
+	if lv.tailer != nil {
+		lv.tailer.Stop()
+	}
+
+	lv.session++
+	lv.logs = newLogRing(lv.bufferSize)
+	lv.visibleValid = false
 	lv.scrollOffset = 0
+	lv.cursor = -1
+	lv.expanded = make(map[uint64]bool)
 	lv.error = nil
-	lv.isFollowing = true
+	lv.isFollowing = !lv.previous
+	lv.isLoading = true
 
 	// Start fetching logs
-	go lv.fetchLogs()
+	ctx, cancel := context.WithCancel(context.Background())
+	lv.cancel = cancel
+	if lv.IsAggregate() {
+		lv.tailer = lv.kubeConfig.NewLogTailer(lv.contextName, lv.namespace, lv.selector)
+		lv.tailer.SetOptions(lv.options)
+		lv.tailer.SetPods(lv.pods)
+		go streamSelectorLogs(ctx, lv.events, lv.session, lv.tailer)
+		return
+	}
+
+	// The last 100 lines or the logs since the chosen start, then follow
+	opts := lv.options
+	opts.Container = lv.containerName
+	opts.Previous = lv.previous
+	opts.Follow = !lv.previous // the previous instance has terminated, there is nothing to follow
+	if opts.SinceTime.IsZero() && opts.SinceSeconds == 0 {
+		opts.TailLines = logsInitialLines
+	}
+	go streamPodLogs(ctx, lv.events, lv.session, lv.kubeConfig, lv.contextName, lv.namespace, lv.podName, opts)
 }
 
 func (lv *LogsViewer) Close() {
 	lv.isOpen = false
-	lv.logs = []string{}
+	lv.session++
+	lv.logs = newLogRing(lv.bufferSize)
+	lv.visibleValid = false
 	lv.scrollOffset = 0
 	lv.isFollowing = false
 	if lv.cancel != nil {
 		lv.cancel()
 		lv.cancel = nil
 	}
+	if lv.tailer != nil {
+		lv.tailer.Stop()
+		lv.tailer = nil
+	}
+	lv.selector = ""
+	lv.pods = nil
+	lv.inputMode = ""
+	lv.search = nil
+	lv.searchQuery = ""
+	lv.filter = nil
+	lv.filterInvert = false
+	lv.fieldFilter = nil
+	lv.options = k8s.LogOptions{}
+}
+
+// SetSettings gives the viewer the persisted preferences, such as the extra fields to show
+func (lv *LogsViewer) SetSettings(s *settings.Settings) {
+	lv.settings = s
+	if s != nil {
+		lv.fields = s.Logs.Fields
+		lv.bufferSize = s.Logs.BufferLines
+		lv.wrap = s.Logs.Wrap
+		lv.timestamps = s.Logs.Timestamps
+		lv.levelColors = s.Logs.LevelColors
+	}
+}
+
+// ToggleWrap switches between wrapping long lines and panning them horizontally
+func (lv *LogsViewer) ToggleWrap() error {
+	lv.wrap = !lv.wrap
+	lv.hOffset = 0
+	return lv.saveDisplayModes()
+}
+
+// PanLeft scrolls unwrapped lines to the left
+func (lv *LogsViewer) PanLeft() {
+	lv.hOffset = max(lv.hOffset-logsPanColumns, 0)
+}
+
+// PanRight scrolls unwrapped lines to the right
+func (lv *LogsViewer) PanRight() {
+	if !lv.wrap {
+		lv.hOffset += logsPanColumns
+	}
+}
+
+// CycleTimestamps switches to the next timestamp mode: raw, local, relative, hidden
+func (lv *LogsViewer) CycleTimestamps() error {
+	next := 0
+	for i, mode := range logTimestampModes {
+		if mode == lv.timestamps {
+			next = (i + 1) % len(logTimestampModes)
+		}
+	}
+	lv.timestamps = logTimestampModes[next]
+	return lv.saveDisplayModes()
+}
+
+// ToggleLevelColors switches coloring lines by their level on or off
+func (lv *LogsViewer) ToggleLevelColors() error {
+	lv.levelColors = !lv.levelColors
+	return lv.saveDisplayModes()
+}
+
+// saveDisplayModes remembers the display modes for the next session
+func (lv *LogsViewer) saveDisplayModes() error {
+	if lv.settings == nil {
+		return nil
+	}
+	lv.settings.Logs.Wrap = lv.wrap
+	lv.settings.Logs.Timestamps = lv.timestamps
+	lv.settings.Logs.LevelColors = lv.levelColors
+	return lv.settings.Save()
 }
 
 func (lv *LogsViewer) IsOpen() bool {
 	return lv.isOpen
 }
 
+// ScrollUp moves the selected line up, scrolling when it leaves the view
 func (lv *LogsViewer) ScrollUp() {
-	if lv.scrollOffset > 0 {
-		lv.scrollOffset--
-	}
-	lv.isFollowing = false
+	lv.moveCursor(-1)
 }
 
+// ScrollDown moves the selected line down; following resumes at the last line
 func (lv *LogsViewer) ScrollDown() {
-	maxScroll := len(lv.logs) - 20 // Assuming 20 visible lines
-	if maxScroll < 0 {
-		maxScroll = 0
+	lv.moveCursor(1)
+}
+
+func (lv *LogsViewer) PageUp() {
+	lv.moveCursor(-10)
+}
+
+func (lv *LogsViewer) PageDown() {
+	lv.moveCursor(10)
+}
+
+func (lv *LogsViewer) ToggleFollow() {
+	lv.isFollowing = !lv.isFollowing
+	if lv.isFollowing {
+		// Scroll to bottom
+		lv.scrollOffset = lv.maxScroll()
+		lv.cursor = len(lv.visibleLines()) - 1
 	}
-	if lv.scrollOffset < maxScroll {
-		lv.scrollOffset++
+}
+
+// moveCursor moves the selected line by delta lines and keeps it in view
+func (lv *LogsViewer) moveCursor(delta int) {
+	last := len(lv.visibleLines()) - 1
+	if lv.cursor < 0 || lv.cursor > last {
+		lv.cursor = last
 	}
-	
+	lv.cursor = max(min(lv.cursor+delta, last), 0)
+
+	if lv.cursor < lv.scrollOffset {
+		lv.scrollOffset = lv.cursor
+	} else if lv.cursor >= lv.scrollOffset+logsPageLines {
+		lv.scrollOffset = lv.cursor - logsPageLines + 1
+	}
+
 	// Resume following if we're at the bottom
-	if lv.scrollOffset >= maxScroll {
-		lv.isFollowing = true
+	lv.isFollowing = lv.cursor == last
+	if lv.isFollowing {
+		lv.scrollOffset = lv.maxScroll()
 	}
 }
 
-func (lv *LogsViewer) PageUp() {
-	lv.scrollOffset -= 10
-	if lv.scrollOffset < 0 {
-		lv.scrollOffset = 0
+// maxScroll is the offset that shows the last visible lines
+func (lv *LogsViewer) maxScroll() int {
+	return max(len(lv.visibleLines())-logsPageLines, 0)
+}
+
+// visibleLines returns the buffered lines that pass the filter; stream notices are always shown.
+// The result is cached until the buffer or the filters change.
+func (lv *LogsViewer) visibleLines() []logLine {
+	if lv.visibleValid {
+		return lv.visible
 	}
-	lv.isFollowing = false
+
+	lines := lv.logs.Lines()
+	if lv.filter != nil || !lv.fieldFilter.IsEmpty() {
+		visible := lines[:0]
+		for _, line := range lines {
+			if lv.lineVisible(line) {
+				visible = append(visible, line)
+			}
+		}
+		lines = visible
+	}
+	lv.visible = lines
+	lv.visibleValid = true
+	return lines
 }
 
-func (lv *LogsViewer) PageDown() {
-	maxScroll := len(lv.logs) - 20
-	if maxScroll < 0 {
-		maxScroll = 0
+// lineVisible reports whether a line passes the filters
+func (lv *LogsViewer) lineVisible(line logLine) bool {
+	if line.notice != "" {
+		return true
 	}
-	lv.scrollOffset += 10
-	if lv.scrollOffset > maxScroll {
-		lv.scrollOffset = maxScroll
+	if lv.filter != nil && lv.filter.MatchString(line.text) == lv.filterInvert {
+		return false
 	}
-	
-	// Resume following if we're at the bottom
-	if lv.scrollOffset >= maxScroll {
-		lv.isFollowing = true
+	return lv.fieldFilter.Matches(line.text, line.entry)
+}
+
+// StartSearch opens the search input; matches are highlighted while typing
+func (lv *LogsViewer) StartSearch() {
+	lv.inputMode = logsInputSearch
+	lv.input = lv.searchQuery
+	lv.inputErr = nil
+	lv.savedSearch = lv.searchQuery
+	lv.savedOffset = lv.scrollOffset
+	lv.savedCursor = lv.cursor
+	lv.savedFollow = lv.isFollowing
+}
+
+// StartWhere opens the field filter input, prefilled with the current field filter
+func (lv *LogsViewer) StartWhere() {
+	lv.inputMode = logsInputWhere
+	lv.input = lv.fieldFilter.String()
+	lv.inputErr = nil
+}
+
+// StartFields opens the input for the extra fields shown after the message
+func (lv *LogsViewer) StartFields() {
+	lv.inputMode = logsInputFields
+	lv.input = strings.Join(lv.fields, ",")
+	lv.inputErr = nil
+}
+
+// StartOptions opens the input for where the logs start and how many bytes are read
+func (lv *LogsViewer) StartOptions() {
+	lv.inputMode = logsInputOptions
+	lv.input = strings.TrimSpace(lv.GetOptions() + fmt.Sprintf(" buffer=%d", lv.bufferSize))
+	lv.inputErr = nil
+}
+
+// StartExport asks what to export
+func (lv *LogsViewer) StartExport() {
+	lv.inputMode = logsInputExport
+	lv.input = ""
+	lv.inputErr = nil
+}
+
+// IsChoosingExport reports whether the viewer waits for the export choice
+func (lv *LogsViewer) IsChoosingExport() bool {
+	return lv.inputMode == logsInputExport
+}
+
+// StartFilter opens the filter input, prefilled with the current filter
+func (lv *LogsViewer) StartFilter() {
+	lv.inputMode = logsInputFilter
+	lv.input = lv.GetFilter()
+	lv.inputErr = nil
+}
+
+// IsEditing reports whether a search or filter is being typed
+func (lv *LogsViewer) IsEditing() bool {
+	return lv.inputMode != ""
+}
+
+func (lv *LogsViewer) AddInputChar(char string) {
+	if len(char) == 1 && char[0] >= ' ' && char[0] < 0x7f {
+		lv.input += char
+		lv.inputChanged()
 	}
 }
 
-func (lv *LogsViewer) ToggleFollow() {
-	lv.isFollowing = !lv.isFollowing
-	if lv.isFollowing {
-		// Scroll to bottom
-		maxScroll := len(lv.logs) - 20
-		if maxScroll < 0 {
-			maxScroll = 0
-		}
-		lv.scrollOffset = maxScroll
+func (lv *LogsViewer) InputBackspace() {
+	if len(lv.input) > 0 {
+		lv.input = lv.input[:len(lv.input)-1]
+		lv.inputChanged()
 	}
 }
 
-func (lv *LogsViewer) fetchLogs() {
-	ctx, cancel := context.WithCancel(context.Background())
-	lv.cancel = cancel
+// inputChanged searches as the query is typed, starting from the top of the current view
+func (lv *LogsViewer) inputChanged() {
+	if lv.inputMode != logsInputSearch {
+		return
+	}
+	lv.setSearch(lv.input)
+	lv.scrollOffset = lv.savedOffset
+	lv.cursor = lv.scrollOffset - 1
+	if lv.search != nil {
+		lv.findMatch(1)
+	}
+}
 
-	// First, get the last 100 lines
-	logReader, err := lv.kubeConfig.GetPodLogs(lv.contextName, lv.namespace, lv.podName, lv.containerName, 100, false)
+// ConfirmInput applies the search or filter being typed; an empty filter clears it
+func (lv *LogsViewer) ConfirmInput() error {
+	var err error
+	switch lv.inputMode {
+	case logsInputFilter:
+		err = lv.SetFilter(lv.input)
+	case logsInputWhere:
+		err = lv.SetFieldFilter(lv.input)
+	case logsInputOptions:
+		err = lv.SetOptions(lv.input)
+	case logsInputFields:
+		err = lv.SetFields(strings.FieldsFunc(lv.input, func(r rune) bool {
+			return r == ',' || r == ' '
+		}))
+	}
 	if err != nil {
-		lv.error = err
+		lv.inputErr = err
+		return err
+	}
+	lv.inputMode = ""
+	lv.inputErr = nil
+	return nil
+}
+
+// CancelInput stops typing; a cancelled search restores the previous query and position
+func (lv *LogsViewer) CancelInput() {
+	if lv.inputMode == logsInputSearch {
+		lv.setSearch(lv.savedSearch)
+		lv.scrollOffset = lv.savedOffset
+		lv.cursor = lv.savedCursor
+		lv.isFollowing = lv.savedFollow
+	}
+	lv.inputMode = ""
+	lv.inputErr = nil
+}
+
+// setSearch sets the search query; matching is literal and case-insensitive
+func (lv *LogsViewer) setSearch(query string) {
+	lv.searchQuery = query
+	lv.cursor = -1
+	if query == "" {
+		lv.search = nil
 		return
 	}
+	lv.search = regexp.MustCompile("(?i)" + regexp.QuoteMeta(query))
+}
 
-	// Read initial logs
-	scanner := bufio.NewScanner(logReader)
-	for scanner.Scan() {
-		line := scanner.Text()
-		lv.logs = append(lv.logs, line)
+// SetFilter shows only the lines matching a regex, or the lines not matching it when the
+// pattern starts with '!'. An empty pattern removes the filter.
+func (lv *LogsViewer) SetFilter(pattern string) error {
+	invert := false
+	if rest, ok := strings.CutPrefix(pattern, "!"); ok {
+		invert = true
+		pattern = rest
+	}
+	if pattern == "" {
+		lv.filter = nil
+		lv.filterInvert = false
+	} else {
+		filter, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid filter: %w", err)
+		}
+		lv.filter = filter
+		lv.filterInvert = invert
 	}
-	logReader.Close()
 
-	// Start following logs
-	followReader, err := lv.kubeConfig.GetPodLogs(lv.contextName, lv.namespace, lv.podName, lv.containerName, 0, true)
+	lv.visibleValid = false
+
+	// Line positions change with the filter
+	lv.cursor = -1
+	if lv.isFollowing || lv.scrollOffset > lv.maxScroll() {
+		lv.scrollOffset = lv.maxScroll()
+	}
+	return nil
+}
+
+// SetFieldFilter shows only the lines whose fields match a query such as `level>=warn user=bob`
+func (lv *LogsViewer) SetFieldFilter(query string) error {
+	filter, err := k8s.ParseLogFilter(query)
 	if err != nil {
-		lv.error = err
+		return err
+	}
+	if filter.IsEmpty() {
+		filter = nil
+	}
+	lv.fieldFilter = filter
+
+	lv.visibleValid = false
+
+	// Line positions change with the filter
+	lv.cursor = -1
+	if lv.isFollowing || lv.scrollOffset > lv.maxScroll() {
+		lv.scrollOffset = lv.maxScroll()
+	}
+	return nil
+}
+
+// SetFields sets the extra fields shown after the message of structured lines and remembers them
+func (lv *LogsViewer) SetFields(fields []string) error {
+	lv.fields = fields
+	if lv.settings == nil {
+		return nil
+	}
+	lv.settings.Logs.Fields = fields
+	return lv.settings.Save()
+}
+
+// SetOptions parses and applies log options such as `since=1h limit=5MB` or
+// `since=2026-10-18T14:00`, then fetches the logs again. An empty input resets them. The
+// buffer size, `buffer=5000`, is remembered and only changes when given.
+func (lv *LogsViewer) SetOptions(input string) error {
+	var opts k8s.LogOptions
+	bufferSize := lv.bufferSize
+	for _, token := range strings.Fields(input) {
+		key, value, ok := strings.Cut(token, "=")
+		if !ok {
+			return fmt.Errorf("expected since=..., limit=... or buffer=..., got %q", token)
+		}
+		switch strings.ToLower(key) {
+		case "since":
+			if duration, err := time.ParseDuration(value); err == nil {
+				if duration <= 0 {
+					return fmt.Errorf("since must be positive")
+				}
+				opts.SinceSeconds = int64(duration.Seconds())
+				opts.SinceTime = time.Time{}
+				continue
+			}
+			sinceTime, err := parseLogSinceTime(value)
+			if err != nil {
+				return err
+			}
+			opts.SinceTime = sinceTime
+			opts.SinceSeconds = 0
+		case "limit":
+			limit, err := parseByteSize(value)
+			if err != nil {
+				return err
+			}
+			opts.LimitBytes = limit
+		case "buffer":
+			size, err := strconv.Atoi(value)
+			if err != nil || size < logsPageLines {
+				return fmt.Errorf("invalid buffer %q (use a number of lines, at least %d)", value, logsPageLines)
+			}
+			bufferSize = size
+		default:
+			return fmt.Errorf("unknown option %q (use since, limit or buffer)", key)
+		}
+	}
+
+	resized := bufferSize != lv.bufferSize
+	lv.options = opts
+	lv.bufferSize = bufferSize
+	lv.restart()
+	if !resized || lv.settings == nil {
+		return nil
+	}
+	lv.settings.Logs.BufferLines = bufferSize
+	return lv.settings.Save()
+}
+
+// GetOptions returns the log options in the form SetOptions accepts
+func (lv *LogsViewer) GetOptions() string {
+	var parts []string
+	if !lv.options.SinceTime.IsZero() {
+		parts = append(parts, "since="+lv.options.SinceTime.Local().Format("2006-01-02T15:04:05"))
+	} else if lv.options.SinceSeconds > 0 {
+		parts = append(parts, "since="+(time.Duration(lv.options.SinceSeconds)*time.Second).String())
+	}
+	if lv.options.LimitBytes > 0 {
+		parts = append(parts, "limit="+formatByteSize(lv.options.LimitBytes))
+	}
+	return strings.Join(parts, " ")
+}
+
+// parseLogSinceTime reads an RFC3339 time or a local date and time
+func parseLogSinceTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	// A time of day means today
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			now := time.Now()
+			return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.Local), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid since %q (use a duration like 15m or a time like 2026-10-18T14:00)", value)
+}
+
+// parseByteSize reads sizes like 1048576, 512K, 10MB or 1GiB
+func parseByteSize(value string) (int64, error) {
+	upper := strings.ToUpper(value)
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{{"GIB", 1 << 30}, {"MIB", 1 << 20}, {"KIB", 1 << 10}, {"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}, {"B", 1}} {
+		if number, ok := strings.CutSuffix(upper, unit.suffix); ok {
+			upper = number
+			multiplier = unit.size
+			break
+		}
+	}
+	size, err := strconv.ParseInt(upper, 10, 64)
+	if err != nil || size <= 0 {
+		return 0, fmt.Errorf("invalid limit %q (use a size like 512K or 10MB)", value)
+	}
+	return size * multiplier, nil
+}
+
+// formatByteSize renders a size in the largest whole unit
+func formatByteSize(size int64) string {
+	switch {
+	case size%(1<<30) == 0:
+		return fmt.Sprintf("%dGB", size>>30)
+	case size%(1<<20) == 0:
+		return fmt.Sprintf("%dMB", size>>20)
+	case size%(1<<10) == 0:
+		return fmt.Sprintf("%dKB", size>>10)
+	}
+	return strconv.FormatInt(size, 10)
+}
+
+// ExportJob prepares an export of the logs to a new file in the working directory. Buffered lines
+// are copied right away; the returned job writes the file and returns its path, and may take a
+// while for a full fetch, so it is meant to run outside the UI loop.
+func (lv *LogsViewer) ExportJob(scope string) (func() (string, error), error) {
+	if lv.inputMode == logsInputExport {
+		lv.inputMode = ""
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate working directory: %w", err)
+	}
+	name := lv.exportName()
+	if scope == LogExportFull {
+		name += "-full"
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.log", name, time.Now().Format("20060102-150405")))
+
+	var write func(w io.Writer) error
+	switch scope {
+	case LogExportVisible, LogExportBuffer:
+		lines := lv.logs.Lines()
+		if scope == LogExportVisible {
+			lines = lv.visibleLines()
+		}
+		lines = append([]logLine(nil), lines...)
+		write = func(w io.Writer) error {
+			for _, line := range lines {
+				if line.notice != "" {
+					continue
+				}
+				text := line.text
+				if line.pod != "" {
+					text = line.pod + "/" + line.container + " " + text
+				}
+				if _, err := fmt.Fprintln(w, text); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case LogExportFull:
+		kubeConfig, contextName, namespace := lv.kubeConfig, lv.contextName, lv.namespace
+		// Everything since the start the viewer was set to, without its byte limit
+		opts := lv.options
+		opts.LimitBytes = 0
+		opts.TailLines = 0
+		if lv.IsAggregate() {
+			selector, pods := lv.selector, lv.pods
+			write = func(w io.Writer) error {
+				return kubeConfig.WriteSelectorLogs(contextName, namespace, selector, pods, opts, w)
+			}
+		} else {
+			podName := lv.podName
+			opts.Container = lv.containerName
+			opts.Previous = lv.previous
+			write = func(w io.Writer) error {
+				logs, err := kubeConfig.GetPodLogs(contextName, namespace, podName, opts)
+				if err != nil {
+					return err
+				}
+				defer logs.Close()
+				_, err = io.Copy(w, logs)
+				return err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown export %q", scope)
+	}
+
+	return func() (string, error) {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return "", fmt.Errorf("failed to create export file: %w", err)
+		}
+		if err := write(file); err != nil {
+			file.Close()
+			return path, fmt.Errorf("failed to export logs: %w", err)
+		}
+		if err := file.Close(); err != nil {
+			return path, fmt.Errorf("failed to export logs: %w", err)
+		}
+		return path, nil
+	}, nil
+}
+
+// exportName names export files after the pod and container or the tailed workload
+func (lv *LogsViewer) exportName() string {
+	name := lv.podName
+	if lv.IsAggregate() {
+		name = lv.description
+	} else if lv.containerName != "" {
+		name += "-" + lv.containerName
+	}
+	if lv.previous {
+		name += "-previous"
+	}
+
+	// Keep the name safe for any file system
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.', r == '_':
+			return r
+		}
+		return '_'
+	}, name)
+	if name == "" {
+		return "logs"
+	}
+	return name
+}
+
+// ToggleStructured switches between the structured rendering of JSON and logfmt lines and the raw lines
+func (lv *LogsViewer) ToggleStructured() {
+	lv.structured = !lv.structured
+}
+
+// ToggleExpand shows or hides the whole object of the selected line; it reports whether the line is structured
+func (lv *LogsViewer) ToggleExpand() bool {
+	lines := lv.visibleLines()
+	if len(lines) == 0 {
+		return false
+	}
+	index := lv.cursor
+	if index < 0 || index >= len(lines) {
+		index = len(lines) - 1
+	}
+	line := lines[index]
+	if line.entry == nil {
+		return false
+	}
+	if lv.expanded[line.seq] {
+		delete(lv.expanded, line.seq)
+	} else {
+		lv.expanded[line.seq] = true
+	}
+	return true
+}
+
+// GetFilter returns the filter as typed, with a leading '!' when it is inverted
+func (lv *LogsViewer) GetFilter() string {
+	if lv.filter == nil {
+		return ""
+	}
+	if lv.filterInvert {
+		return "!" + lv.filter.String()
+	}
+	return lv.filter.String()
+}
+
+// NextMatch scrolls to the next line matching the search
+func (lv *LogsViewer) NextMatch() bool {
+	return lv.findMatch(1)
+}
+
+// PrevMatch scrolls to the previous line matching the search
+func (lv *LogsViewer) PrevMatch() bool {
+	return lv.findMatch(-1)
+}
+
+// findMatch moves to the next match in a direction, wrapping around the buffer
+func (lv *LogsViewer) findMatch(direction int) bool {
+	if lv.search == nil {
+		return false
+	}
+	lines := lv.visibleLines()
+	current := lv.cursor
+	if current < 0 {
+		// Following, searches start after the last line
+		current = len(lines)
+	}
+	for step := 1; step <= len(lines); step++ {
+		i := ((current+direction*step)%len(lines) + len(lines)) % len(lines)
+		if lv.searchMatches(lines[i]) {
+			lv.cursor = i
+			// Keep a few lines of context above the match
+			lv.scrollOffset = min(max(i-3, 0), lv.maxScroll())
+			lv.isFollowing = false
+			return true
+		}
+	}
+	return false
+}
+
+// countMatches returns how many visible lines match the search
+func (lv *LogsViewer) countMatches(lines []logLine) int {
+	count := 0
+	for _, line := range lines {
+		if lv.searchMatches(line) {
+			count++
+		}
+	}
+	return count
+}
+
+// searchMatches reports whether the search matches a line as it is rendered: the displayed text of
+// a plain line, or the message and shown key=value fields of a structured one
+func (lv *LogsViewer) searchMatches(line logLine) bool {
+	if line.notice != "" {
+		return false
+	}
+	if line.entry == nil || !lv.structured {
+		return lv.search.MatchString(lv.displayText(line.text))
+	}
+	if lv.search.MatchString(line.entry.Message) {
+		return true
+	}
+	for _, field := range lv.fields {
+		if value, ok := line.entry.Fields[field]; ok && lv.search.MatchString(field+"="+value) {
+			return true
+		}
+	}
+	return false
+}
+
+// streamPodLogs follows one container and delivers its lines on events until ctx is cancelled.
+// It only uses its arguments, never the viewer, so it can run next to the UI loop.
+func streamPodLogs(ctx context.Context, events chan<- logEvent, session uint64, kubeConfig *k8s.KubeConfig, contextName, namespace, podName string, opts k8s.LogOptions) {
+	send := func(event logEvent) bool {
+		event.session = session
+		select {
+		case events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	err := kubeConfig.FollowPodLogs(ctx, contextName, namespace, podName, opts, func(line k8s.LogLine) bool {
+		if line.Notice == k8s.LogTailStarted {
+			return send(logEvent{loaded: true})
+		}
+		return send(logEvent{line: logLine{text: line.Text, notice: line.Notice}})
+	})
+	send(logEvent{loaded: true, done: true, err: err})
+}
+
+// streamSelectorLogs delivers the lines of a log tailer on events until ctx is cancelled
+func streamSelectorLogs(ctx context.Context, events chan<- logEvent, session uint64, tailer *k8s.LogTailer) {
+	tailer.Start()
+	defer tailer.Stop()
+
+	select {
+	case events <- logEvent{session: session, loaded: true}:
+	case <-ctx.Done():
 		return
 	}
 
-	go func() {
-		defer followReader.Close()
-		scanner := bufio.NewScanner(followReader)
-		for scanner.Scan() {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case line := <-tailer.Lines():
+			event := logEvent{session: session, line: logLine{pod: line.Pod, container: line.Container, text: line.Text, notice: line.Notice}}
 			select {
+			case events <- event:
 			case <-ctx.Done():
 				return
+			}
+		}
+	}
+}
+
+// WaitForLogs waits for streamed lines and delivers them as a LogStreamMsg, batching the lines
+// that are already waiting. Run it again after every LogStreamMsg.
+func (lv *LogsViewer) WaitForLogs() tea.Cmd {
+	events := lv.events
+	return func() tea.Msg {
+		batch := []logEvent{<-events}
+		for len(batch) < logsBatchSize {
+			select {
+			case event := <-events:
+				batch = append(batch, event)
 			default:
-				line := scanner.Text()
-				lv.logs = append(lv.logs, line)
-				
-				// Limit to last 1000 lines to prevent memory issues
-				if len(lv.logs) > 1000 {
-					lv.logs = lv.logs[len(lv.logs)-1000:]
-				}
-				
-				// Auto-scroll if following
-				if lv.isFollowing {
-					maxScroll := len(lv.logs) - 20
-					if maxScroll < 0 {
-						maxScroll = 0
-					}
-					lv.scrollOffset = maxScroll
-				}
+				return LogStreamMsg{events: batch}
 			}
 		}
-	}()
+		return LogStreamMsg{events: batch}
+	}
+}
+
+// HandleLogs adds streamed lines to the buffer; lines of streams that were replaced are dropped
+func (lv *LogsViewer) HandleLogs(msg LogStreamMsg) {
+	for _, event := range msg.events {
+		if event.session != lv.session {
+			continue
+		}
+		if event.loaded {
+			lv.isLoading = false
+		}
+		if event.err != nil {
+			if lv.logs.Len() == 0 {
+				lv.error = event.err
+			} else {
+				lv.appendLine(logLine{notice: k8s.LogTailError, text: event.err.Error()})
+			}
+		}
+		if event.done && lv.isFollowing && lv.error == nil && !lv.previous {
+			lv.appendLine(logLine{notice: k8s.LogTailStopped, text: "the container exited, no more logs will follow"})
+		}
+		if event.line.text != "" || event.line.notice != "" {
+			lv.appendLine(event.line)
+		}
+	}
+
+	// Auto-scroll if following
+	if lv.isFollowing {
+		lv.scrollOffset = lv.maxScroll()
+		lv.cursor = -1
+	}
+}
+
+// appendLine adds a line to the ring buffer, keeping the selected line in place when the oldest line drops out
+func (lv *LogsViewer) appendLine(line logLine) {
+	lv.nextSeq++
+	line.seq = lv.nextSeq
+	if line.notice == "" {
+		if entry, ok := k8s.ParseStructuredLog(line.text); ok {
+			// Fall back to the time the API server recorded the line at
+			if timestamp, _, ok := k8s.SplitLogTimestamp(line.text); ok && entry.Time.IsZero() {
+				entry.Time = timestamp
+			}
+			line.entry = entry
+		}
+	}
+
+	dropped, full := lv.logs.Push(line)
+	lv.visibleValid = false
+	if !full {
+		return
+	}
+	delete(lv.expanded, dropped.seq)
+	if !lv.isFollowing && lv.lineVisible(dropped) {
+		lv.scrollOffset = max(lv.scrollOffset-1, 0)
+		if lv.cursor > 0 {
+			lv.cursor--
+		}
+	}
 }
 
 func (lv *LogsViewer) Render(screenWidth, screenHeight int) string {
@@ -200,15 +1157,39 @@ func (lv *LogsViewer) Render(screenWidth, screenHeight int) string {
 	// Header
 	headerStyle := styles.NormalStyle.Bold(true).Foreground(lipgloss.Color("39"))
 	title := fmt.Sprintf("📋 Logs: %s/%s", lv.podName, lv.containerName)
-	if lv.containerName == "" {
+	if lv.IsAggregate() {
+		title = fmt.Sprintf("📋 Logs: %s", lv.description)
+	} else if lv.containerName == "" {
 		title = fmt.Sprintf("📋 Logs: %s", lv.podName)
 	}
+	if lv.previous {
+		title += " (previous instance)"
+	}
 	content.WriteString(headerStyle.Render(title) + "\n")
 
 	// Status line
 	statusStyle := styles.NormalStyle.Foreground(lipgloss.Color("245"))
 	status := fmt.Sprintf("Namespace: %s", lv.namespace)
-	if lv.isFollowing {
+	if lv.namespace == "" {
+		status = "All namespaces"
+	}
+	if len(lv.pods) > 0 {
+		status += fmt.Sprintf(" • %d pods • %d containers", len(lv.pods), lv.tailer.Streams())
+	} else if lv.IsAggregate() {
+		status += fmt.Sprintf(" • Selector: %s • %d containers", lv.selector, lv.tailer.Streams())
+	}
+	if options := lv.GetOptions(); options != "" {
+		status += " • " + options
+	}
+	if lv.wrap {
+		status += " • Wrapping"
+	} else if lv.hOffset > 0 {
+		status += fmt.Sprintf(" • Column %d", lv.hOffset+1)
+	}
+	status += " • Timestamps: " + lv.timestamps
+	if lv.previous {
+		status += " • Last output before the container restarted"
+	} else if lv.isFollowing {
 		status += " • Following (press 'f' to stop)"
 	} else {
 		status += " • Paused (press 'f' to follow)"
@@ -217,18 +1198,24 @@ func (lv *LogsViewer) Render(screenWidth, screenHeight int) string {
 
 	// Controls
 	controlsStyle := styles.NormalStyle.Foreground(lipgloss.Color("240"))
-	controls := "↑↓=scroll PgUp/PgDn=page f=follow/pause Esc=close"
-	content.WriteString(controlsStyle.Render(controls) + "\n\n")
+	controls := "↑↓=scroll PgUp/PgDn=page f=follow/pause /=search n/N=next/prev &=filter w=where c=fields s=raw Enter=expand o=options x=export W=wrap ←→=pan t=timestamps L=colors p=current/previous Esc=close"
+	if lv.IsAggregate() {
+		controls = "↑↓=scroll PgUp/PgDn=page f=follow/pause /=search n/N=next/prev &=filter w=where c=fields s=raw Enter=expand o=options x=export W=wrap ←→=pan t=timestamps L=colors Esc=close"
+	}
+	content.WriteString(controlsStyle.Render(controls) + "\n")
+	content.WriteString(lv.renderSearchBar() + "\n\n")
 
 	// Error handling
 	if lv.error != nil {
 		errorStyle := styles.NormalStyle.Foreground(lipgloss.Color("196"))
 		content.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", lv.error)))
-	} else if len(lv.logs) == 0 {
+	} else if lv.logs.Len() == 0 && lv.isLoading {
 		content.WriteString(styles.NormalStyle.Render("Loading logs..."))
+	} else if lv.logs.Len() == 0 {
+		content.WriteString(styles.NormalStyle.Render("No logs available"))
 	} else {
 		// Render logs
-		content.WriteString(lv.renderLogs(height - 6)) // Reserve space for header and controls
+		content.WriteString(lv.renderLogs(height-7, width-2)) // Reserve space for header, controls and search bar
 	}
 
 	// Create the box style
@@ -252,56 +1239,351 @@ func (lv *LogsViewer) Render(screenWidth, screenHeight int) string {
 	)
 }
 
-func (lv *LogsViewer) renderLogs(maxLines int) string {
-	if len(lv.logs) == 0 {
-		return "No logs available"
+func (lv *LogsViewer) renderLogs(maxLines, width int) string {
+	logs := lv.visibleLines()
+	if len(logs) == 0 {
+		return styles.NormalStyle.Foreground(lipgloss.Color("240")).Render("No lines match the filter")
 	}
 
-	var result strings.Builder
-	
-	// Calculate which logs to show
+	// Calculate which logs to show, keeping the selected line in view
 	startLine := lv.scrollOffset
-	endLine := startLine + maxLines
-	
-	if endLine > len(lv.logs) {
-		endLine = len(lv.logs)
+	if lv.cursor >= startLine+maxLines {
+		startLine = lv.cursor - maxLines + 1
 	}
-	if startLine >= len(lv.logs) {
-		startLine = len(lv.logs) - 1
+	if startLine >= len(logs) {
+		startLine = len(logs) - 1
 		if startLine < 0 {
 			startLine = 0
 		}
 	}
 
-	// Show logs
-	for i := startLine; i < endLine; i++ {
-		line := lv.logs[i]
-		
-		// Color code based on log level
-		lineStyle := styles.NormalStyle
-		lowerLine := strings.ToLower(line)
-		if strings.Contains(lowerLine, "error") || strings.Contains(lowerLine, "err") {
-			lineStyle = lineStyle.Foreground(lipgloss.Color("196")) // Red
-		} else if strings.Contains(lowerLine, "warn") || strings.Contains(lowerLine, "warning") {
-			lineStyle = lineStyle.Foreground(lipgloss.Color("226")) // Yellow
-		} else if strings.Contains(lowerLine, "info") {
-			lineStyle = lineStyle.Foreground(lipgloss.Color("39")) // Blue
-		} else if strings.Contains(lowerLine, "debug") {
-			lineStyle = lineStyle.Foreground(lipgloss.Color("240")) // Gray
+	// The line that must stay in view: the selected one, or the newest while following
+	target := lv.cursor
+	if lv.isFollowing {
+		target = len(logs) - 1
+	}
+
+	// Show logs until the view is full; wrapped and expanded lines take several rows, which can
+	// push the target line out of view, so start further down until it fits
+	var rows []string
+	endLine := startLine
+	for {
+		rows, endLine = rows[:0], startLine
+		for i := startLine; i < len(logs) && len(rows) < maxLines; i++ {
+			rows = append(rows, lv.renderLineRows(logs[i], i, width)...)
+			endLine = i + 1
 		}
-		
-		result.WriteString(lineStyle.Render(line))
-		if i < endLine-1 {
-			result.WriteString("\n")
+		fits := target < endLine-1 || target == endLine-1 && len(rows) <= maxLines
+		if target < 0 || startLine >= target || fits {
+			break
 		}
+		startLine++
 	}
+	if len(rows) > maxLines {
+		rows = rows[:maxLines]
+	}
+
+	result := strings.Join(rows, "\n")
 
 	// Show scroll indicator
-	if len(lv.logs) > maxLines {
-		scrollInfo := fmt.Sprintf("\nShowing lines %d-%d of %d", startLine+1, endLine, len(lv.logs))
+	if len(logs) > endLine-startLine {
+		scrollInfo := fmt.Sprintf("\nShowing lines %d-%d of %d", startLine+1, endLine, len(logs))
 		scrollStyle := styles.NormalStyle.Foreground(lipgloss.Color("240")).Italic(true)
-		result.WriteString(scrollStyle.Render(scrollInfo))
+		result += scrollStyle.Render(scrollInfo)
 	}
 
+	return result
+}
+
+// renderLineRows renders one line, and its expanded object, as rows fitted to the width
+func (lv *LogsViewer) renderLineRows(line logLine, index, width int) []string {
+	if line.notice != "" {
+		return lv.fitRow(lv.renderNotice(line), width)
+	}
+
+	var row strings.Builder
+	if index == lv.cursor && !lv.isFollowing {
+		row.WriteString(styles.NormalStyle.Foreground(lipgloss.Color("226")).Render("▶ "))
+	}
+	if line.pod != "" {
+		row.WriteString(lv.renderSource(line) + " ")
+	}
+	if line.entry != nil && lv.structured {
+		row.WriteString(lv.renderStructured(line.entry))
+	} else {
+		text := lv.displayText(line.text)
+		row.WriteString(lv.highlight(text, lv.plainLineStyle(text)))
+	}
+	rows := lv.fitRow(row.String(), width)
+
+	if line.entry != nil && lv.expanded[line.seq] {
+		prettyStyle := styles.NormalStyle.Foreground(lipgloss.Color("250"))
+		for _, prettyLine := range strings.Split(line.entry.Pretty(), "\n") {
+			rows = append(rows, lv.fitRow("    "+lv.highlight(prettyLine, prettyStyle), width)...)
+		}
+	}
+	return rows
+}
+
+// fitRow wraps a rendered row to the width, or cuts the horizontally scrolled part out of it
+func (lv *LogsViewer) fitRow(row string, width int) []string {
+	if width <= 0 {
+		return []string{row}
+	}
+	if lv.wrap {
+		return strings.Split(ansi.Hardwrap(row, width, true), "\n")
+	}
+	return []string{ansi.Cut(row, lv.hOffset, lv.hOffset+width)}
+}
+
+// displayText shows the timestamp the API server prefixes lines with in the chosen mode
+func (lv *LogsViewer) displayText(text string) string {
+	timestamp, rest, ok := k8s.SplitLogTimestamp(text)
+	if !ok || lv.timestamps == logTimestampsRaw {
+		return text
+	}
+	if lv.timestamps == logTimestampsHidden {
+		return rest
+	}
+	return formatLogTime(timestamp, lv.timestamps) + " " + rest
+}
+
+// formatLogTime formats a log time in a timestamp mode other than hidden
+func formatLogTime(timestamp time.Time, mode string) string {
+	switch mode {
+	case logTimestampsRaw:
+		return timestamp.Format(time.RFC3339Nano)
+	case logTimestampsRelative:
+		return fmt.Sprintf("%6s", k8s.FormatTimeAgo(timestamp))
+	}
+	return timestamp.Local().Format("2006-01-02 15:04:05.000")
+}
+
+// plainLineStyle colors an unstructured line by the level words in it, as level filters see them
+func (lv *LogsViewer) plainLineStyle(text string) lipgloss.Style {
+	lineStyle := styles.NormalStyle
+	if !lv.levelColors {
+		return lineStyle
+	}
+	if level := k8s.DetectLogLevel(text); level != "" {
+		lineStyle = lineStyle.Foreground(lipgloss.Color(logLevelColor(level)))
+	}
+	return lineStyle
+}
+
+// renderStructured renders a JSON or logfmt line as time, level and message followed by the
+// configured extra fields
+func (lv *LogsViewer) renderStructured(entry *k8s.StructuredLog) string {
+	var parts []string
+
+	timeStyle := styles.NormalStyle.Foreground(lipgloss.Color("245"))
+	if !entry.Time.IsZero() && lv.timestamps != logTimestampsHidden {
+		parts = append(parts, timeStyle.Render(formatLogTime(entry.Time, lv.timestamps)))
+	}
+
+	level := entry.Level
+	if level == "" {
+		level = "-"
+	}
+	levelStyle := styles.NormalStyle.Bold(true)
+	if lv.levelColors {
+		levelStyle = levelStyle.Foreground(lipgloss.Color(logLevelColor(entry.Level)))
+	}
+	parts = append(parts, levelStyle.Render(fmt.Sprintf("%-5s", strings.ToUpper(level))))
+
+	parts = append(parts, lv.highlight(entry.Message, styles.NormalStyle))
+
+	keyStyle := styles.NormalStyle.Foreground(lipgloss.Color("240"))
+	valueStyle := styles.NormalStyle.Foreground(lipgloss.Color("250"))
+	for _, field := range lv.fields {
+		if value, ok := entry.Fields[field]; ok {
+			parts = append(parts, lv.highlightField(field, value, keyStyle, valueStyle))
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// logLevelColor returns the color of a normalized log level
+func logLevelColor(level string) string {
+	switch level {
+	case k8s.LogLevelFatal:
+		return "201" // Magenta
+	case k8s.LogLevelError:
+		return "196" // Red
+	case k8s.LogLevelWarn:
+		return "226" // Yellow
+	case k8s.LogLevelInfo:
+		return "39" // Blue
+	case k8s.LogLevelDebug, k8s.LogLevelTrace:
+		return "240" // Gray
+	}
+	return "252"
+}
+
+// renderSource renders the "pod/container" prefix of an aggregated line, colored per pod
+func (lv *LogsViewer) renderSource(line logLine) string {
+	var hash uint32
+	for _, c := range line.pod {
+		hash = hash*31 + uint32(c)
+	}
+	color := logSourceColors[hash%uint32(len(logSourceColors))]
+	return styles.NormalStyle.Foreground(lipgloss.Color(color)).Render(line.pod + "/" + line.container)
+}
+
+// renderNotice renders a stream change, such as a pod being added or a stream reconnecting
+func (lv *LogsViewer) renderNotice(line logLine) string {
+	var marker, text string
+	color := "240"
+	switch line.notice {
+	case k8s.LogTailStarted:
+		marker = "+"
+	case k8s.LogTailStopped:
+		marker = "-"
+	case k8s.LogTailInterrupted:
+		marker, color = "⚠", "214"
+		text = fmt.Sprintf("stream interrupted (%s), reconnecting...", line.text)
+	case k8s.LogTailResumed:
+		marker, color = "↻", "46"
+		text = "stream resumed"
+	default:
+		marker, color = "!", "196"
+	}
+	if text == "" {
+		text = line.text
+	}
+
+	noticeStyle := styles.NormalStyle.Foreground(lipgloss.Color(color))
+	parts := []string{noticeStyle.Render(marker)}
+	if line.pod != "" {
+		parts = append(parts, lv.renderSource(line))
+	}
+	if text != "" {
+		parts = append(parts, noticeStyle.Render(text))
+	}
+	return strings.Join(parts, " ")
+}
+
+// highlight renders text with the search matches marked
+func (lv *LogsViewer) highlight(text string, lineStyle lipgloss.Style) string {
+	if lv.search == nil {
+		return lineStyle.Render(text)
+	}
+	matches := lv.search.FindAllStringIndex(text, -1)
+	if len(matches) == 0 {
+		return lineStyle.Render(text)
+	}
+
+	matchStyle := styles.NormalStyle.Background(lipgloss.Color("226")).Foreground(lipgloss.Color("0"))
+	var result strings.Builder
+	last := 0
+	for _, match := range matches {
+		result.WriteString(lineStyle.Render(text[last:match[0]]))
+		result.WriteString(matchStyle.Render(text[match[0]:match[1]]))
+		last = match[1]
+	}
+	result.WriteString(lineStyle.Render(text[last:]))
+	return result.String()
+}
+
+// highlightField renders a key=value field with the search matches marked; a match may span the key
+// and the value, as searches see them together
+func (lv *LogsViewer) highlightField(key, value string, keyStyle, valueStyle lipgloss.Style) string {
+	text := key + "=" + value
+	split := len(key) + 1
+	// render styles the part of the field between from and to that is not a match
+	render := func(from, to int) string {
+		if to <= split {
+			return keyStyle.Render(text[from:to])
+		}
+		if from >= split {
+			return valueStyle.Render(text[from:to])
+		}
+		return keyStyle.Render(text[from:split]) + valueStyle.Render(text[split:to])
+	}
+
+	var matches [][]int
+	if lv.search != nil {
+		matches = lv.search.FindAllStringIndex(text, -1)
+	}
+	matchStyle := styles.NormalStyle.Background(lipgloss.Color("226")).Foreground(lipgloss.Color("0"))
+	var result strings.Builder
+	last := 0
+	for _, match := range matches {
+		result.WriteString(render(last, match[0]))
+		result.WriteString(matchStyle.Render(text[match[0]:match[1]]))
+		last = match[1]
+	}
+	result.WriteString(render(last, len(text)))
 	return result.String()
-}
\ No newline at end of file
+}
+
+// renderSearchBar shows the search or filter being typed, or the active ones
+func (lv *LogsViewer) renderSearchBar() string {
+	promptStyle := styles.NormalStyle.Foreground(lipgloss.Color("39")).Bold(true)
+	infoStyle := styles.NormalStyle.Foreground(lipgloss.Color("245"))
+	errorStyle := styles.NormalStyle.Foreground(lipgloss.Color("196"))
+
+	switch lv.inputMode {
+	case logsInputSearch:
+		bar := promptStyle.Render("Search: ") + styles.NormalStyle.Render(lv.input+"█")
+		if lv.search != nil {
+			bar += infoStyle.Render(fmt.Sprintf("  %d matching lines", lv.countMatches(lv.visibleLines())))
+		}
+		return bar + infoStyle.Render("  Enter=confirm Esc=cancel")
+	case logsInputFilter:
+		bar := promptStyle.Render("Filter: ") + styles.NormalStyle.Render(lv.input+"█")
+		if lv.inputErr != nil {
+			return bar + "  " + errorStyle.Render(lv.inputErr.Error())
+		}
+		return bar + infoStyle.Render("  regex, prefix with ! to hide matches • Enter=apply Esc=cancel")
+	case logsInputWhere:
+		bar := promptStyle.Render("Where: ") + styles.NormalStyle.Render(lv.input+"█")
+		if lv.inputErr != nil {
+			return bar + "  " + errorStyle.Render(lv.inputErr.Error())
+		}
+		return bar + infoStyle.Render("  e.g. level>=warn user.id=42 path~^/api • Enter=apply Esc=cancel")
+	case logsInputOptions:
+		bar := promptStyle.Render("Options: ") + styles.NormalStyle.Render(lv.input+"█")
+		if lv.inputErr != nil {
+			return bar + "  " + errorStyle.Render(lv.inputErr.Error())
+		}
+		return bar + infoStyle.Render("  since=15m or since=2026-10-18T14:00, limit=5MB, buffer=5000 lines • Enter=apply Esc=cancel")
+	case logsInputExport:
+		return promptStyle.Render("Export: ") +
+			infoStyle.Render("v=visible lines  b=whole buffer  a=all logs from the server • Esc=cancel")
+	case logsInputFields:
+		bar := promptStyle.Render("Fields: ") + styles.NormalStyle.Render(lv.input+"█")
+		if lv.inputErr != nil {
+			return bar + "  " + errorStyle.Render(lv.inputErr.Error())
+		}
+		return bar + infoStyle.Render("  comma-separated, shown after the message • Enter=apply Esc=cancel")
+	}
+
+	var parts []string
+	if lv.filter != nil {
+		mode := "showing"
+		if lv.filterInvert {
+			mode = "hiding"
+		}
+		parts = append(parts, infoStyle.Render(fmt.Sprintf("Filter: %s lines matching /%s/", mode, lv.filter.String())))
+	}
+	if lv.fieldFilter != nil {
+		parts = append(parts, infoStyle.Render(fmt.Sprintf("Where: %s", lv.fieldFilter.String())))
+	}
+	if lv.filter != nil || lv.fieldFilter != nil {
+		parts = append(parts, infoStyle.Render(fmt.Sprintf("%d of %d lines", len(lv.visibleLines()), lv.logs.Len())))
+	}
+	if lv.search != nil {
+		matches := lv.countMatches(lv.visibleLines())
+		if matches == 0 {
+			parts = append(parts, errorStyle.Render(fmt.Sprintf("Search: %q not found", lv.searchQuery)))
+		} else {
+			parts = append(parts, infoStyle.Render(fmt.Sprintf("Search: %q, %d matching lines", lv.searchQuery, matches)))
+		}
+	}
+	if len(parts) == 0 {
+		return infoStyle.Render("No search or filter")
+	}
+	return strings.Join(parts, infoStyle.Render(" • "))
+}
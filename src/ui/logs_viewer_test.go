@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"testing"
+
+	"peek/src/k8s"
+)
+
+func TestSearchMatchesRenderedText(t *testing.T) {
+	const timestamped = "2024-05-01T10:00:00.000000000Z connection refused"
+	entry := &k8s.StructuredLog{
+		Level:   k8s.LogLevelError,
+		Message: "request failed",
+		Fields:  map[string]string{"user": "alice", "trace": "abc123"},
+	}
+
+	tests := []struct {
+		name       string
+		search     string
+		line       logLine
+		timestamps string
+		structured bool
+		fields     []string
+		want       bool
+	}{
+		{name: "plain text", search: "refused", line: logLine{text: timestamped}, timestamps: logTimestampsRaw, want: true},
+		{name: "raw timestamp shown", search: "2024-05-01T", line: logLine{text: timestamped}, timestamps: logTimestampsRaw, want: true},
+		{name: "hidden timestamp", search: "2024-05-01T", line: logLine{text: timestamped}, timestamps: logTimestampsHidden, want: false},
+		{name: "notice", search: "refused", line: logLine{text: "connection refused", notice: k8s.LogTailInterrupted}, want: false},
+		{name: "structured message", search: "failed", line: logLine{text: `{"msg":"request failed"}`, entry: entry}, structured: true, want: true},
+		{name: "raw JSON hidden by structured view", search: `"msg"`, line: logLine{text: `{"msg":"request failed"}`, entry: entry}, structured: true, want: false},
+		{name: "shown field", search: "user=ali", line: logLine{entry: entry}, structured: true, fields: []string{"user"}, want: true},
+		{name: "field not shown", search: "abc123", line: logLine{entry: entry}, structured: true, fields: []string{"user"}, want: false},
+		{name: "structured view off", search: `"msg"`, line: logLine{text: `{"msg":"request failed"}`, entry: entry}, timestamps: logTimestampsRaw, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lv := NewLogsViewer()
+			lv.timestamps = tt.timestamps
+			lv.structured = tt.structured
+			lv.fields = tt.fields
+			lv.setSearch(tt.search)
+			if got := lv.searchMatches(tt.line); got != tt.want {
+				t.Errorf("searchMatches(%q) = %v, want %v", tt.search, got, tt.want)
+			}
+		})
+	}
+}
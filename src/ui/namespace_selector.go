@@ -89,6 +89,11 @@ func (ns *NamespaceSelector) GetSelectedNamespaceRaw() string {
 	return ns.selectedNamespace
 }
 
+// SetSelectedNamespace selects a namespace without opening the selector; "" means all namespaces
+func (ns *NamespaceSelector) SetSelectedNamespace(namespace string) {
+	ns.selectedNamespace = namespace
+}
+
 func (ns *NamespaceSelector) MoveUp() {
 	if ns.cursor > 0 {
 		ns.cursor--
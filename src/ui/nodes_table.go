@@ -5,109 +5,175 @@ import (
 	"strings"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"peek/src/k8s"
 	"peek/src/styles"
 )
 
+// NodesLoadedMsg delivers listed nodes to the nodes table
+type NodesLoadedMsg struct {
+	nodes []k8s.NodeInfo
+	err   error
+}
+
 type NodesTable struct {
+	tableData
 	nodes       []k8s.NodeInfo
-	lastUpdate  time.Time
 	kubeConfig  *k8s.KubeConfig
 	contextName string
-	isLoading   bool
-	error       error
+	table       *Table
 }
 
 func NewNodesTable(kubeConfig *k8s.KubeConfig, contextName string) *NodesTable {
 	return &NodesTable{
+		tableData:   tableData{isLoading: true},
 		kubeConfig:  kubeConfig,
 		contextName: contextName,
-		isLoading:   true,
+		table: NewTable("nodes", []Column{
+			{Title: "NAME", Width: 20, Flex: 3},
+			{Title: "STATUS", Width: 6, MaxWidth: 10},
+			{Title: "ROLES", Width: 10, MaxWidth: 30, Flex: 1},
+			{Title: "AGE", Width: 3},
+			{Title: "VERSION", Width: 7, MaxWidth: 20},
+			{Title: "OS", Width: 5, MaxWidth: 10},
+			{Title: "ARCH", Width: 5, MaxWidth: 8},
+			{Title: "CPU USE", Width: 7},
+			{Title: "MEM USE", Width: 7},
+			{Title: "CPU REQ/LIM", Width: 11},
+			{Title: "MEM REQ/LIM", Width: 11},
+			{Title: "MEMORY", Width: 6},
+		}),
 	}
 }
 
-func (nt *NodesTable) Update() error {
-	if nt.kubeConfig == nil {
-		return fmt.Errorf("kubeconfig not available")
+// Load returns a command that lists the nodes, or nil while a load is already running
+func (nt *NodesTable) Load() tea.Cmd {
+	if nt.kubeConfig == nil || nt.running {
+		return nil
 	}
 
-	nt.isLoading = true
-	nt.error = nil
+	nt.startLoad(true)
 
-	nodes, err := nt.kubeConfig.GetNodes(nt.contextName)
-	if err != nil {
-		nt.error = err
-		nt.isLoading = false
-		return err
+	kubeConfig, contextName := nt.kubeConfig, nt.contextName
+	return func() tea.Msg {
+		nodes, err := kubeConfig.GetNodes(contextName)
+		return NodesLoadedMsg{nodes: nodes, err: err}
 	}
+}
 
-	nt.nodes = nodes
-	nt.lastUpdate = time.Now()
-	nt.isLoading = false
-	return nil
+// FinishLoad shows listed nodes
+func (nt *NodesTable) FinishLoad(msg NodesLoadedMsg) {
+	if msg.err != nil {
+		nt.finishLoad(msg.err)
+		return
+	}
+	nt.nodes = msg.nodes
+	nt.finishLoad(nil)
 }
 
-func (nt *NodesTable) ShouldUpdate() bool {
-	// Update every 30 seconds or if never updated
-	return time.Since(nt.lastUpdate) > 30*time.Second
+func (nt *NodesTable) MoveUp() {
+	nt.table.MoveUp()
 }
 
-func (nt *NodesTable) Render() string {
-	var b strings.Builder
+func (nt *NodesTable) MoveDown() {
+	nt.table.MoveDown()
+}
 
-	if nt.isLoading && len(nt.nodes) == 0 {
-		b.WriteString(styles.NormalStyle.Render("Loading nodes..."))
-		return b.String()
-	}
+// SelectNode puts the cursor on a node, waiting for the next refresh if it is not loaded yet
+func (nt *NodesTable) SelectNode(name string) {
+	nt.table.SelectKey(name)
+}
 
-	if nt.error != nil {
-		errorStyle := styles.NormalStyle.Foreground(lipgloss.Color("196"))
-		b.WriteString(errorStyle.Render(fmt.Sprintf("Error loading nodes: %v", nt.error)))
-		return b.String()
+// Render draws the nodes table in width by height
+func (nt *NodesTable) Render(width, height int) string {
+	if state := nt.renderState("nodes", len(nt.nodes) == 0); state != "" {
+		return state
 	}
 
 	if len(nt.nodes) == 0 {
-		b.WriteString(styles.NormalStyle.Render("No nodes found"))
-		return b.String()
+		return styles.NormalStyle.Render("No nodes found")
 	}
 
-	// Table header
-	headerStyle := styles.NormalStyle.Bold(true).Underline(true)
-	header := fmt.Sprintf("%-20s %-10s %-15s %-8s %-12s %-10s %-8s %s",
-		"NAME", "STATUS", "ROLES", "AGE", "VERSION", "OS", "ARCH", "MEMORY")
-	b.WriteString(headerStyle.Render(header) + "\n")
-
 	// Table rows
-	for i, node := range nt.nodes {
-		// Truncate and format fields
-		name := truncateString(node.Name, 20)
-		status := truncateString(node.Status, 10)
-		roles := truncateString(strings.Join(node.Roles, ","), 15)
-		age := truncateString(node.Age, 8)
-		version := truncateString(node.Version, 12)
-		os := truncateString(node.OS, 10)
-		arch := truncateString(node.Architecture, 8)
-		memory := truncateString(node.MemCapacity, 12)
-
-		row := fmt.Sprintf("%-20s %-10s %-15s %-8s %-12s %-10s %-8s %s",
-			name, status, roles, age, version, os, arch, memory)
+	overcommitted := 0
+	rows := make([]TableRow, 0, len(nt.nodes))
+	for _, node := range nt.nodes {
+		cpuUse, memUse := "n/a", "n/a"
+		var cpuValue, memValue any
+		if node.Usage != nil {
+			cpuValue, memValue = node.Usage.CPU, node.Usage.Memory
+			cpuUse = formatUsage(k8s.FormatMilliCPU(node.Usage.CPU), node.Usage.CPU, node.CPUAllocatable)
+			memUse = formatUsage(k8s.FormatBytes(node.Usage.Memory), node.Usage.Memory, node.MemAllocatable)
+		}
+
+		allocation := node.Allocation
+		cpuReqLim, memReqLim := "n/a", "n/a"
+		if node.AllocationError == nil {
+			cpuReqLim = fmt.Sprintf("%s/%s",
+				k8s.FormatPercentage(allocation.CPURequests, allocation.CPUAllocatable),
+				k8s.FormatPercentage(allocation.CPULimits, allocation.CPUAllocatable))
+			memReqLim = fmt.Sprintf("%s/%s",
+				k8s.FormatPercentage(allocation.MemRequests, allocation.MemAllocatable),
+				k8s.FormatPercentage(allocation.MemLimits, allocation.MemAllocatable))
+		}
 
 		// Color based on status
 		var rowStyle lipgloss.Style
-		if node.Ready {
-			rowStyle = styles.NormalStyle.Foreground(lipgloss.Color("46")) // Green
-		} else {
+		if !node.Ready {
 			rowStyle = styles.NormalStyle.Foreground(lipgloss.Color("196")) // Red
+		} else if allocation.Overcommitted() {
+			rowStyle = styles.NormalStyle.Foreground(lipgloss.Color("214")) // Orange
+			overcommitted++
+		} else {
+			rowStyle = styles.NormalStyle.Foreground(lipgloss.Color("46")) // Green
 		}
 
-		b.WriteString(rowStyle.Render(row))
-		if i < len(nt.nodes)-1 {
-			b.WriteString("\n")
-		}
+		rows = append(rows, TableRow{
+			Key: node.Name,
+			Cells: []string{node.Name, node.Status, strings.Join(node.Roles, ","), node.Age, node.Version,
+				node.OS, node.Architecture, cpuUse, memUse, cpuReqLim, memReqLim, node.MemCapacity},
+			Values: []any{nil, nil, nil, time.Since(node.CreationTime), nil, nil, nil, cpuValue, memValue,
+				allocationShare(allocation.CPURequests, allocation.CPUAllocatable),
+				allocationShare(allocation.MemRequests, allocation.MemAllocatable), node.MemCapacityBytes},
+			Style: rowStyle,
+		})
+	}
+	nt.table.SetRows(rows)
+
+	// Controls info
+	controlsStyle := styles.NormalStyle.Foreground(lipgloss.Color("240"))
+	controls := controlsStyle.Render("Auto-refresh every 30s • o/O to sort") + "\n\n"
+	height = max(height-2, 0)
+
+	var warning string
+	warningStyle := styles.NormalStyle.Foreground(lipgloss.Color("214"))
+	if err := nt.nodes[0].AllocationError; err != nil {
+		// Every node failed the same way
+		warning = "\n\n" + warningStyle.Render(fmt.Sprintf("⚠ Requests and limits unavailable: %v", err))
+		height = max(height-2, 0)
+	} else if overcommitted > 0 {
+		warning = "\n\n" + warningStyle.Render(fmt.Sprintf("⚠ %d node(s) overcommitted: container limits exceed allocatable resources", overcommitted))
+		height = max(height-2, 0)
 	}
 
-	return b.String()
+	return controls + nt.table.Render(width, height) + warning
+}
+
+// formatUsage formats a usage value with its share of the given total, e.g. "250m (12%)"
+func formatUsage(value string, used, total int64) string {
+	if total == 0 {
+		return value
+	}
+	return fmt.Sprintf("%s (%d%%)", value, used*100/total)
+}
+
+// allocationShare is the share of allocatable that is requested, for sorting
+func allocationShare(requested, allocatable int64) float64 {
+	if allocatable == 0 {
+		return 0
+	}
+	return float64(requested) / float64(allocatable)
 }
 
 func truncateString(s string, maxLen int) string {
@@ -0,0 +1,384 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"peek/src/k8s"
+	"peek/src/styles"
+)
+
+// OwnerChainMsg delivers the resolved owner chain to the owner tree
+type OwnerChainMsg struct {
+	run   int
+	chain []k8s.OwnerNode
+	err   error
+}
+
+// OwnerChildrenMsg delivers the listed children of an object in the owner tree
+type OwnerChildrenMsg struct {
+	run      int
+	key      string
+	children []k8s.OwnerNode
+	err      error
+}
+
+// ownerTreeNode is an object in the owner tree. Nodes on the owner chain start with only the next
+// link of the chain as their child; listing their children fills in the siblings.
+type ownerTreeNode struct {
+	node     k8s.OwnerNode
+	parent   *ownerTreeNode
+	children []*ownerTreeNode
+	expanded bool
+	loaded   bool // every child is listed, not only the chain
+	loading  bool
+	err      error
+}
+
+// OwnerTreeView shows the chain of owners of an object, e.g. Pod → ReplicaSet → Deployment, with
+// the status of each level, and lists the children of any level on demand
+type OwnerTreeView struct {
+	isOpen      bool
+	kubeConfig  *k8s.KubeConfig
+	contextName string
+	start       k8s.ObjectReference
+	root        *ownerTreeNode
+	nodes       map[string]*ownerTreeNode
+	chain       []k8s.OwnerNode
+	error       error
+	isLoading   bool
+	runs        int // chain fetches started, so results for an earlier tree are dropped
+	table       *Table
+}
+
+func NewOwnerTreeView() *OwnerTreeView {
+	return &OwnerTreeView{
+		table: NewTable("objects", []Column{
+			{Title: "OBJECT", Width: 30, Flex: 3},
+			{Title: "STATUS", Width: 8, MaxWidth: 20},
+			{Title: "READY", Width: 5},
+			{Title: "AGE", Width: 4},
+			{Title: "DETAIL", Width: 10, Flex: 2},
+		}),
+	}
+}
+
+// Open shows the owners of an object; the returned command resolves them
+func (ot *OwnerTreeView) Open(kubeConfig *k8s.KubeConfig, contextName string, ref k8s.ObjectReference) tea.Cmd {
+	ot.isOpen = true
+	ot.kubeConfig = kubeConfig
+	ot.contextName = contextName
+	ot.start = ref
+	ot.root = nil
+	ot.chain = nil
+	ot.nodes = make(map[string]*ownerTreeNode)
+	ot.table.SetRows(nil)
+	ot.table.SelectKey(ownerNodeKey(ref))
+	return ot.Refresh()
+}
+
+// Refresh resolves the owner chain again; listed children have to be listed again
+func (ot *OwnerTreeView) Refresh() tea.Cmd {
+	if key := ot.table.SelectedKey(); key != "" {
+		ot.table.SelectKey(key)
+	}
+	ot.error = nil
+	ot.isLoading = true
+	ot.runs++
+	run, kubeConfig, contextName, start := ot.runs, ot.kubeConfig, ot.contextName, ot.start
+	return func() tea.Msg {
+		chain, err := kubeConfig.GetOwnerChain(contextName, start)
+		return OwnerChainMsg{run: run, chain: chain, err: err}
+	}
+}
+
+// FinishChain shows a resolved owner chain, unless the tree was closed or refreshed since
+func (ot *OwnerTreeView) FinishChain(msg OwnerChainMsg) {
+	if !ot.isOpen || msg.run != ot.runs {
+		return
+	}
+	ot.isLoading = false
+	if msg.err != nil {
+		ot.error = msg.err
+		return
+	}
+	chain := msg.chain
+
+	// The chain runs from the object up, the tree from the top owner down
+	ot.chain = chain
+	ot.nodes = make(map[string]*ownerTreeNode)
+	var parent *ownerTreeNode
+	for i := len(chain) - 1; i >= 0; i-- {
+		node := &ownerTreeNode{node: chain[i], parent: parent, expanded: true}
+		ot.nodes[ownerNodeKey(chain[i].Ref)] = node
+		if parent == nil {
+			ot.root = node
+		} else {
+			parent.children = []*ownerTreeNode{node}
+		}
+		parent = node
+	}
+	ot.setRows()
+}
+
+func (ot *OwnerTreeView) Close() {
+	ot.isOpen = false
+	ot.runs++
+	ot.root = nil
+	ot.start = k8s.ObjectReference{}
+}
+
+func (ot *OwnerTreeView) IsOpen() bool {
+	return ot.isOpen
+}
+
+func (ot *OwnerTreeView) MoveUp() {
+	ot.table.MoveUp()
+}
+
+func (ot *OwnerTreeView) MoveDown() {
+	ot.table.MoveDown()
+}
+
+// GetSelected returns the object under the cursor, nil while the tree is loading
+func (ot *OwnerTreeView) GetSelected() *k8s.ObjectReference {
+	if node := ot.selected(); node != nil && !node.node.Missing {
+		ref := node.node.Ref
+		return &ref
+	}
+	return nil
+}
+
+func (ot *OwnerTreeView) selected() *ownerTreeNode {
+	return ot.nodes[ot.table.SelectedKey()]
+}
+
+// Owner moves the cursor up to the owner of the selected object
+func (ot *OwnerTreeView) Owner() {
+	if node := ot.selected(); node != nil && node.parent != nil {
+		ot.table.SelectKey(ownerNodeKey(node.parent.node.Ref))
+	}
+}
+
+// Children moves the cursor down to the first child of the selected object; the returned command
+// lists the children when they are not listed yet
+func (ot *OwnerTreeView) Children() tea.Cmd {
+	node := ot.selected()
+	if node == nil || !canOwn(node) {
+		return nil
+	}
+	node.expanded = true
+	if node.loaded {
+		if len(node.children) > 0 {
+			ot.table.SelectKey(ownerNodeKey(node.children[0].node.Ref))
+		}
+		ot.setRows()
+		return nil
+	}
+	if node.loading {
+		return nil
+	}
+
+	node.loading = true
+	node.err = nil
+	ot.setRows()
+	run, kubeConfig, contextName, ref := ot.runs, ot.kubeConfig, ot.contextName, node.node.Ref
+	return func() tea.Msg {
+		children, err := kubeConfig.GetOwnedObjects(contextName, ref)
+		return OwnerChildrenMsg{run: run, key: ownerNodeKey(ref), children: children, err: err}
+	}
+}
+
+// ToggleExpand collapses the selected object's children or shows them again
+func (ot *OwnerTreeView) ToggleExpand() {
+	if node := ot.selected(); node != nil && len(node.children) > 0 {
+		node.expanded = !node.expanded
+		ot.setRows()
+	}
+}
+
+// FinishChildren shows the listed children of an object, unless the tree was closed or refreshed since
+func (ot *OwnerTreeView) FinishChildren(msg OwnerChildrenMsg) {
+	node := ot.nodes[msg.key]
+	if !ot.isOpen || msg.run != ot.runs || node == nil {
+		return
+	}
+	node.loading = false
+	if msg.err != nil {
+		node.err = msg.err
+		ot.setRows()
+		return
+	}
+	children := msg.children
+
+	// Keep the nodes already shown, so the chain below stays expanded
+	existing := make(map[string]*ownerTreeNode)
+	for _, child := range node.children {
+		existing[ownerNodeKey(child.node.Ref)] = child
+	}
+	node.children = nil
+	for _, child := range children {
+		key := ownerNodeKey(child.Ref)
+		childNode, ok := existing[key]
+		if ok {
+			childNode.node = child
+		} else {
+			childNode = &ownerTreeNode{node: child, parent: node}
+			ot.nodes[key] = childNode
+		}
+		node.children = append(node.children, childNode)
+	}
+	node.loaded = true
+	if len(node.children) > 0 && ot.table.SelectedKey() == ownerNodeKey(node.node.Ref) {
+		ot.table.SelectKey(ownerNodeKey(node.children[0].node.Ref))
+	}
+	ot.setRows()
+}
+
+// setRows lays out the expanded part of the tree as table rows
+func (ot *OwnerTreeView) setRows() {
+	var rows []TableRow
+	var walk func(node *ownerTreeNode, prefix string, last bool)
+	walk = func(node *ownerTreeNode, prefix string, last bool) {
+		branch, indent := "", ""
+		if node.parent != nil {
+			branch, indent = "├─ ", "│  "
+			if last {
+				branch, indent = "└─ ", "   "
+			}
+		}
+		rows = append(rows, ot.renderRow(node, prefix+branch))
+		if !node.expanded {
+			return
+		}
+		for i, child := range node.children {
+			walk(child, prefix+indent, i == len(node.children)-1)
+		}
+	}
+	if ot.root != nil {
+		walk(ot.root, "", true)
+	}
+	ot.table.SetRows(rows)
+}
+
+func (ot *OwnerTreeView) renderRow(node *ownerTreeNode, prefix string) TableRow {
+	n := node.node
+	marker := "  "
+	if canOwn(node) {
+		marker = "▸ "
+		if node.expanded && (node.loaded || len(node.children) > 0) {
+			marker = "▾ "
+		}
+	}
+
+	detail := n.Detail
+	switch {
+	case node.loading:
+		detail = "listing children..."
+	case node.err != nil:
+		detail = "✗ " + node.err.Error()
+	case node.loaded && len(node.children) == 0:
+		detail = joinDetailText(detail, "no children")
+	}
+
+	age := "-"
+	if !n.Created.IsZero() {
+		age = formatAppAge(n.Created)
+	}
+	color := getStatusColor(n.Status)
+	if n.Ref.Kind == "Pod" {
+		color = getPodStatusColor(n.Status)
+	}
+	if n.Missing {
+		color = "240"
+		detail = "owner no longer exists"
+	}
+	style := styles.NormalStyle.Foreground(lipgloss.Color(color))
+	if ownerNodeKey(n.Ref) == ownerNodeKey(ot.start) {
+		style = style.Bold(true)
+	}
+
+	return TableRow{
+		Key:   ownerNodeKey(n.Ref),
+		Cells: []string{prefix + marker + n.Ref.Kind + "/" + n.Ref.Name, n.Status, n.Ready, age, detail},
+		Style: style,
+	}
+}
+
+func (ot *OwnerTreeView) Render(screenWidth, screenHeight int) string {
+	if !ot.isOpen {
+		return ""
+	}
+
+	width := max(screenWidth-4, 60)
+	height := max(screenHeight-4, 15)
+	innerWidth := width - 2
+
+	var content strings.Builder
+
+	headerStyle := styles.NormalStyle.Bold(true).Foreground(lipgloss.Color("39"))
+	content.WriteString(headerStyle.Render(fmt.Sprintf("🌳 Owners of %s: %s", ot.start.Kind, ot.start.Name)) + "\n")
+
+	// The chain as a breadcrumb from the top owner down
+	statusStyle := styles.NormalStyle.Foreground(lipgloss.Color("245"))
+	status := "Namespace: " + ot.start.Namespace
+	if len(ot.chain) > 0 {
+		links := make([]string, len(ot.chain))
+		for i, node := range ot.chain {
+			links[len(ot.chain)-1-i] = node.Ref.Kind
+		}
+		status += " • " + strings.Join(links, " → ")
+	}
+	if ot.isLoading && ot.root != nil {
+		status += " • Refreshing..."
+	}
+	content.WriteString(statusStyle.Render(status) + "\n")
+
+	controlsStyle := styles.NormalStyle.Foreground(lipgloss.Color("240"))
+	controls := "↑↓=move ←/u=owner →/enter=children space=collapse g=go to y=yaml r=refresh Esc=close"
+	content.WriteString(controlsStyle.Render(controls) + "\n\n")
+
+	switch {
+	case ot.error != nil:
+		errorStyle := styles.NormalStyle.Foreground(lipgloss.Color("196"))
+		content.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", ot.error)))
+	case ot.root == nil:
+		content.WriteString(styles.NormalStyle.Render("Resolving owners..."))
+	default:
+		content.WriteString(ot.table.Render(innerWidth, height-6)) // Reserve space for header and controls
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("39")).
+		Background(lipgloss.Color("235")).
+		Padding(1).
+		Width(width).
+		Height(height)
+
+	return lipgloss.Place(
+		screenWidth,
+		screenHeight,
+		lipgloss.Center,
+		lipgloss.Center,
+		boxStyle.Render(content.String()),
+	)
+}
+
+// canOwn reports whether a node may have children; pods own nothing peek follows
+func canOwn(node *ownerTreeNode) bool {
+	return node.node.Ref.Kind != "Pod" && !node.node.Missing
+}
+
+func ownerNodeKey(ref k8s.ObjectReference) string {
+	return ref.Kind + "/" + ref.Namespace + "/" + ref.Name
+}
+
+func joinDetailText(detail, more string) string {
+	if detail == "" {
+		return more
+	}
+	return detail + ", " + more
+}
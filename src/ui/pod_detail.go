@@ -0,0 +1,449 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"peek/src/k8s"
+	"peek/src/styles"
+)
+
+// PodDescribedMsg delivers a fetched pod description to the detail view
+type PodDescribedMsg struct {
+	run         int
+	description *k8s.PodDescription
+	err         error
+}
+
+// PodDetailView shows a describe-style view of a pod: its conditions, QoS class, containers with
+// their state, last termination, probes and mounts, its node and tolerations, and its events
+type PodDetailView struct {
+	isOpen       bool
+	pod          k8s.PodInfo
+	kubeConfig   *k8s.KubeConfig
+	contextName  string
+	description  *k8s.PodDescription
+	error        error
+	isLoading    bool
+	runs         int // fetches started, so results of an earlier fetch are dropped
+	scrollOffset int
+	lineCount    int // lines of the last rendered body, to bound scrolling
+	pageSize     int
+}
+
+func NewPodDetailView() *PodDetailView {
+	return &PodDetailView{pageSize: 20}
+}
+
+// Open shows the detail of a pod; the returned command fetches it
+func (pd *PodDetailView) Open(kubeConfig *k8s.KubeConfig, contextName string, pod k8s.PodInfo) tea.Cmd {
+	pd.isOpen = true
+	pd.pod = pod
+	pd.kubeConfig = kubeConfig
+	pd.contextName = contextName
+	pd.description = nil
+	pd.scrollOffset = 0
+	return pd.Refresh()
+}
+
+// Refresh fetches the pod and its events again, keeping the scroll position
+func (pd *PodDetailView) Refresh() tea.Cmd {
+	pd.error = nil
+	pd.isLoading = true
+	pd.runs++
+	run, kubeConfig, contextName, pod := pd.runs, pd.kubeConfig, pd.contextName, pd.pod
+	return func() tea.Msg {
+		description, err := kubeConfig.DescribePod(contextName, pod.Namespace, pod.Name)
+		return PodDescribedMsg{run: run, description: description, err: err}
+	}
+}
+
+// Finish shows a fetched description, unless the view was closed or fetched again since
+func (pd *PodDetailView) Finish(msg PodDescribedMsg) {
+	if !pd.isOpen || msg.run != pd.runs {
+		return
+	}
+	pd.isLoading = false
+	if msg.err != nil {
+		pd.error = msg.err
+		return
+	}
+	pd.description = msg.description
+}
+
+func (pd *PodDetailView) Close() {
+	pd.isOpen = false
+	pd.runs++
+	pd.description = nil
+	pd.scrollOffset = 0
+	pd.isLoading = false
+}
+
+func (pd *PodDetailView) IsOpen() bool {
+	return pd.isOpen
+}
+
+// GetPod returns the pod the view describes
+func (pd *PodDetailView) GetPod() k8s.PodInfo {
+	return pd.pod
+}
+
+func (pd *PodDetailView) ScrollUp() {
+	if pd.scrollOffset > 0 {
+		pd.scrollOffset--
+	}
+}
+
+func (pd *PodDetailView) ScrollDown() {
+	if pd.scrollOffset < pd.maxScroll() {
+		pd.scrollOffset++
+	}
+}
+
+func (pd *PodDetailView) PageUp() {
+	pd.scrollOffset = max(pd.scrollOffset-pd.pageSize, 0)
+}
+
+func (pd *PodDetailView) PageDown() {
+	pd.scrollOffset = min(pd.scrollOffset+pd.pageSize, pd.maxScroll())
+}
+
+func (pd *PodDetailView) maxScroll() int {
+	return max(pd.lineCount-pd.pageSize, 0)
+}
+
+func (pd *PodDetailView) Render(screenWidth, screenHeight int) string {
+	if !pd.isOpen {
+		return ""
+	}
+
+	// Calculate dimensions
+	width := max(screenWidth-4, 60)
+	height := max(screenHeight-4, 15)
+	innerWidth := width - 2
+
+	var content strings.Builder
+
+	// Header
+	headerStyle := styles.NormalStyle.Bold(true).Foreground(lipgloss.Color("39"))
+	content.WriteString(headerStyle.Render(fmt.Sprintf("🔎 Pod: %s", pd.pod.Name)) + "\n")
+
+	// Status line
+	statusStyle := styles.NormalStyle.Foreground(lipgloss.Color("245"))
+	status := fmt.Sprintf("Namespace: %s", pd.pod.Namespace)
+	if pd.description != nil {
+		status += " • Status: " + pd.description.Status
+		if pd.description.QoSClass != "" {
+			status += " • QoS: " + pd.description.QoSClass
+		}
+	}
+	if pd.isLoading && pd.description != nil {
+		status += " • Refreshing..."
+	}
+	content.WriteString(statusStyle.Render(status) + "\n")
+
+	// Controls
+	controlsStyle := styles.NormalStyle.Foreground(lipgloss.Color("240"))
+	controls := "↑↓=scroll PgUp/PgDn=page r=refresh l=logs y=yaml D=debug c=copy u=owners Esc=close"
+	content.WriteString(controlsStyle.Render(controls) + "\n\n")
+
+	// Content
+	switch {
+	case pd.error != nil:
+		errorStyle := styles.NormalStyle.Foreground(lipgloss.Color("196"))
+		content.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", pd.error)))
+	case pd.description == nil:
+		content.WriteString(styles.NormalStyle.Render("Loading pod details..."))
+	default:
+		content.WriteString(pd.renderBody(innerWidth, height-6)) // Reserve space for header and controls
+	}
+
+	// Create the box style
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("39")).
+		Background(lipgloss.Color("235")).
+		Padding(1).
+		Width(width).
+		Height(height)
+
+	// Center the box on the screen
+	return lipgloss.Place(
+		screenWidth,
+		screenHeight,
+		lipgloss.Center,
+		lipgloss.Center,
+		boxStyle.Render(content.String()),
+	)
+}
+
+// renderBody lays out every section and shows the lines that fit from the scroll position on
+func (pd *PodDetailView) renderBody(width, maxLines int) string {
+	var lines []string
+	for _, section := range []string{
+		pd.renderOverview(width),
+		pd.renderConditions(width),
+		pd.renderContainers(width),
+		pd.renderTolerations(width),
+		pd.renderEvents(width),
+	} {
+		lines = append(lines, strings.Split(section, "\n")...)
+		lines = append(lines, "")
+	}
+
+	// One line goes to the scroll indicator
+	pd.pageSize = max(maxLines-1, 1)
+	pd.lineCount = len(lines)
+	pd.scrollOffset = min(pd.scrollOffset, pd.maxScroll())
+	end := min(pd.scrollOffset+pd.pageSize, len(lines))
+
+	result := strings.Join(lines[pd.scrollOffset:end], "\n")
+	if len(lines) > pd.pageSize {
+		scrollStyle := styles.NormalStyle.Foreground(lipgloss.Color("240")).Italic(true)
+		result += "\n" + scrollStyle.Render(fmt.Sprintf("Showing lines %d-%d of %d", pd.scrollOffset+1, end, len(lines)))
+	}
+	return result
+}
+
+func (pd *PodDetailView) renderOverview(width int) string {
+	d := pd.description
+	var b strings.Builder
+	b.WriteString(styles.HeaderStyle.Render("📦 Overview"))
+
+	node := d.Node
+	if node == "" {
+		node = "<not scheduled>"
+	} else if d.NodeIP != "" {
+		node += " (" + d.NodeIP + ")"
+	}
+	fields := [][2]string{
+		{"Node", node},
+		{"Pod IP", orNone(d.PodIP)},
+		{"Created", formatDetailTime(d.CreationTime)},
+		{"Started", formatDetailTime(d.StartTime)},
+		{"QoS Class", orNone(d.QoSClass)},
+		{"Service Account", orNone(d.ServiceAccount)},
+	}
+	if d.Priority != "" {
+		fields = append(fields, [2]string{"Priority Class", d.Priority})
+	}
+	if d.ReadinessGates != "" {
+		fields = append(fields, [2]string{"Readiness Gates", d.ReadinessGates})
+	}
+	fields = append(fields,
+		[2]string{"Controlled By", orNone(strings.Join(d.Owners, ", "))},
+		[2]string{"Labels", orNone(formatLabelList(d.Labels))},
+	)
+
+	labelStyle := styles.NormalStyle.Bold(true)
+	for _, field := range fields {
+		b.WriteString("\n" + fitCell(labelStyle.Render(fmt.Sprintf("%-16s", field[0]+":"))+" "+field[1], width, false))
+	}
+	return b.String()
+}
+
+func (pd *PodDetailView) renderConditions(width int) string {
+	var b strings.Builder
+	b.WriteString(styles.HeaderStyle.Render("✅ Conditions"))
+	if len(pd.description.Conditions) == 0 {
+		b.WriteString("\n" + styles.NormalStyle.Render("No conditions reported"))
+		return b.String()
+	}
+
+	table := NewTable("conditions", []Column{
+		{Title: "TYPE", Width: 12, MaxWidth: 30},
+		{Title: "STATUS", Width: 6},
+		{Title: "LAST TRANSITION", Width: 15, MaxWidth: 30},
+		{Title: "REASON", Width: 6, MaxWidth: 30},
+		{Title: "MESSAGE", Width: 10, Flex: 1},
+	})
+	table.ShowCursor(false)
+	var rows []TableRow
+	for _, condition := range pd.description.Conditions {
+		color := "46"
+		if condition.Status != "True" {
+			color = "226"
+		}
+		rows = append(rows, TableRow{
+			Cells: []string{condition.Type, condition.Status, formatDetailTime(condition.LastTransitionTime),
+				condition.Reason, condition.Message},
+			Style: styles.NormalStyle.Foreground(lipgloss.Color(color)),
+		})
+	}
+	table.SetRows(rows)
+	b.WriteString("\n" + table.Render(width, 0))
+	return b.String()
+}
+
+func (pd *PodDetailView) renderContainers(width int) string {
+	var b strings.Builder
+	b.WriteString(styles.HeaderStyle.Render("🐳 Containers"))
+
+	labelStyle := styles.NormalStyle.Bold(true)
+	mutedStyle := styles.NormalStyle.Foreground(lipgloss.Color("245"))
+	line := func(indent int, text string) {
+		b.WriteString("\n" + fitCell(strings.Repeat(" ", indent)+text, width, false))
+	}
+
+	for _, container := range pd.description.Containers {
+		name := container.Name
+		if container.Type != k8s.ContainerTypeApp {
+			name += " (" + container.Type + ")"
+		}
+		line(0, labelStyle.Render("▸ "+name)+" "+mutedStyle.Render(container.Image))
+		if container.TargetContainer != "" {
+			line(2, labelStyle.Render("Target:")+" "+container.TargetContainer)
+		}
+
+		line(2, labelStyle.Render("State:")+" "+formatContainerState(container))
+		if container.Termination != nil {
+			line(4, formatTermination(*container.Termination))
+		}
+		if container.Message != "" {
+			line(4, mutedStyle.Render(container.Message))
+		}
+		line(2, fmt.Sprintf("%s %t • %s %d", labelStyle.Render("Ready:"), container.Ready,
+			labelStyle.Render("Restarts:"), container.RestartCount))
+		if container.LastTermination != nil {
+			line(2, labelStyle.Render("Last Termination:")+" "+formatTermination(*container.LastTermination))
+			if container.LastTermination.Message != "" {
+				line(4, mutedStyle.Render(container.LastTermination.Message))
+			}
+		}
+
+		for _, probe := range container.Probes {
+			name, detail, _ := strings.Cut(probe, ": ")
+			line(2, labelStyle.Render(name+":")+" "+detail)
+		}
+
+		if len(container.Mounts) > 0 {
+			line(2, labelStyle.Render("Mounts:"))
+			for _, mount := range container.Mounts {
+				line(4, mount)
+			}
+		}
+	}
+	return b.String()
+}
+
+func (pd *PodDetailView) renderTolerations(width int) string {
+	var b strings.Builder
+	b.WriteString(styles.HeaderStyle.Render("🔐 Tolerations"))
+	if len(pd.description.Tolerations) == 0 {
+		b.WriteString("\n" + styles.NormalStyle.Render("<none>"))
+	}
+	for _, toleration := range pd.description.Tolerations {
+		b.WriteString("\n" + fitCell(toleration, width, false))
+	}
+	return b.String()
+}
+
+func (pd *PodDetailView) renderEvents(width int) string {
+	var b strings.Builder
+	b.WriteString(styles.HeaderStyle.Render("📰 Events (oldest first)"))
+	if pd.description.EventsError != nil {
+		errorStyle := styles.NormalStyle.Foreground(lipgloss.Color("196"))
+		b.WriteString("\n" + errorStyle.Render(fmt.Sprintf("Error: %v", pd.description.EventsError)))
+		return b.String()
+	}
+	if len(pd.description.Events) == 0 {
+		b.WriteString("\n" + styles.NormalStyle.Render("No events"))
+		return b.String()
+	}
+
+	table := NewTable("events", []Column{
+		{Title: "LAST SEEN", Width: 9},
+		{Title: "TYPE", Width: 7},
+		{Title: "REASON", Width: 8, MaxWidth: 25},
+		{Title: "FROM", Width: 6, MaxWidth: 25},
+		{Title: "COUNT", Width: 5},
+		{Title: "MESSAGE", Width: 10, Flex: 1},
+	})
+	table.ShowCursor(false)
+	var rows []TableRow
+	for _, event := range pd.description.Events {
+		rows = append(rows, TableRow{
+			Cells: []string{k8s.FormatTimeAgo(event.LastTimestamp) + " ago", event.Type, event.Reason,
+				event.Source, fmt.Sprintf("%d", event.Count), event.Message},
+			Style: styles.NormalStyle.Foreground(lipgloss.Color(k8s.GetEventColor(event.Type))),
+		})
+	}
+	table.SetRows(rows)
+	b.WriteString("\n" + table.Render(width, 0))
+	return b.String()
+}
+
+// formatContainerState describes the current state of a container in its state's color
+func formatContainerState(container k8s.ContainerDescription) string {
+	text := container.State
+	color := "252"
+	switch container.State {
+	case "Running":
+		color = "46"
+		if !container.StartedAt.IsZero() {
+			text += " since " + formatDetailTime(container.StartedAt)
+		}
+	case "Waiting":
+		// Back-offs and image pull errors show in red
+		if color = getPodStatusColor(container.Reason); color == "252" {
+			color = "226"
+		}
+		if container.Reason != "" {
+			text += ": " + container.Reason
+		}
+	case "Terminated":
+		color = "46"
+		if container.Termination != nil && container.Termination.ExitCode != 0 {
+			color = "196"
+		}
+		if container.Reason != "" {
+			text += ": " + container.Reason
+		}
+	}
+	return styles.NormalStyle.Foreground(lipgloss.Color(color)).Render(text)
+}
+
+// formatTermination describes how a container instance ended, e.g.
+// "OOMKilled • exit code 137 • signal 9 • finished 2026-01-02 15:04:05 (5m ago)"
+func formatTermination(termination k8s.ContainerTermination) string {
+	parts := []string{orNone(termination.Reason), fmt.Sprintf("exit code %d", termination.ExitCode)}
+	if termination.Signal != 0 {
+		parts = append(parts, fmt.Sprintf("signal %d", termination.Signal))
+	}
+	parts = append(parts, "finished "+formatDetailTime(termination.FinishedAt))
+
+	color := "46"
+	if termination.ExitCode != 0 {
+		color = "196"
+	}
+	return styles.NormalStyle.Foreground(lipgloss.Color(color)).Render(strings.Join(parts, " • "))
+}
+
+// formatDetailTime shows a local timestamp and how long ago it was
+func formatDetailTime(t time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return fmt.Sprintf("%s (%s ago)", t.Local().Format("2006-01-02 15:04:05"), k8s.FormatTimeAgo(t))
+}
+
+// formatLabelList joins labels as key=value in key order
+func formatLabelList(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
@@ -6,81 +6,121 @@ import (
 	"strings"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"peek/src/k8s"
 	"peek/src/styles"
 )
 
+// PodsLoadedMsg delivers listed pods to the pods table
+type PodsLoadedMsg struct {
+	generation int
+	selector   *k8s.Selector
+	pods       []k8s.PodInfo
+	err        error
+}
+
 type PodsTable struct {
-	pods            []k8s.PodInfo
-	filteredPods    []k8s.PodInfo
-	lastUpdate      time.Time
-	kubeConfig      *k8s.KubeConfig
-	contextName     string
-	namespace       string
-	isLoading       bool
-	error           error
-	cursor          int
-	searchMode      bool
-	searchQuery     string
-	selectedPod     *k8s.PodInfo
+	tableData
+	pods         []k8s.PodInfo
+	filteredPods []k8s.PodInfo
+	kubeConfig   *k8s.KubeConfig
+	contextName  string
+	namespace    string
+	searchMode   bool
+	searchQuery  string
+	selector     *k8s.Selector
+	generation   int // bumped when the namespace or selector changes, so loads started before are dropped
+	table        *Table
 }
 
 func NewPodsTable(kubeConfig *k8s.KubeConfig, contextName, namespace string) *PodsTable {
 	return &PodsTable{
+		tableData:   tableData{isLoading: true},
 		kubeConfig:  kubeConfig,
 		contextName: contextName,
 		namespace:   namespace,
-		isLoading:   true,
-		cursor:      0,
 		searchMode:  false,
 		searchQuery: "",
+		table: NewTable("pods", []Column{
+			{Title: "NAME", Width: 20, Flex: 3},
+			{Title: "NAMESPACE", Width: 10, MaxWidth: 30, Flex: 1},
+			{Title: "STATUS", Width: 8, MaxWidth: 26, Flex: 1},
+			{Title: "READY", Width: 5},
+			{Title: "RESTARTS", Width: 8},
+			{Title: "CPU", Width: 5},
+			{Title: "MEMORY", Width: 6},
+			{Title: "NODE", Width: 10, Flex: 1},
+			{Title: "AGE", Width: 3},
+		}),
 	}
 }
 
 func (pt *PodsTable) SetNamespace(namespace string) {
 	pt.namespace = namespace
+	pt.generation++
 	// Force refresh on next update check
-	pt.lastUpdate = time.Time{}
+	pt.invalidate()
 	// Clear pods to trigger loading state
 	pt.pods = []k8s.PodInfo{}
 	pt.filteredPods = []k8s.PodInfo{}
-	pt.cursor = 0
+	pt.table.SetCursor(0)
 }
 
-func (pt *PodsTable) Update() error {
-	if pt.kubeConfig == nil {
-		return fmt.Errorf("kubeconfig not available")
+// Load returns a command that lists the pods, or nil while a load is already running
+func (pt *PodsTable) Load() tea.Cmd {
+	if pt.kubeConfig == nil || pt.running {
+		return nil
 	}
 
 	// Only set loading to true if this is the first load (no existing pods)
-	if len(pt.pods) == 0 {
-		pt.isLoading = true
+	pt.startLoad(len(pt.pods) == 0)
+
+	kubeConfig, contextName, namespace, selector, generation := pt.kubeConfig, pt.contextName, pt.namespace, pt.selector, pt.generation
+	return func() tea.Msg {
+		pods, err := kubeConfig.GetPods(contextName, namespace, selector)
+		return PodsLoadedMsg{generation: generation, selector: selector, pods: pods, err: err}
 	}
-	pt.error = nil
+}
 
-	pods, err := pt.kubeConfig.GetPods(pt.contextName, pt.namespace)
-	if err != nil {
-		pt.error = err
-		pt.isLoading = false
-		return err
+// FinishLoad shows listed pods, unless the namespace or selector changed since the load started
+func (pt *PodsTable) FinishLoad(msg PodsLoadedMsg) {
+	if pt.selector != msg.selector || pt.generation != msg.generation {
+		// The load started for the pods listed now replaces this one
+		return
+	}
+	if msg.err != nil {
+		pt.finishLoad(msg.err)
+		return
 	}
 
 	// Sort pods by name
+	pods := msg.pods
 	sort.Slice(pods, func(i, j int) bool {
 		return pods[i].Name < pods[j].Name
 	})
 
 	pt.pods = pods
 	pt.filterPods()
-	pt.lastUpdate = time.Now()
-	pt.isLoading = false
+	pt.finishLoad(nil)
+}
+
+// SetSelector lists only the pods matching a label and field selector; an empty query lists all pods
+func (pt *PodsTable) SetSelector(query string) error {
+	selector, err := k8s.ParsePodSelector(query)
+	if err != nil {
+		return err
+	}
+	pt.selector = selector
+	pt.generation++
+	// Force refresh on next update check
+	pt.invalidate()
 	return nil
 }
 
-func (pt *PodsTable) ShouldUpdate() bool {
-	// Update every 15 seconds for pods (faster than other resources)
-	return time.Since(pt.lastUpdate) > 15*time.Second
+// GetSelector returns the selector the pods are listed with
+func (pt *PodsTable) GetSelector() string {
+	return pt.selector.String()
 }
 
 func (pt *PodsTable) ToggleSearchMode() {
@@ -88,14 +128,19 @@ func (pt *PodsTable) ToggleSearchMode() {
 	if !pt.searchMode {
 		pt.searchQuery = ""
 		pt.filterPods()
-		pt.cursor = 0
+		pt.table.SetCursor(0)
 	}
 }
 
+// FinishSearch stops typing the search and keeps filtering by it
+func (pt *PodsTable) FinishSearch() {
+	pt.searchMode = false
+}
+
 func (pt *PodsTable) UpdateSearch(query string) {
 	pt.searchQuery = query
 	pt.filterPods()
-	pt.cursor = 0
+	pt.table.SetCursor(0)
 }
 
 func (pt *PodsTable) filterPods() {
@@ -106,52 +151,91 @@ func (pt *PodsTable) filterPods() {
 
 	var filtered []k8s.PodInfo
 	query := strings.ToLower(pt.searchQuery)
-	
+
 	for _, pod := range pt.pods {
 		// Search in name, namespace, status, node
-		searchText := strings.ToLower(fmt.Sprintf("%s %s %s %s", 
+		searchText := strings.ToLower(fmt.Sprintf("%s %s %s %s",
 			pod.Name, pod.Namespace, pod.Status, pod.Node))
 		if strings.Contains(searchText, query) {
 			filtered = append(filtered, pod)
 		}
 	}
-	
+
 	pt.filteredPods = filtered
 }
 
+// SelectPod puts the cursor on a pod, waiting for the next refresh if it is not loaded yet
+func (pt *PodsTable) SelectPod(namespace, name string) {
+	// The pod may be hidden by the current search
+	pt.searchMode = false
+	pt.searchQuery = ""
+	pt.filterPods()
+
+	pt.table.SelectKey(namespace + "/" + name)
+}
+
 func (pt *PodsTable) MoveUp() {
-	if pt.cursor > 0 {
-		pt.cursor--
-	}
+	pt.table.MoveUp()
 }
 
 func (pt *PodsTable) MoveDown() {
-	if pt.cursor < len(pt.filteredPods)-1 {
-		pt.cursor++
+	pt.table.MoveDown()
+}
+
+// ToggleMark marks the pod under the cursor for a bulk action, or unmarks it, and moves on to the next pod
+func (pt *PodsTable) ToggleMark() {
+	pt.table.ToggleSelected()
+	pt.table.MoveDown()
+}
+
+// MarkAllFiltered marks every pod the search and selector show; when they are all marked already
+// they are unmarked instead
+func (pt *PodsTable) MarkAllFiltered() {
+	allMarked := len(pt.filteredPods) > 0
+	for _, pod := range pt.filteredPods {
+		if !pt.table.IsSelected(pod.Namespace + "/" + pod.Name) {
+			allMarked = false
+			break
+		}
+	}
+	for _, pod := range pt.filteredPods {
+		pt.table.SetSelected(pod.Namespace+"/"+pod.Name, !allMarked)
+	}
+}
+
+// ClearMarks unmarks every pod
+func (pt *PodsTable) ClearMarks() {
+	pt.table.ClearSelection()
+}
+
+// GetMarkedPods returns the marked pods that are still listed, including those the search hides
+func (pt *PodsTable) GetMarkedPods() []k8s.PodInfo {
+	var marked []k8s.PodInfo
+	for _, pod := range pt.pods {
+		if pt.table.IsSelected(pod.Namespace + "/" + pod.Name) {
+			marked = append(marked, pod)
+		}
 	}
+	return marked
 }
 
 func (pt *PodsTable) GetSelectedPod() *k8s.PodInfo {
-	if pt.cursor < len(pt.filteredPods) {
-		return &pt.filteredPods[pt.cursor]
+	key := pt.table.SelectedKey()
+	for i := range pt.filteredPods {
+		if pt.filteredPods[i].Namespace+"/"+pt.filteredPods[i].Name == key {
+			return &pt.filteredPods[i]
+		}
 	}
 	return nil
 }
 
-func (pt *PodsTable) Render() string {
-	var b strings.Builder
-
-	// Only show loading screen if we have no pods AND it's the initial load
-	if pt.isLoading && len(pt.pods) == 0 && pt.lastUpdate.IsZero() {
-		b.WriteString(styles.NormalStyle.Render("Loading pods..."))
-		return b.String()
+// Render draws the pods view in width by height
+func (pt *PodsTable) Render(width, height int) string {
+	if state := pt.renderState("pods", len(pt.pods) == 0); state != "" {
+		return state
 	}
 
-	if pt.error != nil {
-		errorStyle := styles.NormalStyle.Foreground(lipgloss.Color("196"))
-		b.WriteString(errorStyle.Render(fmt.Sprintf("Error loading pods: %v", pt.error)))
-		return b.String()
-	}
+	var b strings.Builder
 
 	// Header info
 	namespaceStyle := styles.NormalStyle.Foreground(lipgloss.Color("245"))
@@ -159,29 +243,46 @@ func (pt *PodsTable) Render() string {
 	if pt.namespace == "" {
 		namespaceText = "Showing pods across all namespaces"
 	}
-	if pt.isLoading && len(pt.pods) > 0 {
-		namespaceText += " ●"
-	}
+	namespaceText += pt.refreshMarker(len(pt.pods) == 0)
 	b.WriteString(namespaceStyle.Render(namespaceText) + "\n")
 
+	// Selector the pods are listed with
+	if !pt.selector.IsEmpty() {
+		selectorStyle := styles.NormalStyle.Foreground(lipgloss.Color("39"))
+		selectorText := fmt.Sprintf("🏷 Selector: %s", pt.selector)
+		if clientFields := pt.selector.ClientFields(); len(clientFields) > 0 {
+			selectorText += fmt.Sprintf(" (%s matched locally)", strings.Join(clientFields, ","))
+		}
+		b.WriteString(selectorStyle.Render(selectorText) + "\n")
+	}
+
 	// Search mode indicator
-	if pt.searchMode {
+	if pt.searchMode || pt.searchQuery != "" {
 		searchStyle := styles.NormalStyle.Foreground(lipgloss.Color("39")).Bold(true)
 		searchText := fmt.Sprintf("🔍 Search: %s", pt.searchQuery)
-		if pt.searchQuery == "" {
+		if pt.searchMode {
 			searchText += "█" // cursor
 		}
 		b.WriteString(searchStyle.Render(searchText) + "\n")
 	}
 
+	// Marked pods the bulk actions apply to
+	if marked := len(pt.GetMarkedPods()); marked > 0 {
+		markedStyle := styles.NormalStyle.Foreground(lipgloss.Color("214")).Bold(true)
+		markedText := fmt.Sprintf("✓ %d pods marked • d/r/L/T act on the marked pods • esc to unmark", marked)
+		b.WriteString(markedStyle.Render(markedText) + "\n")
+	}
+
 	// Controls info
 	controlsStyle := styles.NormalStyle.Foreground(lipgloss.Color("240"))
-	controls := "Auto-refresh every 15s • / to search • l=logs e=exec d=delete r=restart y=yaml"
+	controls := "Auto-refresh every 15s • / to search • f=selector • o/O=sort • space=mark a=mark all • enter=describe l=logs L=tail e=exec D=debug c=copy u=owners d=delete r=restart T=label y=yaml"
 	b.WriteString(controlsStyle.Render(controls) + "\n\n")
 
 	if len(pt.filteredPods) == 0 {
-		if pt.searchMode && pt.searchQuery != "" {
+		if pt.searchQuery != "" {
 			b.WriteString(styles.NormalStyle.Render("No pods match your search"))
+		} else if !pt.selector.IsEmpty() {
+			b.WriteString(styles.NormalStyle.Render("No pods match the selector"))
 		} else {
 			b.WriteString(styles.NormalStyle.Render("No pods found in the selected namespace(s)"))
 		}
@@ -192,7 +293,8 @@ func (pt *PodsTable) Render() string {
 	b.WriteString(pt.renderSummary() + "\n\n")
 
 	// Pods table
-	b.WriteString(pt.renderPodsTable())
+	b.WriteString(styles.HeaderStyle.Render("📋 Pods") + "\n")
+	b.WriteString(pt.renderPodsTable(width, remainingHeight(height, b.String())))
 
 	return b.String()
 }
@@ -220,107 +322,89 @@ func (pt *PodsTable) renderSummary() string {
 	return b.String()
 }
 
-func (pt *PodsTable) renderPodsTable() string {
-	var b strings.Builder
-
-	b.WriteString(styles.HeaderStyle.Render("📋 Pods") + "\n")
-
-	// Table header
-	headerStyle := styles.NormalStyle.Bold(true).Underline(true)
-	header := fmt.Sprintf("%-20s %-15s %-12s %-8s %-8s %-15s %s",
-		"NAME", "NAMESPACE", "STATUS", "READY", "RESTARTS", "NODE", "AGE")
-	b.WriteString(headerStyle.Render(header) + "\n")
-
-	// Determine which pods to show (with scrolling)
-	startIndex := 0
-	endIndex := len(pt.filteredPods)
-	maxVisible := 20 // Show max 20 pods at once
-
-	if len(pt.filteredPods) > maxVisible {
-		// Calculate scroll window
-		if pt.cursor >= maxVisible/2 {
-			startIndex = pt.cursor - maxVisible/2
-		}
-		endIndex = startIndex + maxVisible
-		if endIndex > len(pt.filteredPods) {
-			endIndex = len(pt.filteredPods)
-			startIndex = endIndex - maxVisible
-			if startIndex < 0 {
-				startIndex = 0
-			}
-		}
-	}
-
-	// Show scroll indicator if needed
-	if len(pt.filteredPods) > maxVisible {
-		scrollStyle := styles.NormalStyle.Foreground(lipgloss.Color("240"))
-		scrollInfo := fmt.Sprintf("Showing %d-%d of %d pods (use ↑↓ to navigate)",
-			startIndex+1, endIndex, len(pt.filteredPods))
-		b.WriteString(scrollStyle.Render(scrollInfo) + "\n")
-	}
-
-	// Table rows
-	for i := startIndex; i < endIndex; i++ {
-		pod := pt.filteredPods[i]
-
-		// Truncate and format fields
-		name := truncateString(pod.Name, 20)
-		namespace := truncateString(pod.Namespace, 15)
-		status := truncateString(pod.Status, 12)
-		ready := truncateString(pod.Ready, 8)
-		restarts := fmt.Sprintf("%d", pod.Restarts)
-		if len(restarts) > 8 {
-			restarts = truncateString(restarts, 8)
+func (pt *PodsTable) renderPodsTable(width, height int) string {
+	rows := make([]TableRow, 0, len(pt.filteredPods))
+	for _, pod := range pt.filteredPods {
+		cpu, memory := "n/a", "n/a"
+		var cpuValue, memoryValue any
+		if pod.Usage != nil {
+			cpu = k8s.FormatMilliCPU(pod.Usage.CPU)
+			memory = k8s.FormatBytes(pod.Usage.Memory)
+			cpuValue, memoryValue = pod.Usage.CPU, pod.Usage.Memory
 		}
-		node := truncateString(pod.Node, 15)
-		age := formatPodAge(pod.Age)
 
-		row := fmt.Sprintf("%-20s %-15s %-12s %-8s %-8s %-15s %s",
-			name, namespace, status, ready, restarts, node, age)
-
-		// Color based on status and highlight selection
+		// Color based on status
 		statusColor := getPodStatusColor(pod.Status)
-		rowStyle := styles.NormalStyle.Foreground(lipgloss.Color(statusColor))
-
-		// Highlight selected pod
-		if i == pt.cursor {
-			rowStyle = rowStyle.Background(lipgloss.Color("237")).Bold(true)
-		}
-
-		b.WriteString(rowStyle.Render(row))
-		if i < endIndex-1 {
-			b.WriteString("\n")
-		}
+		rows = append(rows, TableRow{
+			Key: pod.Namespace + "/" + pod.Name,
+			Cells: []string{pod.Name, pod.Namespace, pod.Status, pod.Ready, fmt.Sprintf("%d", pod.Restarts),
+				cpu, memory, pod.Node, formatPodAge(pod.Age)},
+			Values: []any{nil, nil, podStatusSeverity(pod.Status), readyRatio(pod.Ready), pod.Restarts,
+				cpuValue, memoryValue, nil, pod.Age},
+			Style: styles.NormalStyle.Foreground(lipgloss.Color(statusColor)),
+		})
 	}
+	pt.table.SetRows(rows)
 
-	return b.String()
+	return pt.table.Render(width, height)
 }
 
 func getPodStatusColor(status string) string {
 	lowerStatus := strings.ToLower(status)
-	
+
 	// Only red for actual errors
-	if strings.Contains(lowerStatus, "failed") || 
+	if strings.Contains(lowerStatus, "failed") ||
 		strings.Contains(lowerStatus, "error") ||
 		strings.Contains(lowerStatus, "crashloopbackoff") ||
 		strings.Contains(lowerStatus, "imagepullbackoff") ||
 		strings.Contains(lowerStatus, "errimagepull") ||
-		strings.Contains(lowerStatus, "invalidimgname") {
+		strings.Contains(lowerStatus, "invalidimgname") ||
+		strings.Contains(lowerStatus, "oomkilled") ||
+		strings.Contains(lowerStatus, "evicted") ||
+		strings.Contains(lowerStatus, "nodelost") ||
+		strings.HasPrefix(lowerStatus, "exitcode:") ||
+		strings.HasPrefix(lowerStatus, "signal:") ||
+		strings.HasPrefix(lowerStatus, "init:exitcode:") ||
+		strings.HasPrefix(lowerStatus, "init:signal:") ||
+		lowerStatus == "unknown" {
 		return "196" // Red
 	}
-	
+
 	// Yellow for warnings/pending states
 	if strings.Contains(lowerStatus, "pending") ||
 		strings.Contains(lowerStatus, "containercreating") ||
 		strings.Contains(lowerStatus, "podinitialized") ||
-		strings.Contains(lowerStatus, "imagepullbackoff") {
+		strings.Contains(lowerStatus, "imagepullbackoff") ||
+		strings.HasPrefix(lowerStatus, "init:") ||
+		lowerStatus == "notready" ||
+		lowerStatus == "schedulinggated" {
 		return "226" // Yellow
 	}
-	
+
 	// Everything else is white (running, succeeded, completed, etc.)
 	return "252" // White/Default
 }
 
+// podStatusSeverity ranks a pod status for sorting: 0 for healthy, 1 for pending, 2 for failing
+func podStatusSeverity(status string) int {
+	switch getPodStatusColor(status) {
+	case "196":
+		return 2
+	case "226":
+		return 1
+	}
+	return 0
+}
+
+// readyRatio turns a "ready/total" count into the ready share, for sorting
+func readyRatio(ready string) float64 {
+	var count, total int
+	if _, err := fmt.Sscanf(ready, "%d/%d", &count, &total); err != nil || total == 0 {
+		return 0
+	}
+	return float64(count) / float64(total)
+}
+
 func formatPodAge(age time.Duration) string {
 	if age < time.Minute {
 		seconds := int(age.Seconds())
@@ -343,4 +427,4 @@ func (pt *PodsTable) IsSearchMode() bool {
 
 func (pt *PodsTable) GetSearchQuery() string {
 	return pt.searchQuery
-}
\ No newline at end of file
+}
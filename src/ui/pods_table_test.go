@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"testing"
+
+	"peek/src/k8s"
+)
+
+func TestPodsLoadForEarlierSelectorIsDropped(t *testing.T) {
+	pt := NewPodsTable(nil, "", "")
+	pt.startLoad(true)
+	stale := PodsLoadedMsg{generation: pt.generation, selector: pt.selector, pods: []k8s.PodInfo{{Name: "web-0"}}}
+
+	if err := pt.SetSelector("app=api"); err != nil {
+		t.Fatal(err)
+	}
+	pt.FinishLoad(stale)
+	if len(pt.pods) != 0 || !pt.lastUpdate.IsZero() {
+		t.Fatalf("kept %d pods listed for the previous selector", len(pt.pods))
+	}
+
+	pt.startLoad(true)
+	pt.FinishLoad(PodsLoadedMsg{generation: pt.generation, selector: pt.selector, pods: []k8s.PodInfo{{Name: "api-0"}}})
+	if len(pt.filteredPods) != 1 || pt.filteredPods[0].Name != "api-0" {
+		t.Errorf("listed %v, want the pods of the current selector", pt.filteredPods)
+	}
+	if pt.running || pt.isLoading {
+		t.Error("load still marked as running after it finished")
+	}
+}
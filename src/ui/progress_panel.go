@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"peek/src/styles"
+)
+
+// progressItem is one target of a bulk action and how it went
+type progressItem struct {
+	name string
+	done bool
+	err  error
+}
+
+// ProgressPanel reports a bulk action item by item as the results come in. Closing it hides the
+// panel; the action keeps running.
+type ProgressPanel struct {
+	isOpen bool
+	title  string
+	items  []progressItem
+	offset int
+}
+
+func NewProgressPanel() *ProgressPanel {
+	return &ProgressPanel{}
+}
+
+// Start shows the panel for a new action on the named items, all pending
+func (pp *ProgressPanel) Start(title string, names []string) {
+	pp.isOpen = true
+	pp.title = title
+	pp.offset = 0
+	pp.items = make([]progressItem, len(names))
+	for i, name := range names {
+		pp.items[i] = progressItem{name: name}
+	}
+}
+
+// Report records the result of the item at index; a nil error means it succeeded
+func (pp *ProgressPanel) Report(index int, err error) {
+	if index >= 0 && index < len(pp.items) {
+		pp.items[index].done = true
+		pp.items[index].err = err
+	}
+}
+
+// Counts returns how many items succeeded, failed and are still pending
+func (pp *ProgressPanel) Counts() (succeeded, failed, pending int) {
+	for _, item := range pp.items {
+		switch {
+		case !item.done:
+			pending++
+		case item.err != nil:
+			failed++
+		default:
+			succeeded++
+		}
+	}
+	return succeeded, failed, pending
+}
+
+// IsDone reports whether every item has a result
+func (pp *ProgressPanel) IsDone() bool {
+	_, _, pending := pp.Counts()
+	return pending == 0
+}
+
+func (pp *ProgressPanel) Close() {
+	pp.isOpen = false
+}
+
+func (pp *ProgressPanel) IsOpen() bool {
+	return pp.isOpen
+}
+
+func (pp *ProgressPanel) ScrollUp() {
+	if pp.offset > 0 {
+		pp.offset--
+	}
+}
+
+func (pp *ProgressPanel) ScrollDown() {
+	if pp.offset < len(pp.items)-1 {
+		pp.offset++
+	}
+}
+
+func (pp *ProgressPanel) Render(screenWidth, screenHeight int) string {
+	if !pp.isOpen {
+		return ""
+	}
+
+	width := min(max(screenWidth-10, 40), 100)
+	// Title, counts, bar, blank lines and controls take 7 lines; the border and padding 4 more
+	listHeight := max(screenHeight-16, 3)
+
+	var content strings.Builder
+
+	titleStyle := styles.NormalStyle.Bold(true).Foreground(lipgloss.Color("39"))
+	content.WriteString(titleStyle.Render(pp.title) + "\n")
+
+	succeeded, failed, pending := pp.Counts()
+	total := len(pp.items)
+	countStyle := styles.NormalStyle.Foreground(lipgloss.Color("245"))
+	counts := fmt.Sprintf("%d of %d done", total-pending, total)
+	if pending == 0 {
+		counts = fmt.Sprintf("Finished %d", total)
+	}
+	content.WriteString(countStyle.Render(counts) + " • " +
+		styles.NormalStyle.Foreground(lipgloss.Color("46")).Render(fmt.Sprintf("✓ %d", succeeded)) + " " +
+		styles.NormalStyle.Foreground(lipgloss.Color("196")).Render(fmt.Sprintf("✗ %d", failed)) + "\n")
+	content.WriteString(pp.renderBar(width-4, total-pending, total) + "\n\n")
+
+	// Items, scrolled
+	offset := min(pp.offset, max(total-listHeight, 0))
+	end := min(offset+listHeight, total)
+	for _, item := range pp.items[offset:end] {
+		content.WriteString(fitCell(pp.renderItem(item), width-4, false) + "\n")
+	}
+	if total > listHeight {
+		content.WriteString(countStyle.Render(fmt.Sprintf("Showing %d-%d of %d", offset+1, end, total)) + "\n")
+	}
+
+	controlsStyle := styles.NormalStyle.Foreground(lipgloss.Color("240")).Italic(true)
+	controls := "↑↓ to scroll • Esc to hide, the action keeps running"
+	if pending == 0 {
+		controls = "↑↓ to scroll • Esc to close"
+	}
+	content.WriteString("\n" + controlsStyle.Render(controls))
+
+	panelStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("39")).
+		Background(lipgloss.Color("235")).
+		Padding(1, 2).
+		Width(width)
+
+	return lipgloss.Place(
+		screenWidth,
+		screenHeight,
+		lipgloss.Center,
+		lipgloss.Center,
+		panelStyle.Render(content.String()),
+	)
+}
+
+func (pp *ProgressPanel) renderItem(item progressItem) string {
+	switch {
+	case !item.done:
+		return styles.NormalStyle.Foreground(lipgloss.Color("240")).Render("⋯ " + item.name)
+	case item.err != nil:
+		return styles.NormalStyle.Foreground(lipgloss.Color("196")).Render("✗ " + item.name + ": " + item.err.Error())
+	default:
+		return styles.NormalStyle.Foreground(lipgloss.Color("46")).Render("✓ " + item.name)
+	}
+}
+
+// renderBar draws how much of the action is done as a bar width cells wide
+func (pp *ProgressPanel) renderBar(width, done, total int) string {
+	filled := width
+	if total > 0 {
+		filled = width * done / total
+	}
+	return styles.NormalStyle.Foreground(lipgloss.Color("46")).Render(strings.Repeat("█", filled)) +
+		styles.NormalStyle.Foreground(lipgloss.Color("240")).Render(strings.Repeat("░", width-filled))
+}
@@ -6,11 +6,46 @@ import (
 	"strings"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"peek/src/history"
 	"peek/src/k8s"
+	"peek/src/settings"
 	"peek/src/styles"
 )
 
+const (
+	// historyInterval is how often cluster metrics are sampled into the history store
+	historyInterval = 15 * time.Second
+	// historyWindow is how far back sparklines reach
+	historyWindow = 30 * time.Minute
+	// sparklineWidth is the number of characters used by trend sparklines
+	sparklineWidth = 40
+	// archiveInterval is how often watched events are flushed to the on-disk archive
+	archiveInterval = 30 * time.Second
+)
+
+// Views with a table, by the name their settings are kept under
+const (
+	viewPods         = "pods"
+	viewNodes        = "nodes"
+	viewEvents       = "events"
+	viewApplications = "applications"
+)
+
+// MetricsSampledMsg delivers cluster metrics sampled for a context to the right pane
+type MetricsSampledMsg struct {
+	context string
+	metrics *k8s.ClusterMetrics
+	err     error
+}
+
+// EventsArchivedMsg reports how a flush of watched events to an archive went
+type EventsArchivedMsg struct {
+	archive *k8s.EventArchive
+	err     error
+}
+
 type RightPane struct {
 	SelectedItem      string
 	Width             int
@@ -19,7 +54,17 @@ type RightPane struct {
 	Notifications     *NotificationManager
 	KubeConfig        *k8s.KubeConfig
 	metrics           *k8s.ClusterMetrics
+	metricsErr        error
 	lastUpdate        time.Time
+	history           *history.Store
+	lastSample        time.Time
+	sampling          bool
+	eventWatcher      *k8s.EventWatcher
+	settings          *settings.Settings
+	eventArchive      *k8s.EventArchive
+	lastArchive       time.Time
+	archiving         bool
+	eventFilter       *k8s.EventFilter
 	nodesTable        *NodesTable
 	eventsTable       *EventsTable
 	applicationsTable *ApplicationsTable
@@ -28,8 +73,9 @@ type RightPane struct {
 
 func NewRightPane(width, height int) *RightPane {
 	return &RightPane{
-		Width:  width,
-		Height: height,
+		Width:   width,
+		Height:  height,
+		history: history.NewStore(historyWindow, historyInterval),
 	}
 }
 
@@ -45,17 +91,59 @@ func (rp *RightPane) SetNotifications(nm *NotificationManager) {
 	rp.Notifications = nm
 }
 
+func (rp *RightPane) SetSettings(s *settings.Settings) {
+	rp.settings = s
+}
+
 func (rp *RightPane) SetKubeConfig(kc *k8s.KubeConfig) {
 	rp.KubeConfig = kc
+	// History and metrics belong to the previous context
+	rp.history.Reset()
+	rp.metrics = nil
+	rp.metricsErr = nil
+	rp.lastSample = time.Time{}
+	rp.sampling = false
+	// The event watch is bound to a context, so restart it
+	if rp.eventWatcher != nil {
+		rp.eventWatcher.Stop()
+		rp.eventWatcher = nil
+	}
 	// Initialize tables with current context if available
 	if kc != nil {
 		// Get the current namespace from kubeconfig
 		currentNamespace := kc.GetCurrentNamespace()
 		
+		rp.eventWatcher = kc.NewEventWatcher(kc.CurrentContext)
+		rp.eventWatcher.Start()
+		rp.eventArchive = nil
+		rp.lastArchive = time.Time{}
+		rp.archiving = false
+		if rp.settings != nil && rp.settings.EventArchive.Enabled {
+			if err := rp.openEventArchive(); err != nil && rp.Notifications != nil {
+				rp.Notifications.AddError("Event Archive", err.Error())
+			}
+		}
+
+		// Restore the events filter saved for this context
+		rp.eventFilter = nil
+		if rp.settings != nil {
+			if query := rp.settings.EventFilters[kc.CurrentContext]; query != "" {
+				if filter, err := k8s.ParseEventFilter(query); err == nil {
+					rp.eventFilter = filter
+				}
+			}
+		}
+
 		rp.nodesTable = NewNodesTable(kc, kc.CurrentContext)
-		rp.eventsTable = NewEventsTable(kc, kc.CurrentContext)
+		rp.restoreSort(viewNodes, rp.nodesTable.table)
+		rp.eventsTable = NewEventsTable(rp.eventWatcher)
+		rp.eventsTable.SetArchive(rp.eventArchive)
+		rp.eventsTable.SetFilter(rp.eventFilter)
+		rp.restoreSort(viewEvents, rp.eventsTable.table)
 		rp.applicationsTable = NewApplicationsTable(kc, kc.CurrentContext, currentNamespace)
+		rp.restoreSort(viewApplications, rp.applicationsTable.table)
 		rp.podsTable = NewPodsTable(kc, kc.CurrentContext, currentNamespace)
+		rp.restoreSort(viewPods, rp.podsTable.table)
 	}
 }
 
@@ -98,20 +186,10 @@ func (rp *RightPane) Render() string {
 }
 
 func (rp *RightPane) renderOverview() string {
-	// Update metrics if needed (every 30 seconds)
-	if rp.KubeConfig != nil && (rp.metrics == nil || time.Since(rp.lastUpdate) > 30*time.Second) {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-
-		metrics, err := rp.KubeConfig.GetClusterMetrics(ctx)
-		if err != nil {
-			return styles.NormalStyle.Render(fmt.Sprintf("Failed to load cluster metrics: %v", err))
-		}
-
-		rp.metrics = metrics
-		rp.lastUpdate = time.Now()
+	// SampleMetrics loads the metrics on the next tick and keeps them fresh
+	if rp.metrics == nil && rp.metricsErr != nil {
+		return styles.NormalStyle.Render(fmt.Sprintf("Failed to load cluster metrics: %v", rp.metricsErr))
 	}
-
 	if rp.metrics == nil {
 		return styles.NormalStyle.Render("Loading cluster metrics...")
 	}
@@ -129,6 +207,9 @@ func (rp *RightPane) renderOverview() string {
 	podMetrics := rp.renderPodMetrics()
 	b.WriteString(podMetrics + "\n\n")
 
+	// Trends from the history store
+	b.WriteString(rp.renderTrends() + "\n\n")
+
 	// Events section
 	b.WriteString(styles.HeaderStyle.Render("⚡ Recent Events") + "\n")
 	eventsTable := rp.renderEventsTable()
@@ -137,6 +218,223 @@ func (rp *RightPane) renderOverview() string {
 	return b.String()
 }
 
+// SampleMetrics returns a command that fetches cluster metrics for FinishSample to record in the
+// history store. It is called on every refresh tick and returns nil when no sample is due.
+func (rp *RightPane) SampleMetrics() tea.Cmd {
+	if rp.KubeConfig == nil || rp.sampling || time.Since(rp.lastSample) < historyInterval {
+		return nil
+	}
+
+	rp.sampling = true
+	rp.lastSample = time.Now()
+	kubeConfig, contextName := rp.KubeConfig, rp.KubeConfig.CurrentContext
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		metrics, err := kubeConfig.GetClusterMetrics(ctx)
+		return MetricsSampledMsg{context: contextName, metrics: metrics, err: err}
+	}
+}
+
+// FinishSample shows sampled metrics and records them, dropping samples taken for another context
+func (rp *RightPane) FinishSample(msg MetricsSampledMsg) {
+	if rp.KubeConfig == nil || msg.context != rp.KubeConfig.CurrentContext {
+		return
+	}
+
+	rp.sampling = false
+	if msg.err != nil {
+		rp.metricsErr = msg.err
+		return
+	}
+	rp.metrics = msg.metrics
+	rp.metricsErr = nil
+	rp.lastUpdate = time.Now()
+	rp.recordSample(msg.metrics)
+}
+
+// ArchiveEvents returns a command that flushes the watched events to the on-disk archive when
+// archiving is enabled. It is called on every refresh tick and returns nil when no flush is due.
+func (rp *RightPane) ArchiveEvents() tea.Cmd {
+	if rp.eventArchive == nil || rp.eventWatcher == nil || rp.archiving || time.Since(rp.lastArchive) < archiveInterval {
+		return nil
+	}
+
+	archive, watcher := rp.eventArchive, rp.eventWatcher
+	rp.archiving = true
+	rp.lastArchive = time.Now()
+	return func() tea.Msg {
+		_, err := archive.Record(watcher.Events(time.Time{}))
+		return EventsArchivedMsg{archive: archive, err: err}
+	}
+}
+
+// FinishArchive reports a failed flush, ignoring flushes to an archive that has since been replaced
+func (rp *RightPane) FinishArchive(msg EventsArchivedMsg) {
+	if msg.archive != rp.eventArchive {
+		return
+	}
+
+	rp.archiving = false
+	if msg.err != nil && rp.Notifications != nil {
+		rp.Notifications.AddError("Event Archive", msg.err.Error())
+	}
+}
+
+// QueryArchivedEvents returns a command that reads the archived events of the events view's range
+// when a query is due, or nil
+func (rp *RightPane) QueryArchivedEvents() tea.Cmd {
+	if rp.eventsTable == nil {
+		return nil
+	}
+	return rp.eventsTable.QueryArchive()
+}
+
+// FinishArchiveQuery hands archived events to the events view
+func (rp *RightPane) FinishArchiveQuery(msg EventsQueriedMsg) {
+	if rp.eventsTable != nil {
+		rp.eventsTable.FinishArchiveQuery(msg)
+	}
+}
+
+// ToggleEventArchive enables or disables the on-disk event archive and remembers the choice
+func (rp *RightPane) ToggleEventArchive() (bool, error) {
+	if rp.settings == nil || rp.KubeConfig == nil {
+		return false, fmt.Errorf("settings not available")
+	}
+
+	enabled := !rp.settings.EventArchive.Enabled
+	if enabled {
+		if err := rp.openEventArchive(); err != nil {
+			return false, err
+		}
+	} else {
+		rp.eventArchive = nil
+		rp.archiving = false
+	}
+	if rp.eventsTable != nil {
+		rp.eventsTable.SetArchive(rp.eventArchive)
+	}
+
+	rp.settings.EventArchive.Enabled = enabled
+	return enabled, rp.settings.Save()
+}
+
+// SetEventFilter applies an events filter query to the Events view and the Overview's recent events,
+// and remembers it for the current context
+func (rp *RightPane) SetEventFilter(query string) error {
+	filter, err := k8s.ParseEventFilter(query)
+	if err != nil {
+		return err
+	}
+
+	if filter.IsEmpty() {
+		filter = nil
+	}
+	rp.eventFilter = filter
+	if rp.eventsTable != nil {
+		rp.eventsTable.SetFilter(filter)
+	}
+
+	if rp.settings == nil || rp.KubeConfig == nil {
+		return nil
+	}
+	if rp.settings.EventFilters == nil {
+		rp.settings.EventFilters = make(map[string]string)
+	}
+	if filter == nil {
+		delete(rp.settings.EventFilters, rp.KubeConfig.CurrentContext)
+	} else {
+		rp.settings.EventFilters[rp.KubeConfig.CurrentContext] = filter.String()
+	}
+	return rp.settings.Save()
+}
+
+// GetEventArchive returns the event archive of the current context, or nil when archiving is disabled
+func (rp *RightPane) GetEventArchive() *k8s.EventArchive {
+	return rp.eventArchive
+}
+
+func (rp *RightPane) openEventArchive() error {
+	dir, err := settings.Dir()
+	if err != nil {
+		return err
+	}
+
+	retention := time.Duration(rp.settings.EventArchive.RetentionDays) * 24 * time.Hour
+	archive, err := k8s.OpenEventArchive(dir, rp.KubeConfig.CurrentContext, retention)
+	if err != nil {
+		return err
+	}
+	rp.eventArchive = archive
+	// Flush what the watcher already buffered on the next tick
+	rp.lastArchive = time.Time{}
+	rp.archiving = false
+	return nil
+}
+
+// recordSample stores the current cluster metrics in the history store
+func (rp *RightPane) recordSample(metrics *k8s.ClusterMetrics) {
+	now := metrics.LastUpdate
+	rp.history.Record(history.PodsRunning, now, int64(metrics.Pods.Running))
+	rp.history.Record(history.PodsPending, now, int64(metrics.Pods.Pending))
+	rp.history.Record(history.PodsFailed, now, int64(metrics.Pods.Failed))
+	rp.history.Record(history.NodesReady, now, int64(metrics.Nodes.Ready))
+	rp.history.Record(history.NodesNotReady, now, int64(metrics.Nodes.NotReady))
+	if rp.eventWatcher != nil && rp.eventWatcher.Synced() {
+		rp.history.Record(history.WarningEvents, now, int64(len(rp.eventWatcher.RecentWarnings(10*time.Minute))))
+	}
+	if metrics.Nodes.UsageAvailable {
+		rp.history.Record(history.CPUUsage, now, metrics.Nodes.CPUUsage)
+		rp.history.Record(history.MemUsage, now, metrics.Nodes.MemUsage)
+	}
+}
+
+// renderTrends renders sparklines for every recorded cluster metric
+func (rp *RightPane) renderTrends() string {
+	var b strings.Builder
+
+	b.WriteString(styles.HeaderStyle.Render(fmt.Sprintf("📉 Trends (last %d minutes)", int(historyWindow.Minutes()))) + "\n")
+
+	formatCount := func(v int64) string { return fmt.Sprintf("%d", v) }
+	lines := []string{
+		rp.renderTrend("Pods running", history.PodsRunning, formatCount, "46"),
+		rp.renderTrend("Pods pending", history.PodsPending, formatCount, "226"),
+		rp.renderTrend("Pods failed", history.PodsFailed, formatCount, "196"),
+		rp.renderTrend("Nodes ready", history.NodesReady, formatCount, "46"),
+		rp.renderTrend("Warnings/10m", history.WarningEvents, formatCount, "214"),
+		rp.renderTrend("CPU usage", history.CPUUsage, k8s.FormatMilliCPU, "39"),
+		rp.renderTrend("Memory usage", history.MemUsage, k8s.FormatBytes, "39"),
+	}
+
+	trends := rp.renderDetailTrends(lines...)
+	if trends == "" {
+		b.WriteString(styles.NormalStyle.Foreground(lipgloss.Color("240")).Render("Collecting samples..."))
+		return b.String()
+	}
+
+	b.WriteString(strings.TrimSuffix(trends, "\n\n"))
+	return b.String()
+}
+
+// renderTrend renders a single labelled sparkline with the latest value, or "" if there is no data
+func (rp *RightPane) renderTrend(label, name string, format func(int64) string, color string) string {
+	values := rp.history.Values(name, historyWindow)
+	if len(values) == 0 {
+		return ""
+	}
+
+	labelStyle := styles.NormalStyle.Width(14)
+	sparkStyle := styles.NormalStyle.Foreground(lipgloss.Color(color)).Width(sparklineWidth)
+	valueStyle := styles.NormalStyle.Foreground(lipgloss.Color("245"))
+
+	return fmt.Sprintf("%s %s %s",
+		labelStyle.Render(label),
+		sparkStyle.Render(CreateSparkline(values, sparklineWidth)),
+		valueStyle.Render(format(values[len(values)-1])))
+}
+
 func (rp *RightPane) renderNodeMetrics() string {
 	if rp.metrics == nil {
 		return styles.NormalStyle.Render("No data available")
@@ -178,58 +476,119 @@ func (rp *RightPane) renderNodeMetrics() string {
 	totalRow := fmt.Sprintf("%-12s %-8d %-12s", "📊 Total", totalNodes, "100.0%")
 	b.WriteString(totalStyle.Render(totalRow) + "\n\n")
 
-	// Resource Usage Table
-	b.WriteString(styles.HeaderStyle.Render("💾 Resource Usage") + "\n")
-	
+	// Resource Allocation Table
+	b.WriteString(styles.HeaderStyle.Render("💾 Resource Allocation") + "\n")
+
 	// Table header
-	resourceHeader := fmt.Sprintf("%-12s %-15s %-15s %-12s", "RESOURCE", "ALLOCATED", "CAPACITY", "USAGE %")
+	resourceHeader := fmt.Sprintf("%-12s %-20s %-20s %-12s %-12s", "RESOURCE", "REQUESTS", "LIMITS", "ALLOCATABLE", "CAPACITY")
 	b.WriteString(headerStyle.Render(resourceHeader) + "\n")
 
-	// CPU usage
-	cpuTotal := rp.metrics.Nodes.CPUCapacity
-	cpuAllocated := rp.metrics.Nodes.CPUAllocated
-	cpuPercent := 0.0
-	if cpuTotal > 0 {
-		cpuPercent = float64(cpuAllocated) / float64(cpuTotal) * 100
+	nodes := rp.metrics.Nodes
+	cpuRow := fmt.Sprintf("%-12s %-20s %-20s %-12s %-12s", "🔧 CPU",
+		fmt.Sprintf("%s (%s)", k8s.FormatMilliCPU(nodes.CPURequests), k8s.FormatPercentage(nodes.CPURequests, nodes.CPUAllocatable)),
+		fmt.Sprintf("%s (%s)", k8s.FormatMilliCPU(nodes.CPULimits), k8s.FormatPercentage(nodes.CPULimits, nodes.CPUAllocatable)),
+		k8s.FormatMilliCPU(nodes.CPUAllocatable),
+		k8s.FormatMilliCPU(nodes.CPUCapacity))
+	b.WriteString(allocationStyle(nodes.CPULimits, nodes.CPUAllocatable).Render(cpuRow) + "\n")
+
+	memRow := fmt.Sprintf("%-12s %-20s %-20s %-12s %-12s", "🧠 Memory",
+		fmt.Sprintf("%s (%s)", k8s.FormatBytes(nodes.MemRequests), k8s.FormatPercentage(nodes.MemRequests, nodes.MemAllocatable)),
+		fmt.Sprintf("%s (%s)", k8s.FormatBytes(nodes.MemLimits), k8s.FormatPercentage(nodes.MemLimits, nodes.MemAllocatable)),
+		k8s.FormatBytes(nodes.MemAllocatable),
+		k8s.FormatBytes(nodes.MemCapacity))
+	b.WriteString(allocationStyle(nodes.MemLimits, nodes.MemAllocatable).Render(memRow) + "\n\n")
+
+	b.WriteString(CreateResourceBar(nodes.CPURequests, nodes.CPUAllocatable, 30, "CPU req",
+		fmt.Sprintf("%s / %s", k8s.FormatMilliCPU(nodes.CPURequests), k8s.FormatMilliCPU(nodes.CPUAllocatable)), "46") + "\n")
+	b.WriteString(CreateResourceBar(nodes.MemRequests, nodes.MemAllocatable, 30, "Memory req",
+		fmt.Sprintf("%s / %s", k8s.FormatBytes(nodes.MemRequests), k8s.FormatBytes(nodes.MemAllocatable)), "46") + "\n")
+
+	// Flag nodes whose limits exceed what they can actually provide
+	if nodes.Overcommitted > 0 {
+		warningStyle := styles.NormalStyle.Foreground(lipgloss.Color("214"))
+		var overcommitted []string
+		for _, allocation := range rp.metrics.NodeAllocations {
+			if allocation.Overcommitted() {
+				overcommitted = append(overcommitted, allocation.Name)
+			}
+		}
+		b.WriteString(warningStyle.Render(fmt.Sprintf("⚠ %d node(s) overcommitted on limits: %s",
+			nodes.Overcommitted, strings.Join(overcommitted, ", "))) + "\n")
 	}
-	
-	cpuColor := "46" // Green
-	if cpuPercent > 80 {
-		cpuColor = "196" // Red
-	} else if cpuPercent > 60 {
-		cpuColor = "226" // Yellow
-	}
-	cpuStyle := styles.NormalStyle.Foreground(lipgloss.Color(cpuColor))
-	cpuRow := fmt.Sprintf("%-12s %-15s %-15s %-12.1f%%", "🔧 CPU", 
-		fmt.Sprintf("%.2f cores", cpuAllocated), 
-		fmt.Sprintf("%.2f cores", cpuTotal), 
-		cpuPercent)
-	b.WriteString(cpuStyle.Render(cpuRow) + "\n")
-
-	// Memory usage
-	memTotal := rp.metrics.Nodes.MemCapacity
-	memAllocated := rp.metrics.Nodes.MemAllocated
-	memPercent := 0.0
-	if memTotal > 0 {
-		memPercent = float64(memAllocated) / float64(memTotal) * 100
+	b.WriteString("\n")
+
+	// Top namespaces by requests
+	b.WriteString(rp.renderNamespaceAllocations() + "\n\n")
+
+	// Live usage from metrics.k8s.io
+	b.WriteString(styles.HeaderStyle.Render("📈 Live Usage") + "\n")
+	if !nodes.UsageAvailable {
+		unavailableStyle := styles.NormalStyle.Foreground(lipgloss.Color("240")).Italic(true)
+		reason := "metrics API not available"
+		if nodes.UsageError != nil {
+			reason = nodes.UsageError.Error()
+		}
+		b.WriteString(unavailableStyle.Render("Usage unavailable: " + reason))
+		return b.String()
 	}
-	
-	memColor := "46" // Green
-	if memPercent > 80 {
-		memColor = "196" // Red
-	} else if memPercent > 60 {
-		memColor = "226" // Yellow
-	}
-	memStyle := styles.NormalStyle.Foreground(lipgloss.Color(memColor))
-	memRow := fmt.Sprintf("%-12s %-15s %-15s %-12.1f%%", "🧠 Memory", 
-		fmt.Sprintf("%.1f GB", memAllocated/(1024*1024*1024)), 
-		fmt.Sprintf("%.1f GB", memTotal/(1024*1024*1024)), 
-		memPercent)
-	b.WriteString(memStyle.Render(memRow))
+
+	b.WriteString(CreateResourceBar(nodes.CPUUsage, nodes.CPUAllocatable, 30, "🔧 CPU",
+		fmt.Sprintf("%s / %s", k8s.FormatMilliCPU(nodes.CPUUsage), k8s.FormatMilliCPU(nodes.CPUAllocatable)), "46") + "\n")
+	b.WriteString(CreateResourceBar(nodes.MemUsage, nodes.MemAllocatable, 30, "🧠 Memory",
+		fmt.Sprintf("%s / %s", k8s.FormatBytes(nodes.MemUsage), k8s.FormatBytes(nodes.MemAllocatable)), "46"))
 
 	return b.String()
 }
 
+// renderNamespaceAllocations renders the namespaces with the largest CPU requests
+func (rp *RightPane) renderNamespaceAllocations() string {
+	var b strings.Builder
+
+	b.WriteString(styles.HeaderStyle.Render("📦 Requests by Namespace") + "\n")
+	if len(rp.metrics.NamespaceAllocations) == 0 {
+		b.WriteString(styles.NormalStyle.Render("No running pods"))
+		return b.String()
+	}
+
+	table := NewTable("namespaces", []Column{
+		{Title: "NAMESPACE", Width: 20, MaxWidth: 40, Flex: 1},
+		{Title: "PODS", Width: 6},
+		{Title: "CPU REQ", Width: 10},
+		{Title: "CPU LIM", Width: 10},
+		{Title: "MEM REQ", Width: 12},
+		{Title: "MEM LIM", Width: 12},
+	})
+	table.ShowCursor(false)
+
+	var rows []TableRow
+	for i, allocation := range rp.metrics.NamespaceAllocations {
+		if i >= 5 { // Limit to the 5 heaviest namespaces
+			break
+		}
+		rows = append(rows, TableRow{
+			Cells: []string{allocation.Name, fmt.Sprintf("%d", allocation.Pods),
+				k8s.FormatMilliCPU(allocation.CPURequests), k8s.FormatMilliCPU(allocation.CPULimits),
+				k8s.FormatBytes(allocation.MemRequests), k8s.FormatBytes(allocation.MemLimits)},
+			Style: styles.NormalStyle,
+		})
+	}
+	table.SetRows(rows)
+	b.WriteString(table.Render(rp.Width, 0))
+
+	return b.String()
+}
+
+// allocationStyle colors a limits row by how far it exceeds allocatable
+func allocationStyle(limits, allocatable int64) lipgloss.Style {
+	color := "46" // Green
+	if allocatable > 0 && limits > allocatable {
+		color = "214" // Orange - overcommitted
+	} else if allocatable > 0 && limits*100/allocatable > 80 {
+		color = "226" // Yellow
+	}
+	return styles.NormalStyle.Foreground(lipgloss.Color(color))
+}
+
 func (rp *RightPane) renderPodMetrics() string {
 	if rp.metrics == nil {
 		return styles.NormalStyle.Render("No data available")
@@ -305,43 +664,53 @@ func (rp *RightPane) renderPodMetrics() string {
 }
 
 func (rp *RightPane) renderEventsTable() string {
-	if rp.metrics == nil || len(rp.metrics.Events) == 0 {
+	if rp.eventWatcher == nil {
 		return styles.NormalStyle.Render("No recent warning or error events")
 	}
+	if !rp.eventWatcher.Synced() {
+		return styles.NormalStyle.Render("Loading events...")
+	}
+
+	events := rp.eventFilter.Apply(rp.eventWatcher.RecentWarnings(10 * time.Minute))
 
 	var b strings.Builder
 
-	// Table header
-	headerStyle := styles.NormalStyle.Bold(true).Underline(true)
-	header := fmt.Sprintf("%-8s %-12s %-15s %-30s %s",
-		"TYPE", "REASON", "OBJECT", "MESSAGE", "AGE")
-	b.WriteString(headerStyle.Render(header) + "\n")
+	// The filter is shared with the Events view
+	if !rp.eventFilter.IsEmpty() {
+		filterStyle := styles.NormalStyle.Foreground(lipgloss.Color("39"))
+		b.WriteString(filterStyle.Render(fmt.Sprintf("🔍 Filter: %s", rp.eventFilter)) + "\n")
+	}
+
+	if len(events) == 0 {
+		b.WriteString(styles.NormalStyle.Render("No recent warning or error events"))
+		return b.String()
+	}
+
+	table := NewTable("events", []Column{
+		{Title: "TYPE", Width: 4, MaxWidth: 8},
+		{Title: "REASON", Width: 12, MaxWidth: 30, Flex: 1},
+		{Title: "OBJECT", Width: 15, Flex: 2},
+		{Title: "MESSAGE", Width: 30, Flex: 4},
+		{Title: "AGE", Width: 3},
+	})
+	table.ShowCursor(false)
 
 	// Event rows
-	for i, event := range rp.metrics.Events {
+	var rows []TableRow
+	for i, event := range events {
 		if i >= 10 { // Limit to 10 events
 			break
 		}
 
-		// Truncate fields to fit
-		eventType := k8s.TruncateString(event.Type, 8)
-		reason := k8s.TruncateString(event.Reason, 12)
-		object := k8s.TruncateString(event.Object, 15)
-		message := k8s.TruncateString(event.Message, 30)
-		age := k8s.FormatTimeAgo(event.LastTimestamp)
-
-		row := fmt.Sprintf("%-8s %-12s %-15s %-30s %s",
-			eventType, reason, object, message, age)
-
 		// Color based on event type
 		color := k8s.GetEventColor(event.Type)
-		rowStyle := styles.NormalStyle.Foreground(lipgloss.Color(color))
-
-		b.WriteString(rowStyle.Render(row))
-		if i < len(rp.metrics.Events)-1 && i < 9 {
-			b.WriteString("\n")
-		}
+		rows = append(rows, TableRow{
+			Cells: []string{event.Type, event.Reason, event.Object, event.Message, k8s.FormatTimeAgo(event.LastTimestamp)},
+			Style: styles.NormalStyle.Foreground(lipgloss.Color(color)),
+		})
 	}
+	table.SetRows(rows)
+	b.WriteString(table.Render(rp.Width, 0))
 
 	return b.String()
 }
@@ -406,57 +775,128 @@ func (rp *RightPane) renderNodes() string {
 	if rp.nodesTable == nil {
 		if rp.KubeConfig != nil {
 			rp.nodesTable = NewNodesTable(rp.KubeConfig, rp.KubeConfig.CurrentContext)
+			rp.restoreSort(viewNodes, rp.nodesTable.table)
 		} else {
 			return styles.NormalStyle.Render("Kubernetes configuration not available")
 		}
 	}
 
-	// Update nodes if needed
-	if rp.nodesTable.ShouldUpdate() {
-		go func() {
-			rp.nodesTable.Update()
-		}()
-	}
-
-	return rp.nodesTable.Render()
+	trends := rp.renderDetailTrends(
+		rp.renderTrend("Nodes ready", history.NodesReady, func(v int64) string { return fmt.Sprintf("%d", v) }, "46"),
+		rp.renderTrend("CPU usage", history.CPUUsage, k8s.FormatMilliCPU, "39"),
+		rp.renderTrend("Memory usage", history.MemUsage, k8s.FormatBytes, "39"),
+	)
+	return trends + rp.nodesTable.Render(rp.Width, rp.tableHeight(trends))
 }
 
 func (rp *RightPane) renderEvents() string {
 	if rp.eventsTable == nil {
-		if rp.KubeConfig != nil {
-			rp.eventsTable = NewEventsTable(rp.KubeConfig, rp.KubeConfig.CurrentContext)
-			// Trigger initial load immediately for first time
-			go func() {
-				rp.eventsTable.Update()
-			}()
-		} else {
+		if rp.eventWatcher == nil {
 			return styles.NormalStyle.Render("Kubernetes configuration not available")
 		}
+		rp.eventsTable = NewEventsTable(rp.eventWatcher)
+		rp.eventsTable.SetArchive(rp.eventArchive)
+		rp.eventsTable.SetFilter(rp.eventFilter)
+		rp.restoreSort(viewEvents, rp.eventsTable.table)
 	}
 
-	// Update events if needed (background updates)
+	// Snapshotting the watcher's buffer is cheap, so refresh inline
 	if rp.eventsTable.ShouldUpdate() {
-		go func() {
-			rp.eventsTable.Update()
-		}()
+		rp.eventsTable.Update()
 	}
 
-	return rp.eventsTable.Render()
+	return rp.eventsTable.Render(rp.Width, rp.tableHeight(""))
 }
 
-func (rp *RightPane) UpdateNodes() {
-	if rp.nodesTable != nil {
-		go func() {
-			rp.nodesTable.Update()
-		}()
+// CycleSort sorts the table of the selected view by its next column, or flips the direction when
+// reverse is set, and remembers the order for the view
+func (rp *RightPane) CycleSort(reverse bool) error {
+	view, table := rp.selectedTable()
+	if table == nil {
+		return nil
+	}
+	if reverse {
+		table.ReverseSort()
+	} else {
+		table.CycleSort()
+	}
+
+	if rp.settings == nil {
+		return nil
+	}
+	column, descending := table.SortOrder()
+	if column == "" {
+		delete(rp.settings.TableSorts, view)
+	} else {
+		if rp.settings.TableSorts == nil {
+			rp.settings.TableSorts = make(map[string]settings.TableSort)
+		}
+		rp.settings.TableSorts[view] = settings.TableSort{Column: column, Descending: descending}
 	}
+	return rp.settings.Save()
 }
 
-func (rp *RightPane) UpdateEvents() {
-	if rp.eventsTable != nil {
-		go func() {
-			rp.eventsTable.Update()
-		}()
+// selectedTable returns the table of the selected view and the name its settings are kept under
+func (rp *RightPane) selectedTable() (string, *Table) {
+	item := strings.ToLower(rp.SelectedItem)
+	switch {
+	case strings.Contains(item, "applications") && rp.applicationsTable != nil:
+		return viewApplications, rp.applicationsTable.table
+	case strings.Contains(item, "pods") && rp.podsTable != nil:
+		return viewPods, rp.podsTable.table
+	case strings.Contains(item, "nodes") && rp.nodesTable != nil:
+		return viewNodes, rp.nodesTable.table
+	case strings.Contains(item, "events") && rp.eventsTable != nil:
+		return viewEvents, rp.eventsTable.table
+	}
+	return "", nil
+}
+
+// restoreSort sorts a new table the way it was sorted last time
+func (rp *RightPane) restoreSort(view string, table *Table) {
+	if rp.settings == nil {
+		return
+	}
+	if sort, ok := rp.settings.TableSorts[view]; ok {
+		table.SetSortOrder(sort.Column, sort.Descending)
+	}
+}
+
+// tableHeight is the height left for a detail view below the pane header and the text above it
+func (rp *RightPane) tableHeight(above string) int {
+	if rp.Height <= 0 {
+		return 0
+	}
+	// The header is the selected item and a blank line
+	return remainingHeight(rp.Height-2, above)
+}
+
+// renderDetailTrends renders the non-empty trend lines above a detail table
+func (rp *RightPane) renderDetailTrends(lines ...string) string {
+	var rendered []string
+	for _, line := range lines {
+		if line != "" {
+			rendered = append(rendered, line)
+		}
+	}
+	if len(rendered) == 0 {
+		return ""
+	}
+	return strings.Join(rendered, "\n") + "\n\n"
+}
+
+// UpdateNodes returns a command that lists the nodes again, or nil while they are being listed
+func (rp *RightPane) UpdateNodes() tea.Cmd {
+	if rp.nodesTable == nil {
+		return nil
+	}
+	return rp.nodesTable.Load()
+}
+
+// FinishNodesLoad hands listed nodes to the nodes view
+func (rp *RightPane) FinishNodesLoad(msg NodesLoadedMsg) {
+	if rp.nodesTable != nil {
+		rp.nodesTable.FinishLoad(msg)
 	}
 }
 
@@ -468,37 +908,81 @@ func (rp *RightPane) renderApplications() string {
 	if rp.applicationsTable == nil {
 		if rp.KubeConfig != nil {
 			rp.applicationsTable = NewApplicationsTable(rp.KubeConfig, rp.KubeConfig.CurrentContext, "")
-			// Trigger initial load immediately for first time
-			go func() {
-				rp.applicationsTable.Update()
-			}()
+			rp.restoreSort(viewApplications, rp.applicationsTable.table)
 		} else {
 			return styles.NormalStyle.Render("Kubernetes configuration not available")
 		}
 	}
 
-	// Update applications if needed (background updates)
-	if rp.applicationsTable.ShouldUpdate() {
-		go func() {
-			rp.applicationsTable.Update()
-		}()
+	return rp.applicationsTable.Render(rp.Width, rp.tableHeight(""))
+}
+
+// UpdateApplications returns a command that lists the applications again, or nil while they are
+// being listed
+func (rp *RightPane) UpdateApplications() tea.Cmd {
+	if rp.applicationsTable == nil {
+		return nil
 	}
+	return rp.applicationsTable.Load()
+}
 
-	return rp.applicationsTable.Render()
+// FinishApplicationsLoad hands listed applications to the applications view
+func (rp *RightPane) FinishApplicationsLoad(msg ApplicationsLoadedMsg) {
+	if rp.applicationsTable != nil {
+		rp.applicationsTable.FinishLoad(msg)
+	}
+}
+
+// SetApplicationsSelector lists only the applications matching a label and field selector; the
+// returned command lists them
+func (rp *RightPane) SetApplicationsSelector(query string) (tea.Cmd, error) {
+	if rp.applicationsTable == nil {
+		return nil, nil
+	}
+	if err := rp.applicationsTable.SetSelector(query); err != nil {
+		return nil, err
+	}
+	return rp.UpdateApplications(), nil
 }
 
-func (rp *RightPane) UpdateApplications() {
+// GetApplicationsSelector returns the selector the applications are listed with
+func (rp *RightPane) GetApplicationsSelector() string {
 	if rp.applicationsTable != nil {
-		go func() {
-			rp.applicationsTable.Update()
-		}()
+		return rp.applicationsTable.GetSelector()
 	}
+	return ""
 }
 
 func (rp *RightPane) GetApplicationsTable() *ApplicationsTable {
 	return rp.applicationsTable
 }
 
+func (rp *RightPane) MoveApplicationsUp() {
+	if rp.applicationsTable != nil {
+		rp.applicationsTable.MoveUp()
+	}
+}
+
+func (rp *RightPane) MoveApplicationsDown() {
+	if rp.applicationsTable != nil {
+		rp.applicationsTable.MoveDown()
+	}
+}
+
+// SelectApplication puts the applications table cursor on an application
+func (rp *RightPane) SelectApplication(appType, namespace, name string) {
+	if rp.applicationsTable != nil {
+		rp.applicationsTable.SelectApplication(appType, namespace, name)
+	}
+}
+
+func (rp *RightPane) GetSelectedApplication() *k8s.ApplicationInfo {
+	if rp.applicationsTable != nil {
+		return rp.applicationsTable.GetSelectedApplication()
+	}
+	return nil
+}
+
 func (rp *RightPane) SetNamespace(namespace string) {
 	// Update all tables with the new namespace
 	if rp.applicationsTable != nil {
@@ -515,30 +999,33 @@ func (rp *RightPane) renderPods() string {
 		if rp.KubeConfig != nil {
 			// Initialize with empty namespace (will be set by namespace selector)
 			rp.podsTable = NewPodsTable(rp.KubeConfig, rp.KubeConfig.CurrentContext, "")
-			// Trigger initial load immediately for first time
-			go func() {
-				rp.podsTable.Update()
-			}()
+			rp.restoreSort(viewPods, rp.podsTable.table)
 		} else {
 			return styles.NormalStyle.Render("Kubernetes configuration not available")
 		}
 	}
 
-	// Update pods if needed (background updates)
-	if rp.podsTable.ShouldUpdate() {
-		go func() {
-			rp.podsTable.Update()
-		}()
-	}
+	formatCount := func(v int64) string { return fmt.Sprintf("%d", v) }
+	trends := rp.renderDetailTrends(
+		rp.renderTrend("Pods running", history.PodsRunning, formatCount, "46"),
+		rp.renderTrend("Pods pending", history.PodsPending, formatCount, "226"),
+		rp.renderTrend("Pods failed", history.PodsFailed, formatCount, "196"),
+	)
+	return trends + rp.podsTable.Render(rp.Width, rp.tableHeight(trends))
+}
 
-	return rp.podsTable.Render()
+// UpdatePods returns a command that lists the pods again, or nil while they are being listed
+func (rp *RightPane) UpdatePods() tea.Cmd {
+	if rp.podsTable == nil {
+		return nil
+	}
+	return rp.podsTable.Load()
 }
 
-func (rp *RightPane) UpdatePods() {
+// FinishPodsLoad hands listed pods to the pods view
+func (rp *RightPane) FinishPodsLoad(msg PodsLoadedMsg) {
 	if rp.podsTable != nil {
-		go func() {
-			rp.podsTable.Update()
-		}()
+		rp.podsTable.FinishLoad(msg)
 	}
 }
 
@@ -560,6 +1047,41 @@ func (rp *RightPane) TogglePodsSearch() {
 	}
 }
 
+// FinishPodsSearch stops typing the pods search and keeps filtering by it
+func (rp *RightPane) FinishPodsSearch() {
+	if rp.podsTable != nil {
+		rp.podsTable.FinishSearch()
+	}
+}
+
+// GetPodsSearchQuery returns the pods search being typed
+func (rp *RightPane) GetPodsSearchQuery() string {
+	if rp.podsTable != nil {
+		return rp.podsTable.GetSearchQuery()
+	}
+	return ""
+}
+
+// SetPodsSelector lists only the pods matching a label and field selector; the returned command
+// lists them
+func (rp *RightPane) SetPodsSelector(query string) (tea.Cmd, error) {
+	if rp.podsTable == nil {
+		return nil, nil
+	}
+	if err := rp.podsTable.SetSelector(query); err != nil {
+		return nil, err
+	}
+	return rp.UpdatePods(), nil
+}
+
+// GetPodsSelector returns the selector the pods are listed with
+func (rp *RightPane) GetPodsSelector() string {
+	if rp.podsTable != nil {
+		return rp.podsTable.GetSelector()
+	}
+	return ""
+}
+
 func (rp *RightPane) UpdatePodsSearch(query string) {
 	if rp.podsTable != nil {
 		rp.podsTable.UpdateSearch(query)
@@ -578,6 +1100,61 @@ func (rp *RightPane) MovePodsDown() {
 	}
 }
 
+// TogglePodMark marks the pod under the cursor for a bulk action, or unmarks it
+func (rp *RightPane) TogglePodMark() {
+	if rp.podsTable != nil {
+		rp.podsTable.ToggleMark()
+	}
+}
+
+// MarkAllPods marks every pod the search and selector show, or unmarks them if all are marked
+func (rp *RightPane) MarkAllPods() {
+	if rp.podsTable != nil {
+		rp.podsTable.MarkAllFiltered()
+	}
+}
+
+// ClearPodMarks unmarks every pod
+func (rp *RightPane) ClearPodMarks() {
+	if rp.podsTable != nil {
+		rp.podsTable.ClearMarks()
+	}
+}
+
+// GetMarkedPods returns the pods marked for a bulk action
+func (rp *RightPane) GetMarkedPods() []k8s.PodInfo {
+	if rp.podsTable != nil {
+		return rp.podsTable.GetMarkedPods()
+	}
+	return nil
+}
+
+// SelectPod puts the pods table cursor on a pod
+func (rp *RightPane) SelectPod(namespace, name string) {
+	if rp.podsTable != nil {
+		rp.podsTable.SelectPod(namespace, name)
+	}
+}
+
+func (rp *RightPane) MoveNodesUp() {
+	if rp.nodesTable != nil {
+		rp.nodesTable.MoveUp()
+	}
+}
+
+func (rp *RightPane) MoveNodesDown() {
+	if rp.nodesTable != nil {
+		rp.nodesTable.MoveDown()
+	}
+}
+
+// SelectNode puts the nodes table cursor on a node
+func (rp *RightPane) SelectNode(name string) {
+	if rp.nodesTable != nil {
+		rp.nodesTable.SelectNode(name)
+	}
+}
+
 func (rp *RightPane) GetSelectedPod() *k8s.PodInfo {
 	if rp.podsTable != nil {
 		return rp.podsTable.GetSelectedPod()
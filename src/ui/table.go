@@ -0,0 +1,580 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"peek/src/styles"
+)
+
+// Column describes a table column. A column is as wide as its widest cell, but never narrower
+// than Width or wider than MaxWidth. When the columns do not fit the pane, the Flex columns give
+// up width down to Width, in proportion to their Flex.
+type Column struct {
+	Title    string
+	Width    int
+	MaxWidth int // 0 for no limit
+	Flex     int
+}
+
+// TableRow is one row of a table. The key identifies the row across refreshes, so the cursor and
+// the selection stay on it when rows are added, removed or reordered.
+type TableRow struct {
+	Key   string
+	Cells []string
+	Style lipgloss.Style
+	// Values are what the cells sort by: numbers, durations, times or strings. Cells without a
+	// value sort by their text, after the cells that have one.
+	Values []any
+	// Child rows belong to the row above them and move with it when the table is sorted
+	Child bool
+}
+
+// Table renders rows under column headers, sized to the pane it is drawn in, and keeps track of
+// the cursor, the scroll position and the selected rows
+type Table struct {
+	name       string // what the rows are, for the scroll indicator
+	columns    []Column
+	source     []TableRow // rows in the order they were set
+	rows       []TableRow // rows in the order they are shown
+	cursor     int
+	offset     int
+	showCursor bool
+	selected   map[string]bool
+	// pendingKey is a row to put the cursor on once it is loaded
+	pendingKey string
+	// sortColumn is the title of the column the rows are sorted by, "" for the order they were set in
+	sortColumn string
+	sortDesc   bool
+}
+
+func NewTable(name string, columns []Column) *Table {
+	return &Table{
+		name:       name,
+		columns:    columns,
+		showCursor: true,
+		selected:   make(map[string]bool),
+	}
+}
+
+// SetColumns replaces the columns, for tables whose layout depends on their mode
+func (t *Table) SetColumns(columns []Column) {
+	t.columns = columns
+}
+
+// ShowCursor turns the highlighted cursor row on or off; tables without a cursor show their first rows
+func (t *Table) ShowCursor(show bool) {
+	t.showCursor = show
+}
+
+// SetRows replaces the rows, keeping the cursor on the row it was on
+func (t *Table) SetRows(rows []TableRow) {
+	current := t.SelectedKey()
+	t.source = rows
+	t.rows = t.sorted()
+
+	if t.pendingKey != "" {
+		if t.SelectKey(t.pendingKey); t.pendingKey == "" {
+			return
+		}
+	}
+	for i, row := range t.rows {
+		if row.Key != "" && row.Key == current {
+			t.cursor = i
+			return
+		}
+	}
+	t.cursor = min(t.cursor, max(len(t.rows)-1, 0))
+}
+
+func (t *Table) Len() int {
+	return len(t.rows)
+}
+
+func (t *Table) Cursor() int {
+	return t.cursor
+}
+
+// SetCursor moves the cursor to a row index
+func (t *Table) SetCursor(index int) {
+	t.cursor = max(min(index, len(t.rows)-1), 0)
+}
+
+// SelectKey puts the cursor on the row with the key, waiting for the next SetRows if it is not there yet
+func (t *Table) SelectKey(key string) {
+	t.pendingKey = key
+	for i, row := range t.rows {
+		if row.Key == key {
+			t.cursor = i
+			t.pendingKey = ""
+			return
+		}
+	}
+}
+
+// SelectedKey returns the key of the row under the cursor, "" when the table is empty
+func (t *Table) SelectedKey() string {
+	if t.cursor < len(t.rows) {
+		return t.rows[t.cursor].Key
+	}
+	return ""
+}
+
+func (t *Table) MoveUp() {
+	if t.cursor > 0 {
+		t.cursor--
+	}
+}
+
+func (t *Table) MoveDown() {
+	if t.cursor < len(t.rows)-1 {
+		t.cursor++
+	}
+}
+
+// CycleSort sorts the rows by the next column; after the last column the rows go back to the
+// order they were set in
+func (t *Table) CycleSort() {
+	next := t.sortIndex() + 1
+	for next < len(t.columns) && t.columns[next].Title == "" {
+		next++
+	}
+	if next < len(t.columns) {
+		t.SetSortOrder(t.columns[next].Title, t.sortDesc)
+	} else {
+		t.SetSortOrder("", false)
+	}
+}
+
+// ReverseSort flips the sort direction, sorting by the first column when the rows are not sorted
+func (t *Table) ReverseSort() {
+	if t.sortIndex() < 0 {
+		t.CycleSort()
+	}
+	t.SetSortOrder(t.sortColumn, !t.sortDesc)
+}
+
+// SortOrder returns the title of the column the rows are sorted by, "" when they are not, and
+// whether the order is descending
+func (t *Table) SortOrder() (string, bool) {
+	return t.sortColumn, t.sortDesc
+}
+
+// SetSortOrder sorts the rows by the column with the title, keeping the cursor on its row
+func (t *Table) SetSortOrder(column string, descending bool) {
+	current := t.SelectedKey()
+	t.sortColumn = column
+	t.sortDesc = descending && column != ""
+	t.rows = t.sorted()
+	for i, row := range t.rows {
+		if row.Key != "" && row.Key == current {
+			t.cursor = i
+			return
+		}
+	}
+}
+
+// sortIndex returns the index of the sort column, -1 when the rows are not sorted or the current
+// columns do not have it
+func (t *Table) sortIndex() int {
+	if t.sortColumn == "" {
+		return -1
+	}
+	return slices.IndexFunc(t.columns, func(column Column) bool {
+		return column.Title == t.sortColumn
+	})
+}
+
+// sorted returns the rows in the sort order, child rows staying under their parent
+func (t *Table) sorted() []TableRow {
+	column := t.sortIndex()
+	if column < 0 {
+		return t.source
+	}
+
+	// Sort blocks of a row and its children by that row
+	var blocks [][]TableRow
+	for i, row := range t.source {
+		if row.Child && len(blocks) > 0 {
+			blocks[len(blocks)-1] = append(blocks[len(blocks)-1], row)
+			continue
+		}
+		blocks = append(blocks, []TableRow{t.source[i]})
+	}
+	slices.SortStableFunc(blocks, func(a, b []TableRow) int {
+		return compareRows(a[0], b[0], column, t.sortDesc)
+	})
+	return slices.Concat(blocks...)
+}
+
+// compareRows orders two rows by a column. Cells without a value go last in either direction.
+func compareRows(a, b TableRow, column int, descending bool) int {
+	av, aTyped := sortValue(a, column)
+	bv, bTyped := sortValue(b, column)
+	if aTyped != bTyped {
+		if aTyped {
+			return -1
+		}
+		return 1
+	}
+
+	c := compareValues(av, bv)
+	if descending {
+		return -c
+	}
+	return c
+}
+
+// sortValue returns the value a cell sorts by and whether it is typed, or else its text
+func sortValue(row TableRow, column int) (any, bool) {
+	if column < len(row.Values) && row.Values[column] != nil {
+		return row.Values[column], true
+	}
+	if column < len(row.Cells) {
+		return row.Cells[column], false
+	}
+	return "", false
+}
+
+// compareValues compares two sort values of the same type; strings compare case-insensitively
+func compareValues(a, b any) int {
+	switch av := a.(type) {
+	case int:
+		return compareAs(av, b)
+	case int32:
+		return compareAs(av, b)
+	case int64:
+		return compareAs(av, b)
+	case float64:
+		return compareAs(av, b)
+	case time.Duration:
+		return compareAs(av, b)
+	case time.Time:
+		if bv, ok := b.(time.Time); ok {
+			return av.Compare(bv)
+		}
+	case string:
+		if bv, ok := b.(string); ok {
+			return strings.Compare(strings.ToLower(av), strings.ToLower(bv))
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func compareAs[T cmp.Ordered](a T, b any) int {
+	if bv, ok := b.(T); ok {
+		return cmp.Compare(a, bv)
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// ToggleSelected adds the row under the cursor to the selection, or removes it
+func (t *Table) ToggleSelected() {
+	key := t.SelectedKey()
+	if key == "" {
+		return
+	}
+	if t.selected[key] {
+		delete(t.selected, key)
+	} else {
+		t.selected[key] = true
+	}
+}
+
+// SelectAll adds every row to the selection
+func (t *Table) SelectAll() {
+	for _, row := range t.rows {
+		if row.Key != "" {
+			t.selected[row.Key] = true
+		}
+	}
+}
+
+// SetSelected adds a row to the selection or removes it
+func (t *Table) SetSelected(key string, selected bool) {
+	if selected {
+		t.selected[key] = true
+	} else {
+		delete(t.selected, key)
+	}
+}
+
+func (t *Table) ClearSelection() {
+	clear(t.selected)
+}
+
+func (t *Table) IsSelected(key string) bool {
+	return t.selected[key]
+}
+
+// Selected returns the keys of the selected rows, in row order; keys of rows that are gone are dropped
+func (t *Table) Selected() []string {
+	var keys []string
+	for _, row := range t.rows {
+		if t.selected[row.Key] {
+			keys = append(keys, row.Key)
+		}
+	}
+	return keys
+}
+
+// Render draws the header and as many rows as fit in width by height, scrolled to keep the cursor
+// in view. A zero height shows every row.
+func (t *Table) Render(width, height int) string {
+	var b strings.Builder
+
+	// Selected rows are marked in an extra column while there is a selection
+	marker := len(t.Selected()) > 0
+	widths := t.columnWidths(width, marker)
+
+	headerStyle := styles.NormalStyle.Bold(true).Underline(true)
+	titles := t.titles()
+	header := t.formatRow(titles, widths, width)
+	if marker {
+		header = t.formatRow(append([]string{""}, titles...), widths, width)
+	}
+	b.WriteString(headerStyle.Render(header))
+
+	start, end := t.visibleRange(height - 1)
+	for i := start; i < end; i++ {
+		row := t.rows[i]
+
+		cells := row.Cells
+		if marker {
+			check := " "
+			if t.selected[row.Key] {
+				check = "✓"
+			}
+			cells = append([]string{check}, cells...)
+		}
+
+		rowStyle := row.Style
+		if t.selected[row.Key] {
+			rowStyle = rowStyle.Background(lipgloss.Color("236"))
+		}
+		// Highlight the row under the cursor
+		if t.showCursor && i == t.cursor {
+			rowStyle = rowStyle.Background(lipgloss.Color("237")).Bold(true)
+		}
+
+		b.WriteString("\n" + rowStyle.Render(t.formatRow(cells, widths, width)))
+	}
+
+	// Show scroll indicator if needed
+	if end-start < len(t.rows) {
+		scrollStyle := styles.NormalStyle.Foreground(lipgloss.Color("240"))
+		scrollInfo := fmt.Sprintf("Showing %d-%d of %d %s", start+1, end, len(t.rows), t.name)
+		if t.showCursor {
+			scrollInfo += " (use ↑↓ to navigate)"
+		}
+		b.WriteString("\n" + scrollStyle.Render(scrollInfo))
+	}
+
+	return b.String()
+}
+
+// titles returns the column titles, the sort column marked with the sort direction
+func (t *Table) titles() []string {
+	sortIndex := t.sortIndex()
+	titles := make([]string, len(t.columns))
+	for i, column := range t.columns {
+		titles[i] = column.Title
+		if i == sortIndex {
+			if t.sortDesc {
+				titles[i] += " ▼"
+			} else {
+				titles[i] += " ▲"
+			}
+		}
+	}
+	return titles
+}
+
+// visibleRange returns the rows that fit in the given number of lines, scrolled to the cursor
+func (t *Table) visibleRange(lines int) (int, int) {
+	if lines <= 0 || len(t.rows) <= lines {
+		t.offset = 0
+		return 0, len(t.rows)
+	}
+
+	// One line goes to the scroll indicator
+	visible := max(lines-1, 1)
+	if !t.showCursor {
+		return 0, visible
+	}
+
+	if t.cursor < t.offset {
+		t.offset = t.cursor
+	}
+	if t.cursor >= t.offset+visible {
+		t.offset = t.cursor - visible + 1
+	}
+	t.offset = max(min(t.offset, len(t.rows)-visible), 0)
+	return t.offset, t.offset + visible
+}
+
+// columnWidths sizes the columns to their cells and shrinks the flexible ones to fit the width
+func (t *Table) columnWidths(width int, marker bool) []int {
+	widths := make([]int, len(t.columns))
+	natural := make([]int, len(t.columns))
+	titles := t.titles()
+	for i, column := range t.columns {
+		natural[i] = ansi.StringWidth(titles[i])
+		for _, row := range t.rows {
+			if i < len(row.Cells) {
+				natural[i] = max(natural[i], ansi.StringWidth(row.Cells[i]))
+			}
+		}
+		if column.MaxWidth > 0 {
+			natural[i] = min(natural[i], column.MaxWidth)
+		}
+		natural[i] = max(natural[i], column.Width)
+
+		widths[i] = natural[i]
+		if column.Flex > 0 {
+			widths[i] = column.Width
+		}
+	}
+
+	// Share the width left after the fixed columns and the minimum widths between the flexible
+	// columns, until each is as wide as its cells
+	spare := width - (len(t.columns) - 1)
+	if marker {
+		spare -= 2
+	}
+	for _, w := range widths {
+		spare -= w
+	}
+	for spare > 0 {
+		weight := 0
+		for i, column := range t.columns {
+			if widths[i] < natural[i] {
+				weight += column.Flex
+			}
+		}
+		if weight == 0 {
+			break
+		}
+
+		remaining := spare
+		for i, column := range t.columns {
+			if widths[i] >= natural[i] || column.Flex == 0 || spare == 0 {
+				continue
+			}
+			grow := min(min(max(remaining*column.Flex/weight, 1), natural[i]-widths[i]), spare)
+			widths[i] += grow
+			spare -= grow
+		}
+	}
+
+	if marker {
+		widths = append([]int{1}, widths...)
+	}
+	return widths
+}
+
+// formatRow pads or truncates each cell to its column and cuts the row at the pane width
+func (t *Table) formatRow(cells []string, widths []int, width int) string {
+	parts := make([]string, len(widths))
+	for i, w := range widths {
+		var cell string
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		if i == len(widths)-1 {
+			parts[i] = fitCell(cell, w, false)
+		} else {
+			parts[i] = fitCell(cell, w, true)
+		}
+	}
+
+	row := strings.Join(parts, " ")
+	if width > 0 {
+		row = ansi.Truncate(row, width, "")
+	}
+	return row
+}
+
+// fitCell truncates a cell to the width and, when pad is set, fills it up with spaces
+func fitCell(cell string, width int, pad bool) string {
+	if ansi.StringWidth(cell) > width {
+		tail := "..."
+		if width <= len(tail) {
+			tail = ""
+		}
+		cell = ansi.Truncate(cell, width, tail)
+	}
+	if pad {
+		cell += strings.Repeat(" ", max(width-ansi.StringWidth(cell), 0))
+	}
+	return cell
+}
+
+// tableData is the loading state the tables share: when their data was last loaded, whether a
+// load is running and why the last one failed
+type tableData struct {
+	lastUpdate time.Time
+	isLoading  bool
+	running    bool // a load is in flight, so refreshes do not start another
+	error      error
+}
+
+// startLoad marks a load as running; the loading message only replaces tables with nothing to show
+func (d *tableData) startLoad(empty bool) {
+	if empty {
+		d.isLoading = true
+	}
+	d.running = true
+	d.error = nil
+}
+
+// finishLoad records the outcome of a load
+func (d *tableData) finishLoad(err error) {
+	d.isLoading = false
+	d.running = false
+	d.error = err
+	if err == nil {
+		d.lastUpdate = time.Now()
+	}
+}
+
+// invalidate forces a refresh on the next update check, without waiting for the load in flight
+func (d *tableData) invalidate() {
+	d.lastUpdate = time.Time{}
+	d.running = false
+}
+
+// renderState renders the loading or error message shown instead of a table, "" when there is none
+func (d *tableData) renderState(resource string, empty bool) string {
+	// Only show loading screen if we have no data AND it's the initial load
+	if d.isLoading && empty && d.lastUpdate.IsZero() {
+		return styles.NormalStyle.Render(fmt.Sprintf("Loading %s...", resource))
+	}
+
+	if d.error != nil {
+		errorStyle := styles.NormalStyle.Foreground(lipgloss.Color("196"))
+		return errorStyle.Render(fmt.Sprintf("Error loading %s: %v", resource, d.error))
+	}
+	return ""
+}
+
+// refreshMarker is a subtle updating indicator, shown only while reloading data already on screen
+func (d *tableData) refreshMarker(empty bool) string {
+	if d.isLoading && !empty {
+		return " ●"
+	}
+	return ""
+}
+
+// remainingHeight is how many lines of height are left below the rendered text above
+func remainingHeight(height int, above string) int {
+	if height <= 0 {
+		return 0
+	}
+	return max(height-strings.Count(above, "\n"), 2)
+}
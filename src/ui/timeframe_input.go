@@ -11,6 +11,7 @@ type TimeframeInput struct {
 	isOpen      bool
 	input       string
 	placeholder string
+	hint        string
 	title       string
 	width       int
 	height      int
@@ -20,10 +21,11 @@ func NewTimeframeInput() *TimeframeInput {
 	return &TimeframeInput{
 		isOpen:      false,
 		input:       "",
-		placeholder: "Enter minutes (e.g., 30)",
+		placeholder: "Enter minutes or a duration (e.g., 30, 6h, 3d)",
+		hint:        "Or a range: 2026-10-17 14:00..16:00",
 		title:       "Change Timeframe",
-		width:       50,
-		height:      6,
+		width:       60,
+		height:      8,
 	}
 }
 
@@ -50,8 +52,8 @@ func (ti *TimeframeInput) SetInput(input string) {
 }
 
 func (ti *TimeframeInput) AddChar(char string) {
-	// Only allow numeric input
-	if char >= "0" && char <= "9" {
+	// Durations and ranges need letters, separators and spaces besides digits
+	if len(char) == 1 && char[0] >= ' ' && char[0] < 0x7f {
 		ti.input += char
 	}
 }
@@ -101,6 +103,10 @@ func (ti *TimeframeInput) Render(screenWidth, screenHeight int) string {
 	}
 
 	content.WriteString(inputFieldStyle.Render(displayText))
+	content.WriteString("\n")
+
+	hintStyle := styles.NormalStyle.Foreground(lipgloss.Color("240"))
+	content.WriteString(hintStyle.Render(ti.hint))
 	content.WriteString("\n\n")
 
 	// Instructions
@@ -13,6 +13,7 @@ type YAMLViewer struct {
 	isOpen       bool
 	podName      string
 	namespace    string
+	object       *k8s.ObjectReference // set when viewing an object other than a pod
 	kubeConfig   *k8s.KubeConfig
 	contextName  string
 	yamlContent  string
@@ -31,6 +32,17 @@ func NewYAMLViewer() *YAMLViewer {
 }
 
 func (yv *YAMLViewer) Open(kubeConfig *k8s.KubeConfig, contextName, namespace, podName string) {
+	yv.object = nil
+	yv.open(kubeConfig, contextName, namespace, podName)
+}
+
+// OpenObject shows the YAML of any object, e.g. the involved object of an event
+func (yv *YAMLViewer) OpenObject(kubeConfig *k8s.KubeConfig, contextName string, ref k8s.ObjectReference) {
+	yv.object = &ref
+	yv.open(kubeConfig, contextName, ref.Namespace, ref.Name)
+}
+
+func (yv *YAMLViewer) open(kubeConfig *k8s.KubeConfig, contextName, namespace, podName string) {
 	yv.isOpen = true
 	yv.podName = podName
 	yv.namespace = namespace
@@ -93,7 +105,13 @@ func (yv *YAMLViewer) PageDown() {
 }
 
 func (yv *YAMLViewer) fetchYAML() {
-	yaml, err := yv.kubeConfig.GetPodYAML(yv.contextName, yv.namespace, yv.podName)
+	var yaml string
+	var err error
+	if yv.object != nil {
+		yaml, err = yv.kubeConfig.GetResourceYAML(yv.contextName, *yv.object)
+	} else {
+		yaml, err = yv.kubeConfig.GetPodYAML(yv.contextName, yv.namespace, yv.podName)
+	}
 	if err != nil {
 		yv.error = err
 	} else {
@@ -122,11 +140,17 @@ func (yv *YAMLViewer) Render(screenWidth, screenHeight int) string {
 	// Header
 	headerStyle := styles.NormalStyle.Bold(true).Foreground(lipgloss.Color("39"))
 	title := fmt.Sprintf("📄 YAML: %s", yv.podName)
+	if yv.object != nil {
+		title = fmt.Sprintf("📄 YAML: %s/%s", yv.object.Kind, yv.object.Name)
+	}
 	content.WriteString(headerStyle.Render(title) + "\n")
 
 	// Status line
 	statusStyle := styles.NormalStyle.Foreground(lipgloss.Color("245"))
 	status := fmt.Sprintf("Namespace: %s", yv.namespace)
+	if yv.namespace == "" {
+		status = "Cluster-scoped"
+	}
 	content.WriteString(statusStyle.Render(status) + "\n")
 
 	// Controls